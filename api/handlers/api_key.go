@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	ErrorCodeUnauthorized       = "unauthorized"
+	ErrorCodeEndpointNotAllowed = "endpoint_not_allowed"
+
+	requestTenantKey = "go-qris/tenant"
+)
+
+type TenantStoreInterface interface {
+	Tenant(apiKey string) (*entities.Tenant, bool)
+}
+
+type APIKey struct {
+	tenants TenantStoreInterface
+}
+
+type APIKeyInterface interface {
+	Authenticate(c *gin.Context)
+	Authorize(endpoint string) gin.HandlerFunc
+}
+
+// NewAPIKey authenticates requests against tenants, a nil store leaves the
+// API open.
+func NewAPIKey(tenants TenantStoreInterface) APIKeyInterface {
+	return &APIKey{
+		tenants: tenants,
+	}
+}
+
+// Authenticate resolves the tenant of a known API key without rejecting
+// anything, so the rate limiter can tell tenants apart before Authorize runs.
+func (h *APIKey) Authenticate(c *gin.Context) {
+	if h.tenants == nil {
+		return
+	}
+	if _, ok := RequestTenant(c); ok {
+		return
+	}
+
+	apiKey := requestAPIKey(c)
+	tenant, exists := h.tenants.Tenant(apiKey)
+	if apiKey == "" || !exists {
+		return
+	}
+	c.Set(requestLoggerKey, RequestLogger(c).With("tenant", tenant.Name))
+	c.Set(requestTenantKey, tenant)
+}
+
+// Authorize requires an API key, sent as X-API-Key or a bearer token, whose
+// tenant may call endpoint, and a publishable key to come from one of the
+// tenant's origins.
+func (h *APIKey) Authorize(endpoint string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.tenants == nil {
+			return
+		}
+
+		h.Authenticate(c)
+		tenant, ok := RequestTenant(c)
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="go-qris"`)
+			abort(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "missing or unknown API key")
+			return
+		}
+
+		if !tenant.AllowsEndpoint(endpoint) {
+			RequestLogger(c).Warn("Endpoint not allowed", "endpoint", endpoint)
+			abort(c, http.StatusForbidden, ErrorCodeEndpointNotAllowed, fmt.Sprintf("endpoint %s is not allowed for this API key", endpoint))
+			return
+		}
+
+		if origin := c.GetHeader("Origin"); !tenant.AllowsOrigin(origin) {
+			RequestLogger(c).Warn("Origin not allowed", "origin", origin)
+			abort(c, http.StatusForbidden, ErrorCodeOriginNotAllowed, "origin is not allowed for this API key")
+		}
+	}
+}
+
+// requestAPIKey reads the X-API-Key header or a bearer token.
+func requestAPIKey(c *gin.Context) string {
+	if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+		return apiKey
+	}
+	if apiKey, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found {
+		return apiKey
+	}
+
+	return ""
+}
+
+// RequestTenant returns the tenant of the current request, if authenticated.
+func RequestTenant(c *gin.Context) (*entities.Tenant, bool) {
+	tenant, ok := c.Value(requestTenantKey).(*entities.Tenant)
+	return tenant, ok
+}
+
+func abort(c *gin.Context, status int, code string, message string) {
+	c.AbortWithStatusJSON(status, Response{
+		Success: false,
+		Code:    code,
+		Message: message,
+		Errors:  nil,
+		Data:    nil,
+	})
+}
+
+// Chain runs handlers in order until one of them aborts, for routes that take
+// a single handler such as the Dispatch methods.
+func Chain(handlers ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, handler := range handlers {
+			if c.IsAborted() {
+				return
+			}
+			handler(c)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAPIKeyAuthorize(t *testing.T) {
+	testAPIKey := "0123456789abcdef"
+	testTenant := &entities.Tenant{
+		Name:             "warung",
+		AllowedNMIDs:     []string{"ID1020017611473"},
+		AllowedEndpoints: []string{"parse"},
+	}
+	testPublishableKey := "publishable-0123456789"
+	testPublishableTenant := &entities.Tenant{
+		Name:             "warung",
+		AllowedOrigins:   []string{"https://warung.example.com"},
+		AllowedNMIDs:     []string{"ID1020017611473"},
+		AllowedEndpoints: []string{"convert"},
+		Publishable:      true,
+	}
+	tenants := &mockTenantStore{
+		TenantFunc: func(apiKey string) (*entities.Tenant, bool) {
+			switch apiKey {
+			case testAPIKey:
+				return testTenant, true
+			case testPublishableKey:
+				return testPublishableTenant, true
+			}
+			return nil, false
+		},
+	}
+
+	type want struct {
+		code     int
+		response string
+		tenant   *entities.Tenant
+	}
+
+	tests := []struct {
+		name     string
+		tenants  TenantStoreInterface
+		endpoint string
+		headers  map[string]string
+		want     want
+	}{
+		{
+			name:     "Success: Open Without Tenants",
+			tenants:  nil,
+			endpoint: "convert",
+			want: want{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name:     "Error: Missing API Key",
+			tenants:  tenants,
+			endpoint: "parse",
+			want: want{
+				code:     http.StatusUnauthorized,
+				response: `"code":"unauthorized"`,
+			},
+		},
+		{
+			name:     "Error: Unknown API Key",
+			tenants:  tenants,
+			endpoint: "parse",
+			headers:  map[string]string{APIKeyHeader: "fedcba9876543210"},
+			want: want{
+				code:     http.StatusUnauthorized,
+				response: `"code":"unauthorized"`,
+			},
+		},
+		{
+			name:     "Error: Basic Authorization",
+			tenants:  tenants,
+			endpoint: "parse",
+			headers:  map[string]string{"Authorization": "Basic " + testAPIKey},
+			want: want{
+				code:     http.StatusUnauthorized,
+				response: `"code":"unauthorized"`,
+			},
+		},
+		{
+			name:     "Error: Endpoint Not Allowed",
+			tenants:  tenants,
+			endpoint: "convert",
+			headers:  map[string]string{APIKeyHeader: testAPIKey},
+			want: want{
+				code:     http.StatusForbidden,
+				response: `"code":"endpoint_not_allowed"`,
+			},
+		},
+		{
+			name:     "Error: Publishable Key Without Origin",
+			tenants:  tenants,
+			endpoint: "convert",
+			headers:  map[string]string{APIKeyHeader: testPublishableKey},
+			want: want{
+				code:     http.StatusForbidden,
+				response: `"code":"origin_not_allowed"`,
+			},
+		},
+		{
+			name:     "Error: Publishable Key From Foreign Origin",
+			tenants:  tenants,
+			endpoint: "convert",
+			headers:  map[string]string{APIKeyHeader: testPublishableKey, "Origin": "https://evil.example.com"},
+			want: want{
+				code:     http.StatusForbidden,
+				response: `"code":"origin_not_allowed"`,
+			},
+		},
+		{
+			name:     "Success: Publishable Key From Allowed Origin",
+			tenants:  tenants,
+			endpoint: "convert",
+			headers:  map[string]string{APIKeyHeader: testPublishableKey, "Origin": "https://Warung.example.com"},
+			want: want{
+				code:   http.StatusNoContent,
+				tenant: testPublishableTenant,
+			},
+		},
+		{
+			name:     "Success: API Key From Any Origin",
+			tenants:  tenants,
+			endpoint: "parse",
+			headers:  map[string]string{APIKeyHeader: testAPIKey, "Origin": "https://evil.example.com"},
+			want: want{
+				code:   http.StatusNoContent,
+				tenant: testTenant,
+			},
+		},
+		{
+			name:     "Success: API Key Header",
+			tenants:  tenants,
+			endpoint: "parse",
+			headers:  map[string]string{APIKeyHeader: testAPIKey},
+			want: want{
+				code:   http.StatusNoContent,
+				tenant: testTenant,
+			},
+		},
+		{
+			name:     "Success: Bearer Token",
+			tenants:  tenants,
+			endpoint: "parse",
+			headers:  map[string]string{"Authorization": "Bearer " + testAPIKey},
+			want: want{
+				code:   http.StatusNoContent,
+				tenant: testTenant,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotTenant *entities.Tenant
+			controller := &mockQRISController{
+				WithTenantFunc: func(tenant *entities.Tenant) controllers.QRISInterface {
+					gotTenant = tenant
+					return nil
+				},
+			}
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/", NewAPIKey(test.tenants).Authorize(test.endpoint), func(c *gin.Context) {
+				requestController(c, controller)
+				c.Status(http.StatusNoContent)
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/", nil)
+			for key, value := range test.headers {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.want.code {
+				t.Errorf(expectedStatusCode, test.want.code, recorder.Code)
+			}
+			if !strings.Contains(recorder.Body.String(), test.want.response) {
+				t.Errorf(expectedResponseToContain, test.want.response, recorder.Body.String())
+			}
+			if gotTenant != test.want.tenant {
+				t.Errorf(expectedButGotMessage, "WithTenant()", test.want.tenant, gotTenant)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	calls := 0
+	handler := Chain(
+		func(c *gin.Context) {
+			calls++
+			c.AbortWithStatus(http.StatusForbidden)
+		},
+		func(c *gin.Context) {
+			calls++
+		},
+	)
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	handler(c)
+	if calls != 1 {
+		t.Errorf(expectedButGotMessage, "Chain() calls", 1, calls)
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ErrorCodeOriginNotAllowed = "origin_not_allowed"
+
+const (
+	corsAllowedMethods = "GET, POST"
+	corsAllowedHeaders = "Content-Type, Authorization, " + APIKeyHeader + ", " + RequestIDHeader
+	corsExposedHeaders = RequestIDHeader + ", Retry-After"
+	corsMaxAge         = "600"
+)
+
+type CORS struct {
+	allowedOrigins []string
+}
+
+type CORSInterface interface {
+	Middleware(c *gin.Context)
+}
+
+// NewCORS lets browsers on allowedOrigins, or any origin with "*", call the
+// API. Without origins no CORS headers are sent at all.
+func NewCORS(allowedOrigins []string) CORSInterface {
+	origins := make([]string, len(allowedOrigins))
+	for i, origin := range allowedOrigins {
+		origins[i] = normalizeOrigin(origin)
+	}
+
+	return &CORS{
+		allowedOrigins: origins,
+	}
+}
+
+// Middleware answers preflight requests itself and marks the responses of
+// allowed origins as readable.
+func (h *CORS) Middleware(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	if origin == "" || len(h.allowedOrigins) == 0 {
+		return
+	}
+
+	c.Writer.Header().Add("Vary", "Origin")
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+	anyOrigin := slices.Contains(h.allowedOrigins, "*")
+	if !anyOrigin && !slices.Contains(h.allowedOrigins, normalizeOrigin(origin)) {
+		if preflight {
+			abort(c, http.StatusForbidden, ErrorCodeOriginNotAllowed, "origin is not allowed")
+		}
+		return
+	}
+
+	allowOrigin := origin
+	if anyOrigin {
+		allowOrigin = "*"
+	}
+	c.Header("Access-Control-Allow-Origin", allowOrigin)
+	c.Header("Access-Control-Expose-Headers", corsExposedHeaders)
+	if preflight {
+		c.Header("Access-Control-Allow-Methods", corsAllowedMethods)
+		c.Header("Access-Control-Allow-Headers", corsAllowedHeaders)
+		c.Header("Access-Control-Max-Age", corsMaxAge)
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
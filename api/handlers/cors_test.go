@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowedOrigins  []string
+		method          string
+		headers         map[string]string
+		wantCode        int
+		wantAllowOrigin string
+		wantMethods     string
+	}{
+		{
+			name:     "Success: No Allowed Origins",
+			method:   http.MethodPost,
+			headers:  map[string]string{"Origin": "https://shop.example"},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:           "Success: No Origin",
+			allowedOrigins: []string{"https://shop.example"},
+			method:         http.MethodPost,
+			wantCode:       http.StatusNoContent,
+		},
+		{
+			name:            "Success: Allowed Origin",
+			allowedOrigins:  []string{"https://Shop.example/"},
+			method:          http.MethodPost,
+			headers:         map[string]string{"Origin": "https://shop.example"},
+			wantCode:        http.StatusNoContent,
+			wantAllowOrigin: "https://shop.example",
+		},
+		{
+			name:            "Success: Any Origin",
+			allowedOrigins:  []string{"*"},
+			method:          http.MethodPost,
+			headers:         map[string]string{"Origin": "https://shop.example"},
+			wantCode:        http.StatusNoContent,
+			wantAllowOrigin: "*",
+		},
+		{
+			name:            "Success: Preflight",
+			allowedOrigins:  []string{"https://shop.example"},
+			method:          http.MethodOptions,
+			headers:         map[string]string{"Origin": "https://shop.example", "Access-Control-Request-Method": http.MethodPost},
+			wantCode:        http.StatusNoContent,
+			wantAllowOrigin: "https://shop.example",
+			wantMethods:     corsAllowedMethods,
+		},
+		{
+			name:           "Success: Disallowed Origin",
+			allowedOrigins: []string{"https://shop.example"},
+			method:         http.MethodPost,
+			headers:        map[string]string{"Origin": "https://evil.example"},
+			wantCode:       http.StatusNoContent,
+		},
+		{
+			name:           "Error: Disallowed Preflight",
+			allowedOrigins: []string{"https://shop.example"},
+			method:         http.MethodOptions,
+			headers:        map[string]string{"Origin": "https://evil.example", "Access-Control-Request-Method": http.MethodPost},
+			wantCode:       http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(NewCORS(test.allowedOrigins).Middleware)
+			router.POST("/", func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+
+			request := httptest.NewRequest(test.method, "/", nil)
+			for key, value := range test.headers {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.wantCode {
+				t.Errorf(expectedStatusCode, test.wantCode, recorder.Code)
+			}
+			if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != test.wantAllowOrigin {
+				t.Errorf(expectedButGotMessage, "Access-Control-Allow-Origin", test.wantAllowOrigin, got)
+			}
+			if got := recorder.Header().Get("Access-Control-Allow-Methods"); got != test.wantMethods {
+				t.Errorf(expectedButGotMessage, "Access-Control-Allow-Methods", test.wantMethods, got)
+			}
+		})
+	}
+}
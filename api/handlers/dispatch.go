@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ErrorCodeNotFound = "not_found"
+
+// Dispatch routes custom methods such as "qris:parse" registered under a
+// single ":method" parameter, since gin can not tell colon paths apart.
+func Dispatch(methods map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handler, exists := methods[c.Param("method")]
+		if !exists {
+			c.JSON(http.StatusNotFound, Response{
+				Success: false,
+				Code:    ErrorCodeNotFound,
+				Message: fmt.Sprintf("unknown method %s", c.Param("method")),
+				Errors:  nil,
+				Data:    nil,
+			})
+			return
+		}
+
+		handler(c)
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/fyvri/go-qris/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ErrorCodeNotReady = "not_ready"
+
+type Health struct {
+	isReady func() bool
+	version *bootstrap.BuildInfo
+}
+
+type HealthInterface interface {
+	Healthz(c *gin.Context)
+	Readyz(c *gin.Context)
+	Version(c *gin.Context)
+}
+
+func NewHealth(isReady func() bool, version *bootstrap.BuildInfo) HealthInterface {
+	return &Health{
+		isReady: isReady,
+		version: version,
+	}
+}
+
+// Healthz only tells that the process serves HTTP, it never checks anything
+// else so a busy instance is not restarted.
+func (h *Health) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "OK",
+		Errors:  nil,
+		Data:    nil,
+	})
+}
+
+func (h *Health) Readyz(c *gin.Context) {
+	if !h.isReady() {
+		c.JSON(http.StatusServiceUnavailable, Response{
+			Success: false,
+			Code:    ErrorCodeNotReady,
+			Message: "server is not accepting requests",
+			Errors:  nil,
+			Data:    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "Ready",
+		Errors:  nil,
+		Data:    nil,
+	})
+}
+
+func (h *Health) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "Build information",
+		Errors:  nil,
+		Data:    h.version,
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fyvri/go-qris/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHealth(t *testing.T) {
+	type want struct {
+		code     int
+		response string
+	}
+
+	tests := []struct {
+		name    string
+		isReady bool
+		path    string
+		want    want
+	}{
+		{
+			name:    "Success: Healthz While Not Ready",
+			isReady: false,
+			path:    "/healthz",
+			want: want{
+				code:     http.StatusOK,
+				response: `"success":true`,
+			},
+		},
+		{
+			name:    "Error: Readyz While Not Ready",
+			isReady: false,
+			path:    "/readyz",
+			want: want{
+				code:     http.StatusServiceUnavailable,
+				response: `"code":"not_ready"`,
+			},
+		},
+		{
+			name:    "Success: Readyz",
+			isReady: true,
+			path:    "/readyz",
+			want: want{
+				code:     http.StatusOK,
+				response: `"success":true`,
+			},
+		},
+		{
+			name:    "Success: Version",
+			isReady: true,
+			path:    "/version",
+			want: want{
+				code:     http.StatusOK,
+				response: `{"version":"v1.3.3","commit":"1b1eed7","build_date":"2026-10-19T00:00:00Z","go_version":"go1.23.4"}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewHealth(func() bool {
+				return test.isReady
+			}, &bootstrap.BuildInfo{
+				Version:   "v1.3.3",
+				Commit:    "1b1eed7",
+				BuildDate: "2026-10-19T00:00:00Z",
+				GoVersion: "go1.23.4",
+			})
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/healthz", handler.Healthz)
+			router.GET("/readyz", handler.Readyz)
+			router.GET("/version", handler.Version)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			if recorder.Code != test.want.code {
+				t.Errorf(expectedStatusCode, test.want.code, recorder.Code)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.want.response)) {
+				t.Errorf(expectedResponseToContain, test.want.response, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	idempotencyKeyMaxLength   = 255
+	idempotencyRetryAfterSecs = "1"
+
+	ErrorCodeInvalidIdempotencyKey    = "invalid_idempotency_key"
+	ErrorCodeIdempotencyKeyReused     = "idempotency_key_reused"
+	ErrorCodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+)
+
+type Idempotency struct {
+	store utils.IdempotencyStoreInterface
+	ttl   time.Duration
+}
+
+type IdempotencyInterface interface {
+	Wrap(handler gin.HandlerFunc) gin.HandlerFunc
+}
+
+// NewIdempotency replays responses kept in store for ttl, a nil store or a
+// zero ttl turns idempotency keys off.
+func NewIdempotency(store utils.IdempotencyStoreInterface, ttl time.Duration) IdempotencyInterface {
+	return &Idempotency{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Wrap runs handler once per Idempotency-Key and client, and replays its
+// response to retries with the same body. It wraps the handler instead of
+// calling c.Next so it also works inside Chain.
+func (h *Idempotency) Wrap(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if h.store == nil || h.ttl <= 0 || key == "" {
+			handler(c)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			abort(c, http.StatusBadRequest, ErrorCodeInvalidIdempotencyKey, "idempotency key must be 1 to 255 printable ASCII characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			writeBadRequest(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := idempotencyFingerprint(c.Request.Method, c.Request.URL.Path, body)
+		tenant, _ := RequestTenant(c)
+		storeKey := ClientKey(tenant, c.ClientIP()) + ":" + key
+		if response, reserved := h.store.Reserve(storeKey, fingerprint, h.ttl); !reserved {
+			switch {
+			case response.Fingerprint != fingerprint:
+				abort(c, http.StatusUnprocessableEntity, ErrorCodeIdempotencyKeyReused, "idempotency key was already used with a different request")
+			case !response.Completed:
+				c.Header("Retry-After", idempotencyRetryAfterSecs)
+				abort(c, http.StatusConflict, ErrorCodeIdempotencyKeyInProgress, "a request with this idempotency key is still in progress")
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(response.Status, response.ContentType, response.Body)
+			}
+			return
+		}
+
+		completed := false
+		defer func() {
+			if !completed {
+				h.store.Release(storeKey)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		handler(c)
+		c.Writer = recorder.ResponseWriter
+
+		// Server errors, cancellations and timeouts are not replayed, so the
+		// client can retry them.
+		if status := recorder.Status(); replayable(status) && c.Request.Context().Err() == nil {
+			h.store.Complete(storeKey, &utils.IdempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      status,
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			}, h.ttl)
+			completed = true
+		}
+	}
+}
+
+func replayable(status int) bool {
+	return status < http.StatusInternalServerError && status != statusClientClosedRequest
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > idempotencyKeyMaxLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func idempotencyFingerprint(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the body written through it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyWrap(t *testing.T) {
+	type request struct {
+		key      string
+		body     string
+		status   int
+		wantCode int
+		wantBody string
+		replayed bool
+	}
+
+	tests := []struct {
+		name      string
+		store     utils.IdempotencyStoreInterface
+		ttl       time.Duration
+		reserved  string
+		requests  []request
+		wantCalls int
+	}{
+		{
+			name:  "Success: Replay Same Request",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":1`, replayed: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:  "Success: Without Key",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":1`},
+				{body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":2`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "Success: Disabled",
+			ttl:  time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":2`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:  "Success: Replay Client Error",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusUnprocessableEntity, wantCode: http.StatusUnprocessableEntity, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusUnprocessableEntity, wantBody: `"call":1`, replayed: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:  "Success: Retry Server Error",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusInternalServerError, wantCode: http.StatusInternalServerError, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":2`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:  "Success: Retry Canceled Request",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: statusClientClosedRequest, wantCode: statusClientClosedRequest, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":2`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:  "Success: Retry Timed Out Request",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusGatewayTimeout, wantCode: http.StatusGatewayTimeout, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":2`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:  "Error: Key Reused With Different Body",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusOK, wantBody: `"call":1`},
+				{key: "order-1", body: `{"a":2}`, status: http.StatusOK, wantCode: http.StatusUnprocessableEntity, wantBody: ErrorCodeIdempotencyKeyReused},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:     "Error: Key In Progress",
+			store:    utils.NewIdempotencyStore(0, 0),
+			ttl:      time.Hour,
+			reserved: "order-1",
+			requests: []request{
+				{key: "order-1", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusConflict, wantBody: ErrorCodeIdempotencyKeyInProgress},
+			},
+			wantCalls: 0,
+		},
+		{
+			name:  "Error: Invalid Key",
+			store: utils.NewIdempotencyStore(0, 0),
+			ttl:   time.Hour,
+			requests: []request{
+				{key: "order\x01", body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusBadRequest, wantBody: ErrorCodeInvalidIdempotencyKey},
+				{key: strings.Repeat("k", 256), body: `{"a":1}`, status: http.StatusOK, wantCode: http.StatusBadRequest, wantBody: ErrorCodeInvalidIdempotencyKey},
+			},
+			wantCalls: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls, status := 0, 0
+			handler := func(c *gin.Context) {
+				calls++
+				body, _ := c.GetRawData()
+				c.JSON(status, gin.H{"call": calls, "body": string(body)})
+			}
+			if test.reserved != "" {
+				key := ClientKey(nil, "192.0.2.1") + ":" + test.reserved
+				test.store.Reserve(key, idempotencyFingerprint(http.MethodPost, "/", []byte(`{"a":1}`)), test.ttl)
+			}
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/", NewIdempotency(test.store, test.ttl).Wrap(handler))
+
+			for _, req := range test.requests {
+				status = req.status
+				request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(req.body))
+				if req.key != "" {
+					request.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, request)
+
+				if recorder.Code != req.wantCode {
+					t.Errorf(expectedStatusCode, req.wantCode, recorder.Code)
+				}
+				if !strings.Contains(recorder.Body.String(), req.wantBody) {
+					t.Errorf(expectedResponseToContain, req.wantBody, recorder.Body.String())
+				}
+				if got := recorder.Header().Get(IdempotentReplayedHeader) == "true"; got != req.replayed {
+					t.Errorf(expectedButGotMessage, IdempotentReplayedHeader, req.replayed, got)
+				}
+			}
+			if calls != test.wantCalls {
+				t.Errorf(expectedButGotMessage, "handler calls", test.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyWrapScopedByTenant(t *testing.T) {
+	tenants := &mockTenantStore{
+		TenantFunc: func(apiKey string) (*entities.Tenant, bool) {
+			return &entities.Tenant{Name: apiKey[:8]}, true
+		},
+	}
+
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", NewAPIKey(tenants).Authenticate, NewIdempotency(utils.NewIdempotencyStore(0, 0), time.Hour).Wrap(func(c *gin.Context) {
+		calls++
+		c.Status(http.StatusNoContent)
+	}))
+
+	for _, apiKey := range []string{"tenant-a-0123456789", "tenant-b-0123456789"} {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		request.Header.Set(APIKeyHeader, apiKey)
+		request.Header.Set(IdempotencyKeyHeader, "order-1")
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	if calls != 2 {
+		t.Errorf(expectedButGotMessage, "handler calls", 2, calls)
+	}
+}
+
+func TestIdempotencyWrapRetryAfterCancel(t *testing.T) {
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", NewIdempotency(utils.NewIdempotencyStore(0, 0), time.Hour).Wrap(func(c *gin.Context) {
+		calls++
+		if err := c.Request.Context().Err(); err != nil {
+			writeError(c, controllers.NewError(controllers.ErrorKindCanceled, controllers.ErrorCodeCanceled, err), nil)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)).WithContext(ctx)
+	canceled.Header.Set(IdempotencyKeyHeader, "order-1")
+	router.ServeHTTP(httptest.NewRecorder(), canceled)
+
+	retry := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	retry.Header.Set(IdempotencyKeyHeader, "order-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, retry)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf(expectedStatusCode, http.StatusNoContent, recorder.Code)
+	}
+	if calls != 2 {
+		t.Errorf(expectedButGotMessage, "handler calls", 2, calls)
+	}
+}
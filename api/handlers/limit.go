@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ErrorCodeRateLimited     = "rate_limited"
+	ErrorCodeRequestTooLarge = "request_too_large"
+)
+
+type Limit struct {
+	rateLimiter  utils.RateLimiterInterface
+	maxBodyBytes int64
+}
+
+type LimitInterface interface {
+	RateLimit(c *gin.Context)
+	BodyLimit(c *gin.Context)
+}
+
+// NewLimit throttles with rateLimiter and caps bodies at maxBodyBytes, a nil
+// limiter or a zero size turns the limit off.
+func NewLimit(rateLimiter utils.RateLimiterInterface, maxBodyBytes int64) LimitInterface {
+	return &Limit{
+		rateLimiter:  rateLimiter,
+		maxBodyBytes: maxBodyBytes,
+	}
+}
+
+// RateLimit keeps a bucket per tenant, or per client IP without a known API
+// key, and answers 429 with Retry-After once it runs dry. It runs after
+// APIKey.Authenticate.
+func (h *Limit) RateLimit(c *gin.Context) {
+	if h.rateLimiter == nil {
+		return
+	}
+
+	tenant, _ := RequestTenant(c)
+	allowed, retryAfter := h.rateLimiter.Allow(ClientKey(tenant, c.ClientIP()))
+	if !allowed {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		abort(c, http.StatusTooManyRequests, ErrorCodeRateLimited, "too many requests, retry later")
+	}
+}
+
+// BodyLimit answers 413 to bodies declared larger than the limit and stops
+// reading the others once they pass it.
+func (h *Limit) BodyLimit(c *gin.Context) {
+	if h.maxBodyBytes <= 0 {
+		return
+	}
+
+	if c.Request.ContentLength > h.maxBodyBytes {
+		abort(c, http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge, fmt.Sprintf("request body exceeds %d bytes", h.maxBodyBytes))
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBodyBytes)
+}
+
+// ClientKey identifies the caller by its authenticated tenant, or by client
+// IP without one. Unknown API keys never pick a bucket of their own, so
+// rotating them neither dodges a limit nor grows the limiter.
+func ClientKey(tenant *entities.Tenant, clientIP string) string {
+	if tenant == nil {
+		return "ip:" + clientIP
+	}
+
+	// Publishable keys are public, so one visitor must not be able to use
+	// up the tenant's requests.
+	if tenant.Publishable {
+		return "publishable:" + tenant.Name + ":" + clientIP
+	}
+
+	return "tenant:" + tenant.Name
+}
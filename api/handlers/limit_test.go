@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLimitRateLimit(t *testing.T) {
+	tenants := &mockTenantStore{
+		TenantFunc: func(apiKey string) (*entities.Tenant, bool) {
+			if apiKey == "publishable-0123456789" {
+				return &entities.Tenant{Name: "warung", Publishable: true}, true
+			}
+			return &entities.Tenant{Name: "warung"}, apiKey == "warung-0123456789"
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tenants        TenantStoreInterface
+		headers        map[string]string
+		allow          bool
+		retryAfter     time.Duration
+		wantKey        string
+		wantCode       int
+		wantRetryAfter string
+	}{
+		{
+			name:     "Success: Keyed By Client IP",
+			allow:    true,
+			wantKey:  "ip:192.0.2.1",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "Success: Keyed By Tenant",
+			tenants:  tenants,
+			headers:  map[string]string{APIKeyHeader: "warung-0123456789"},
+			allow:    true,
+			wantKey:  "tenant:warung",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "Success: Publishable Key Keyed By Tenant And Client IP",
+			tenants:  tenants,
+			headers:  map[string]string{APIKeyHeader: "publishable-0123456789"},
+			allow:    true,
+			wantKey:  "publishable:warung:192.0.2.1",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "Success: Unknown API Key Keyed By Client IP",
+			tenants:  tenants,
+			headers:  map[string]string{APIKeyHeader: "0123456789abcdef"},
+			allow:    true,
+			wantKey:  "ip:192.0.2.1",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "Success: API Key Without Tenants Keyed By Client IP",
+			headers:  map[string]string{APIKeyHeader: "0123456789abcdef"},
+			allow:    true,
+			wantKey:  "ip:192.0.2.1",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:           "Error: Rate Limited",
+			tenants:        tenants,
+			headers:        map[string]string{"Authorization": "Bearer warung-0123456789"},
+			allow:          false,
+			retryAfter:     1200 * time.Millisecond,
+			wantKey:        "tenant:warung",
+			wantCode:       http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotKey string
+			limit := NewLimit(&mockRateLimiter{
+				AllowFunc: func(key string) (bool, time.Duration) {
+					gotKey = key
+					return test.allow, test.retryAfter
+				},
+			}, 0)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/", NewAPIKey(test.tenants).Authenticate, limit.RateLimit, func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/", nil)
+			for key, value := range test.headers {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.wantCode {
+				t.Errorf(expectedStatusCode, test.wantCode, recorder.Code)
+			}
+			if gotKey != test.wantKey {
+				t.Errorf(expectedButGotMessage, "rate limit key", test.wantKey, gotKey)
+			}
+			if got := recorder.Header().Get("Retry-After"); got != test.wantRetryAfter {
+				t.Errorf(expectedButGotMessage, "Retry-After", test.wantRetryAfter, got)
+			}
+		})
+	}
+}
+
+func TestLimitRateLimitRotatingAPIKeys(t *testing.T) {
+	tenants := &mockTenantStore{
+		TenantFunc: func(apiKey string) (*entities.Tenant, bool) {
+			return nil, false
+		},
+	}
+	apiKey := NewAPIKey(tenants)
+	limit := NewLimit(utils.NewRateLimiter(1, 3), 0)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies(nil); err != nil {
+		t.Fatalf(expectedButGotMessage, "SetTrustedProxies() error", nil, err)
+	}
+	router.POST("/", apiKey.Authenticate, limit.RateLimit, apiKey.Authorize("parse"), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	codes := map[int]int{}
+	for i := range 10 {
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set(APIKeyHeader, fmt.Sprintf("random-key-%016d", i))
+		request.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		codes[recorder.Code]++
+	}
+
+	if codes[http.StatusUnauthorized] != 3 || codes[http.StatusTooManyRequests] != 7 {
+		t.Errorf(expectedButGotMessage, "status codes", "3 unauthorized and 7 rate limited", codes)
+	}
+}
+
+func TestLimitBodyLimit(t *testing.T) {
+	qrisController := &mockQRISController{
+		ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+			return &entities.QRIS{}, nil, nil
+		},
+		ParseImageFunc: func(imageData []byte) (*entities.QRIS, error, *[]string) {
+			return &entities.QRIS{}, nil, nil
+		},
+	}
+
+	multipartBody := func(size int) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("image", "qris.png")
+		part.Write(bytes.Repeat([]byte{0}, size))
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		body        func() (*bytes.Buffer, string)
+		chunked     bool
+		wantCode    int
+		wantMessage string
+	}{
+		{
+			name: "Success: Within Limit",
+			path: "/parse",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"qr_string": "000201"}`), testHeaderContentTypeValue
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "Error: Declared Length Over Limit",
+			path: "/parse",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"qr_string": "` + strings.Repeat("0", 256) + `"}`), testHeaderContentTypeValue
+			},
+			wantCode:    http.StatusRequestEntityTooLarge,
+			wantMessage: `"code":"request_too_large"`,
+		},
+		{
+			name: "Error: Streamed JSON Over Limit",
+			path: "/parse",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"qr_string": "` + strings.Repeat("0", 256) + `"}`), testHeaderContentTypeValue
+			},
+			chunked:     true,
+			wantCode:    http.StatusRequestEntityTooLarge,
+			wantMessage: `"code":"request_too_large"`,
+		},
+		{
+			name: "Error: Streamed Image Over Limit",
+			path: "/parse-image",
+			body: func() (*bytes.Buffer, string) {
+				return multipartBody(256)
+			},
+			chunked:     true,
+			wantCode:    http.StatusRequestEntityTooLarge,
+			wantMessage: `"code":"request_too_large"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit := NewLimit(nil, 128)
+			handler := NewQRIS(qrisController)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/parse", limit.BodyLimit, handler.Parse)
+			router.POST("/parse-image", limit.BodyLimit, handler.ParseImage)
+
+			body, contentType := test.body()
+			request := httptest.NewRequest(http.MethodPost, test.path, body)
+			request.Header.Set(testHeaderContentType, contentType)
+			if test.chunked {
+				request.ContentLength = -1
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.wantCode {
+				t.Errorf(expectedStatusCode, test.wantCode, recorder.Code)
+			}
+			if !strings.Contains(recorder.Body.String(), test.wantMessage) {
+				t.Errorf(expectedResponseToContain, test.wantMessage, recorder.Body.String())
+			}
+		})
+	}
+}
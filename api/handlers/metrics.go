@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Metrics struct {
+	metrics utils.MetricsInterface
+}
+
+type MetricsInterface interface {
+	Middleware(c *gin.Context)
+	Serve(c *gin.Context)
+}
+
+func NewMetrics(metrics utils.MetricsInterface) MetricsInterface {
+	return &Metrics{
+		metrics: metrics,
+	}
+}
+
+// Middleware counts every request under its route template, so path
+// parameters do not blow up the number of series. Known /v2 methods are
+// spelled out, unknown ones stay under :method.
+func (h *Metrics) Middleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	} else if c.Writer.Status() != http.StatusNotFound {
+		route = strings.Replace(route, ":method", c.Param("method"), 1)
+	}
+	h.metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+}
+
+func (h *Metrics) Serve(c *gin.Context) {
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", utils.MetricsContentType)
+	if err := h.metrics.WriteText(c.Writer); err != nil {
+		c.Error(err)
+	}
+}
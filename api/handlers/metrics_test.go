@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewMetrics(utils.NewMetrics())
+	router.Use(handler.Middleware)
+	router.GET("/metrics", handler.Serve)
+	router.POST("/v2/:method", Dispatch(map[string]gin.HandlerFunc{
+		"qris:parse": func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		},
+	}))
+
+	for _, path := range []string{"/v2/qris:parse", "/v2/qris:parse", "/v2/qris:unknown", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf(expectedStatusCode, http.StatusOK, recorder.Code)
+	}
+	if got := recorder.Header().Get(testHeaderContentType); got != utils.MetricsContentType {
+		t.Errorf(expectedButGotMessage, testHeaderContentType, utils.MetricsContentType, got)
+	}
+	for _, want := range []string{
+		`goqris_http_requests_total{method="POST",route="/v2/qris:parse",status="200"} 2`,
+		`goqris_http_requests_total{method="POST",route="/v2/:method",status="404"} 1`,
+		`goqris_http_requests_total{method="POST",route="unmatched",status="404"} 1`,
+	} {
+		if !bytes.Contains(recorder.Body.Bytes(), []byte(want)) {
+			t.Errorf(expectedResponseToContain, want, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const OpenAPIVersion = "3.0.3"
+
+//go:embed static/docs.html
+var docsHTML []byte
+
+type Docs struct {
+	document map[string]any
+}
+
+type DocsInterface interface {
+	OpenAPI(c *gin.Context)
+	Viewer(c *gin.Context)
+}
+
+// OpenAPIOperation describes a single endpoint. Request is the JSON body and
+// Data the success payload inside Response, both are documented from their
+// json tags. Example is sent as the request example.
+type OpenAPIOperation struct {
+	ID         string
+	Method     string
+	Path       string
+	Summary    string
+	Request    any
+	FormFiles  []string
+	Data       any
+	Example    any
+	Deprecated bool
+	Idempotent bool
+}
+
+func NewDocs(document map[string]any) DocsInterface {
+	return &Docs{
+		document: document,
+	}
+}
+
+func (h *Docs) OpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, h.document)
+}
+
+func (h *Docs) Viewer(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsHTML)
+}
+
+// NewOpenAPIDocument builds an OpenAPI 3 document for the operations, every
+// named struct ends up in the component schemas.
+func NewOpenAPIDocument(title string, version string, operations []OpenAPIOperation) map[string]any {
+	schemas := map[string]any{}
+	responseSchema := openAPISchema(reflect.TypeOf(Response{}), schemas, false)
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content": map[string]any{
+				"application/json": map[string]any{
+					"schema": responseSchema,
+				},
+			},
+		}
+	}
+
+	paths := map[string]any{}
+	for _, operation := range operations {
+		successSchema := responseSchema
+		if operation.Data != nil {
+			successSchema = map[string]any{
+				"allOf": []any{
+					responseSchema,
+					map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"data": openAPISchema(reflect.TypeOf(operation.Data), schemas, false),
+						},
+					},
+				},
+			}
+		}
+
+		item := map[string]any{
+			"operationId": operation.ID,
+			"summary":     operation.Summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "Success",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": successSchema,
+						},
+					},
+				},
+				"400": errorResponse("Malformed request, see code"),
+				"401": errorResponse("Missing or unknown API key"),
+				"403": errorResponse("Endpoint or merchant not allowed for the API key"),
+				"413": errorResponse("Request body or QR string above the configured limit"),
+				"422": errorResponse("Request failed validation, see code"),
+				"429": errorResponse("Rate limit exceeded, retry after the Retry-After seconds"),
+				"500": errorResponse("Internal error"),
+			},
+		}
+
+		if operation.Deprecated {
+			item["deprecated"] = true
+		}
+
+		if operation.Idempotent {
+			item["parameters"] = []any{
+				map[string]any{
+					"name":        IdempotencyKeyHeader,
+					"in":          "header",
+					"required":    false,
+					"description": "Replays the response to a retry with the same key and body",
+					"schema": map[string]any{
+						"type":      "string",
+						"maxLength": idempotencyKeyMaxLength,
+					},
+				},
+			}
+			item["responses"].(map[string]any)["409"] = errorResponse("Request with the same Idempotency-Key still in progress")
+		}
+
+		if operation.Request != nil {
+			content := map[string]any{
+				"schema": openAPISchema(reflect.TypeOf(operation.Request), schemas, true),
+			}
+			if operation.Example != nil {
+				content["example"] = operation.Example
+			}
+			item["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": content,
+				},
+			}
+		} else if len(operation.FormFiles) > 0 {
+			properties := map[string]any{}
+			for _, name := range operation.FormFiles {
+				properties[name] = map[string]any{
+					"type":   "string",
+					"format": "binary",
+				}
+			}
+			item["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"multipart/form-data": map[string]any{
+						"schema": map[string]any{
+							"type":       "object",
+							"properties": properties,
+							"required":   operation.FormFiles,
+						},
+					},
+				},
+			}
+		}
+
+		path, exists := paths[operation.Path].(map[string]any)
+		if !exists {
+			path = map[string]any{}
+			paths[operation.Path] = path
+		}
+		path[strings.ToLower(operation.Method)] = item
+	}
+
+	return map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{
+					"type": "apiKey",
+					"in":   "header",
+					"name": APIKeyHeader,
+				},
+				"bearer": map[string]any{
+					"type":   "http",
+					"scheme": "bearer",
+				},
+			},
+		},
+		// API keys are only enforced once tenants are configured.
+		"security": []any{
+			map[string]any{"apiKey": []any{}},
+			map[string]any{"bearer": []any{}},
+			map[string]any{},
+		},
+	}
+}
+
+// openAPISchema describes a Go type the way encoding/json marshals it. Named
+// structs are stored once in schemas and referenced from then on. Response
+// fields are required unless omitempty, request fields only when bound with
+// binding:"required".
+func openAPISchema(t reflect.Type, schemas map[string]any, request bool) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := openAPISchema(t.Elem(), schemas, request)
+		if _, isReference := schema["$ref"]; isReference {
+			return map[string]any{
+				"allOf":    []any{schema},
+				"nullable": true,
+			}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{
+			"type":  "array",
+			"items": openAPISchema(t.Elem(), schemas, request),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": openAPISchema(t.Elem(), schemas, request),
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return openAPIObjectSchema(t, schemas, request)
+		}
+		reference := map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		if _, exists := schemas[t.Name()]; !exists {
+			schemas[t.Name()] = nil
+			schemas[t.Name()] = openAPIObjectSchema(t, schemas, request)
+		}
+		return reference
+	default:
+		return map[string]any{}
+	}
+}
+
+func openAPIObjectSchema(t reflect.Type, schemas map[string]any, request bool) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, optional := openAPIFieldName(field)
+		if name == "" {
+			continue
+		}
+		properties[name] = openAPISchema(field.Type, schemas, request)
+		if request {
+			optional = !strings.Contains(field.Tag.Get("binding"), "required")
+		}
+		if !optional {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// openAPIFieldName returns the JSON name of a field and whether it may be left
+// out, an empty name means the field is never marshalled.
+func openAPIFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, strings.Contains(options, "omitempty")
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPISchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		request bool
+		want    map[string]any
+	}{
+		{
+			name:  "Success: Response",
+			value: Response{},
+			want: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"success": map[string]any{"type": "boolean"},
+					"code":    map[string]any{"type": "string"},
+					"message": map[string]any{"type": "string"},
+					"errors": map[string]any{
+						"type":     "array",
+						"items":    map[string]any{"type": "string"},
+						"nullable": true,
+					},
+					"data": map[string]any{},
+				},
+				"required": []string{"success", "message", "errors", "data"},
+			},
+		},
+		{
+			name:    "Success: Request Without Required Fields",
+			value:   StickerRequest{},
+			request: true,
+			want: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"qr_string":              map[string]any{"type": "string"},
+					"format":                 map[string]any{"type": "string"},
+					"dpi":                    map[string]any{"type": "integer", "format": "int32"},
+					"error_correction_level": map[string]any{"type": "string"},
+				},
+			},
+		},
+		{
+			name: "Success: Binding Required And Bytes",
+			value: struct {
+				Name string `json:"name" binding:"required"`
+				Logo []byte `json:"logo"`
+				Skip string `json:"-"`
+			}{},
+			request: true,
+			want: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+					"logo": map[string]any{"type": "string", "format": "byte"},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schemas := map[string]any{}
+			got := openAPISchema(reflect.TypeOf(test.value), schemas, test.request)
+			if name := reflect.TypeOf(test.value).Name(); name != "" {
+				if !reflect.DeepEqual(got, map[string]any{"$ref": "#/components/schemas/" + name}) {
+					t.Errorf(expectedButGotMessage, "openAPISchema()", name, got)
+				}
+				got = schemas[name].(map[string]any)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "openAPISchema()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	document := NewOpenAPIDocument("Go-QRIS", "1.0.0", []OpenAPIOperation{
+		{
+			ID:         "parse",
+			Method:     http.MethodPost,
+			Path:       "/parse",
+			Summary:    "Parse QRIS",
+			Request:    ParseRequest{},
+			Idempotent: true,
+		},
+		{
+			ID:        "parseImage",
+			Method:    http.MethodPost,
+			Path:      "/parse-image",
+			Summary:   "Parse QRIS from an image",
+			FormFiles: []string{"image"},
+		},
+	})
+
+	if document["openapi"] != OpenAPIVersion {
+		t.Errorf(expectedButGotMessage, "openapi", OpenAPIVersion, document["openapi"])
+	}
+	paths := document["paths"].(map[string]any)
+	for _, path := range []string{"/parse", "/parse-image"} {
+		operation, exists := paths[path].(map[string]any)["post"].(map[string]any)
+		if !exists {
+			t.Fatalf(expectedButGotMessage, "paths", path, paths)
+		}
+		responses := operation["responses"].(map[string]any)
+		for _, status := range []string{"200", "400", "422", "500"} {
+			if _, exists := responses[status]; !exists {
+				t.Errorf(expectedButGotMessage, path+" responses", status, responses)
+			}
+		}
+	}
+	for path, idempotent := range map[string]bool{"/parse": true, "/parse-image": false} {
+		operation := paths[path].(map[string]any)["post"].(map[string]any)
+		_, hasConflict := operation["responses"].(map[string]any)["409"]
+		_, hasParameters := operation["parameters"]
+		if hasConflict != idempotent || hasParameters != idempotent {
+			t.Errorf(expectedButGotMessage, path+" idempotent", idempotent, operation)
+		}
+	}
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, name := range []string{"Response", "ParseRequest"} {
+		if _, exists := schemas[name]; !exists {
+			t.Errorf(expectedButGotMessage, "components.schemas", name, schemas)
+		}
+	}
+}
+
+func TestDocsViewer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/docs", NewDocs(map[string]any{}).Viewer)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf(expectedStatusCode, http.StatusOK, recorder.Code)
+	}
+	if !bytes.Contains(recorder.Body.Bytes(), []byte(`fetch("openapi.json")`)) {
+		t.Errorf(expectedResponseToContain, `fetch("openapi.json")`, recorder.Body.String())
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed static/playground.html
+var playgroundHTML []byte
+
+type Playground struct {
+	fallback gin.HandlerFunc
+}
+
+type PlaygroundInterface interface {
+	Page(c *gin.Context)
+}
+
+// NewPlayground serves the web playground to browsers and leaves every other
+// client, such as curl or an API client asking for JSON, to fallback.
+func NewPlayground(fallback gin.HandlerFunc) PlaygroundInterface {
+	return &Playground{
+		fallback: fallback,
+	}
+}
+
+func (h *Playground) Page(c *gin.Context) {
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) != gin.MIMEHTML {
+		h.fallback(c)
+		return
+	}
+
+	c.Header("Content-Security-Policy", "default-src 'self'; img-src 'self' data:; style-src 'unsafe-inline'; script-src 'unsafe-inline'")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", playgroundHTML)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPlaygroundPage(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "Success: Browser",
+			accept:          "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			wantContentType: "text/html; charset=utf-8",
+			wantBody:        "v2/qris:parse",
+		},
+		{
+			name:            "Success: API Client",
+			accept:          "application/json",
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `"success":true`,
+		},
+		{
+			name:            "Success: Any",
+			accept:          "*/*",
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `"success":true`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/", NewPlayground(func(c *gin.Context) {
+				c.JSON(http.StatusOK, Response{Success: true})
+			}).Page)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Accept", test.accept)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Errorf(expectedStatusCode, http.StatusOK, recorder.Code)
+			}
+			if got := recorder.Header().Get(testHeaderContentType); got != test.wantContentType {
+				t.Errorf(expectedButGotMessage, testHeaderContentType, test.wantContentType, got)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.wantBody)) {
+				t.Errorf(expectedResponseToContain, test.wantBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,8 +16,10 @@ type QRIS struct {
 
 type QRISInterface interface {
 	Parse(c *gin.Context)
+	ParseImage(c *gin.Context)
 	Convert(c *gin.Context)
 	IsValid(c *gin.Context)
+	Sticker(c *gin.Context)
 }
 
 type ParseRequest struct {
@@ -34,6 +38,30 @@ type ConvertRequest struct {
 	PaymentFeeCategory string `json:"payment_fee_category"`
 	PaymentFee         uint32 `json:"payment_fee"`
 	TerminalLabel      string `json:"terminal_label"`
+	QRCodeFormat       string `json:"qr_code_format"`
+	QRCodeSize         int    `json:"qr_code_size"`
+	QRCodeModuleSize   int    `json:"qr_code_module_size"`
+	QRCodeMargin       *int   `json:"qr_code_margin"`
+	QRCodeECCLevel     string `json:"qr_code_error_correction_level"`
+	QRCodeForeground   string `json:"qr_code_foreground_color"`
+	QRCodeBackground   string `json:"qr_code_background_color"`
+	QRCodeLogo         []byte `json:"qr_code_logo"`
+}
+
+type StickerRequest struct {
+	QRString             string `json:"qr_string"`
+	Format               string `json:"format"`
+	DPI                  int    `json:"dpi"`
+	ErrorCorrectionLevel string `json:"error_correction_level"`
+}
+
+type ConvertResponse struct {
+	QRString string `json:"qr_string"`
+	QRCode   string `json:"qr_code"`
+}
+
+type StickerResponse struct {
+	Sticker string `json:"sticker"`
 }
 
 func NewQRIS(qrisController controllers.QRISInterface) QRISInterface {
@@ -46,23 +74,34 @@ func (h *QRIS) Parse(c *gin.Context) {
 	var req ParseRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  nil,
-			Data:    nil,
-		})
+		writeBadRequest(c, err)
 		return
 	}
 
-	data, err, errs := h.qrisController.Parse(req.QRString)
+	data, err, errs := requestController(c, h.qrisController).Parse(c.Request.Context(), req.QRString)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  errs,
-			Data:    nil,
-		})
+		writeError(c, err, errs)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "QRIS parsed successfully",
+		Errors:  nil,
+		Data:    data,
+	})
+}
+
+func (h *QRIS) ParseImage(c *gin.Context) {
+	imageData, err := readFormFile(c, "image")
+	if err != nil {
+		writeBadRequest(c, err)
+		return
+	}
+
+	data, err, errs := requestController(c, h.qrisController).ParseImage(c.Request.Context(), imageData)
+	if err != nil {
+		writeError(c, err, errs)
 		return
 	}
 
@@ -78,23 +117,26 @@ func (h *QRIS) Convert(c *gin.Context) {
 	var req ConvertRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  nil,
-			Data:    nil,
-		})
+		writeBadRequest(c, err)
 		return
 	}
 
-	qrString, qrCode, err, errs := h.qrisController.Convert(req.QRString, req.MerchantCity, req.MerchantPostalCode, req.PaymentAmount, req.PaymentFeeCategory, req.PaymentFee, req.TerminalLabel)
+	qrCodeOptions := &utils.QRCodeOptions{
+		Format:               req.QRCodeFormat,
+		Size:                 req.QRCodeSize,
+		ModuleSize:           req.QRCodeModuleSize,
+		ErrorCorrectionLevel: req.QRCodeECCLevel,
+		ForegroundColor:      req.QRCodeForeground,
+		BackgroundColor:      req.QRCodeBackground,
+		Logo:                 req.QRCodeLogo,
+	}
+	if req.QRCodeMargin != nil {
+		qrCodeOptions.Margin = *req.QRCodeMargin
+		qrCodeOptions.MarginSet = true
+	}
+	qrString, qrCode, err, errs := requestController(c, h.qrisController).Convert(c.Request.Context(), req.QRString, req.MerchantCity, req.MerchantPostalCode, req.PaymentAmount, req.PaymentFeeCategory, req.PaymentFee, req.TerminalLabel, qrCodeOptions)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  errs,
-			Data:    nil,
-		})
+		writeError(c, err, errs)
 		return
 	}
 
@@ -102,10 +144,7 @@ func (h *QRIS) Convert(c *gin.Context) {
 		Success: true,
 		Message: "Dynamic QRIS converted successfully",
 		Errors:  nil,
-		Data: struct {
-			QRString string `json:"qr_string"`
-			QRCode   string `json:"qr_code"`
-		}{
+		Data: &ConvertResponse{
 			QRString: qrString,
 			QRCode:   qrCode,
 		},
@@ -116,23 +155,13 @@ func (h *QRIS) IsValid(c *gin.Context) {
 	var req IsValidRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  nil,
-			Data:    nil,
-		})
+		writeBadRequest(c, err)
 		return
 	}
 
-	err, errs := h.qrisController.IsValid(req.QRString)
+	err, errs := requestController(c, h.qrisController).IsValid(c.Request.Context(), req.QRString)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Message: err.Error(),
-			Errors:  errs,
-			Data:    nil,
-		})
+		writeError(c, err, errs)
 		return
 	}
 
@@ -143,3 +172,46 @@ func (h *QRIS) IsValid(c *gin.Context) {
 		Data:    nil,
 	})
 }
+
+func (h *QRIS) Sticker(c *gin.Context) {
+	var req StickerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err)
+		return
+	}
+
+	sticker, err, errs := requestController(c, h.qrisController).Sticker(c.Request.Context(), req.QRString, &utils.StickerOptions{
+		Format:               req.Format,
+		DPI:                  req.DPI,
+		ErrorCorrectionLevel: req.ErrorCorrectionLevel,
+	})
+	if err != nil {
+		writeError(c, err, errs)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "QRIS sticker generated successfully",
+		Errors:  nil,
+		Data: &StickerResponse{
+			Sticker: sticker,
+		},
+	})
+}
+
+func readFormFile(c *gin.Context, name string) ([]byte, error) {
+	fileHeader, err := c.FormFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
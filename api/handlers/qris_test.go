@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
 	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -98,6 +101,40 @@ func TestQRISParse(t *testing.T) {
 				response: `"invalid QR string"`,
 			},
 		},
+		{
+			name: "Error: Malformed QRIS",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+						return nil, controllers.NewError(controllers.ErrorKindMalformed, controllers.ErrorCodeInvalidQRIS, fmt.Errorf("invalid QRIS format")), nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "invalid"}`,
+			},
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `"code":"invalid_qris"`,
+			},
+		},
+		{
+			name: "Error: Deadline Exceeded",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+						return nil, controllers.NewError(controllers.ErrorKindTimeout, controllers.ErrorCodeDeadlineExceeded, context.DeadlineExceeded), nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "invalid"}`,
+			},
+			want: want{
+				code:     http.StatusGatewayTimeout,
+				response: `"code":"deadline_exceeded"`,
+			},
+		},
 		{
 			name: "Success",
 			fields: QRIS{
@@ -141,6 +178,105 @@ func TestQRISParse(t *testing.T) {
 	}
 }
 
+func TestQRISParseImage(t *testing.T) {
+	type args struct {
+		fieldName string
+		imageData []byte
+	}
+	type want struct {
+		code     int
+		response string
+	}
+
+	tests := []struct {
+		name   string
+		fields QRIS
+		args   args
+		want   want
+	}{
+		{
+			name:   "Error: Missing Image",
+			fields: QRIS{},
+			args: args{
+				fieldName: "file",
+				imageData: []byte("image"),
+			},
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `"http: no such file"`,
+			},
+		},
+		{
+			name: "Error: h.qrisController.ParseImage()",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ParseImageFunc: func(imageData []byte) (*entities.QRIS, error, *[]string) {
+						return nil, fmt.Errorf("can not find QR code in image"), nil
+					},
+				},
+			},
+			args: args{
+				fieldName: "image",
+				imageData: []byte("image"),
+			},
+			want: want{
+				code:     http.StatusInternalServerError,
+				response: `"can not find QR code in image"`,
+			},
+		},
+		{
+			name: "Success",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ParseImageFunc: func(imageData []byte) (*entities.QRIS, error, *[]string) {
+						if string(imageData) != "image" {
+							return nil, fmt.Errorf("unexpected image %q", imageData), nil
+						}
+						return &entities.QRIS{}, nil, nil
+					},
+				},
+			},
+			args: args{
+				fieldName: "image",
+				imageData: []byte("image"),
+			},
+			want: want{
+				code:     http.StatusOK,
+				response: `"QRIS parsed successfully"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewQRIS(test.fields.qrisController)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.POST("/", handler.ParseImage)
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, _ := writer.CreateFormFile(test.args.fieldName, "qris.png")
+			part.Write(test.args.imageData)
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/", &body)
+			req.Header.Set(testHeaderContentType, writer.FormDataContentType())
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != test.want.code {
+				t.Errorf(expectedStatusCode, test.want.code, recorder.Code)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.want.response)) {
+				t.Errorf(expectedResponseToContain, test.want.response, recorder.Body.String())
+			}
+		})
+	}
+}
+
 func TestQRISConvert(t *testing.T) {
 	type args struct {
 		requestBody string
@@ -171,7 +307,7 @@ func TestQRISConvert(t *testing.T) {
 			name: "Error: h.qrisController.Convert()",
 			fields: QRIS{
 				qrisController: &mockQRISController{
-					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) (string, string, error, *[]string) {
+					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
 						return "", "", fmt.Errorf("invalid QR string"), nil
 					},
 				},
@@ -184,11 +320,28 @@ func TestQRISConvert(t *testing.T) {
 				response: `"invalid QR string"`,
 			},
 		},
+		{
+			name: "Error: Input Too Long",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
+						return "", "", controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInputTooLong, fmt.Errorf("input length exceeds the maximum permitted characters")), &[]string{"merchant city exceeds 15 characters"}
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "valid", "merchant_city": "Kota Yogyakarta Istimewa"}`,
+			},
+			want: want{
+				code:     http.StatusUnprocessableEntity,
+				response: `"code":"input_too_long"`,
+			},
+		},
 		{
 			name: "Success",
 			fields: QRIS{
 				qrisController: &mockQRISController{
-					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) (string, string, error, *[]string) {
+					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
 						return "QR Dynamic String", "QR Dynamic Code", nil, nil
 					},
 				},
@@ -201,6 +354,26 @@ func TestQRISConvert(t *testing.T) {
 				response: `"Dynamic QRIS converted successfully"`,
 			},
 		},
+		{
+			name: "Success: With Logo",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					ConvertFunc: func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
+						if string(qrCodeOptions.Logo) != "logo" {
+							return "", "", fmt.Errorf("unexpected logo %q", qrCodeOptions.Logo), nil
+						}
+						return "QR Dynamic String", "QR Dynamic Code", nil, nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "valid", "qr_code_logo": "bG9nbw=="}`,
+			},
+			want: want{
+				code:     http.StatusOK,
+				response: `"Dynamic QRIS converted successfully"`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -270,6 +443,23 @@ func TestQRISIsValid(t *testing.T) {
 				response: `"invalid CRC16-CCITT code"`,
 			},
 		},
+		{
+			name: "Error: Invalid CRC",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					IsValidFunc: func(qrisString string) (error, *[]string) {
+						return controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInvalidCRC, fmt.Errorf("invalid CRC16-CCITT code")), nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "invalid"}`,
+			},
+			want: want{
+				code:     http.StatusUnprocessableEntity,
+				response: `"code":"invalid_crc"`,
+			},
+		},
 		{
 			name: "Success",
 			fields: QRIS{
@@ -312,3 +502,89 @@ func TestQRISIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestQRISSticker(t *testing.T) {
+	type args struct {
+		requestBody string
+	}
+	type want struct {
+		code     int
+		response string
+	}
+
+	tests := []struct {
+		name   string
+		fields QRIS
+		args   args
+		want   want
+	}{
+		{
+			name:   testNameInvalidJSON,
+			fields: QRIS{},
+			args: args{
+				requestBody: `"{"qr_string": 1337}"`,
+			},
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `cannot unmarshal string`,
+			},
+		},
+		{
+			name: "Error: h.qrisController.Sticker()",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					StickerFunc: func(qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string) {
+						return "", fmt.Errorf("unsupported sticker format %s", stickerOptions.Format), nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "valid", "format": "svg"}`,
+			},
+			want: want{
+				code:     http.StatusInternalServerError,
+				response: `"unsupported sticker format svg"`,
+			},
+		},
+		{
+			name: "Success",
+			fields: QRIS{
+				qrisController: &mockQRISController{
+					StickerFunc: func(qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string) {
+						return "data:application/pdf;base64,sticker", nil, nil
+					},
+				},
+			},
+			args: args{
+				requestBody: `{"qr_string": "valid"}`,
+			},
+			want: want{
+				code:     http.StatusOK,
+				response: `"QRIS sticker generated successfully"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewQRIS(test.fields.qrisController)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.POST("/", handler.Sticker)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(test.args.requestBody))
+			req.Header.Set(testHeaderContentType, testHeaderContentTypeValue)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != test.want.code {
+				t.Errorf(expectedStatusCode, test.want.code, recorder.Code)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.want.response)) {
+				t.Errorf(expectedResponseToContain, test.want.response, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QRISV2 struct {
+	qrisController controllers.QRISInterface
+}
+
+type QRISV2Interface interface {
+	Parse(c *gin.Context)
+	Convert(c *gin.Context)
+	Generate(c *gin.Context)
+}
+
+type ParseV2Request struct {
+	QRString string `json:"qr_string" binding:"required"`
+}
+
+type ConvertV2Request struct {
+	QRString              string                               `json:"qr_string" binding:"required"`
+	PaymentAmount         string                               `json:"payment_amount" binding:"required"`
+	PaymentFee            *entities.PaymentFeePatch            `json:"payment_fee"`
+	MerchantCity          *string                              `json:"merchant_city"`
+	MerchantPostalCode    *string                              `json:"merchant_postal_code"`
+	AdditionalInformation *entities.AdditionalInformationPatch `json:"additional_information"`
+}
+
+type GenerateV2Request struct {
+	QRString string              `json:"qr_string" binding:"required"`
+	QRCode   utils.QRCodeOptions `json:"qr_code"`
+}
+
+type ParseV2Response struct {
+	QRIS     *entities.QRIS `json:"qris"`
+	CRCValid bool           `json:"crc_valid"`
+}
+
+type ConvertV2Response struct {
+	QRString string         `json:"qr_string"`
+	QRIS     *entities.QRIS `json:"qris"`
+}
+
+type GenerateV2Response struct {
+	QRCode string `json:"qr_code"`
+}
+
+func NewQRISV2(qrisController controllers.QRISInterface) QRISV2Interface {
+	return &QRISV2{
+		qrisController: qrisController,
+	}
+}
+
+func (h *QRISV2) Parse(c *gin.Context) {
+	var req ParseV2Request
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err)
+		return
+	}
+
+	qrisController := requestController(c, h.qrisController)
+	qris, err, errs := qrisController.Parse(c.Request.Context(), req.QRString)
+	if err != nil {
+		writeError(c, err, errs)
+		return
+	}
+	crcValid, err := qrisController.VerifyCRC(c.Request.Context(), qris)
+	if err != nil {
+		writeError(c, err, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "QRIS parsed successfully",
+		Errors:  nil,
+		Data: &ParseV2Response{
+			QRIS:     qris,
+			CRCValid: crcValid,
+		},
+	})
+}
+
+func (h *QRISV2) Convert(c *gin.Context) {
+	var req ConvertV2Request
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err)
+		return
+	}
+
+	qrString, qris, err, errs := requestController(c, h.qrisController).Patch(c.Request.Context(), req.QRString, &entities.QRISPatch{
+		PaymentAmount:         req.PaymentAmount,
+		PaymentFee:            req.PaymentFee,
+		MerchantCity:          req.MerchantCity,
+		MerchantPostalCode:    req.MerchantPostalCode,
+		AdditionalInformation: req.AdditionalInformation,
+	})
+	if err != nil {
+		writeError(c, err, errs)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "Dynamic QRIS converted successfully",
+		Errors:  nil,
+		Data: &ConvertV2Response{
+			QRString: qrString,
+			QRIS:     qris,
+		},
+	})
+}
+
+func (h *QRISV2) Generate(c *gin.Context) {
+	var req GenerateV2Request
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err)
+		return
+	}
+
+	qrCode, err, errs := requestController(c, h.qrisController).Generate(c.Request.Context(), req.QRString, &req.QRCode)
+	if err != nil {
+		writeError(c, err, errs)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "QR code generated successfully",
+		Errors:  nil,
+		Data: &GenerateV2Response{
+			QRCode: qrCode,
+		},
+	})
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func TestQRISV2(t *testing.T) {
+	type want struct {
+		code     int
+		response string
+	}
+
+	tests := []struct {
+		name        string
+		controller  *mockQRISController
+		handler     func(h QRISV2Interface) gin.HandlerFunc
+		requestBody string
+		want        want
+	}{
+		{
+			name:       "Parse: Missing QR String",
+			controller: &mockQRISController{},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Parse
+			},
+			requestBody: `{}`,
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `"code":"invalid_request"`,
+			},
+		},
+		{
+			name: "Parse: Malformed QRIS",
+			controller: &mockQRISController{
+				ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+					return nil, controllers.NewError(controllers.ErrorKindMalformed, controllers.ErrorCodeInvalidQRIS, fmt.Errorf("invalid QRIS format")), nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Parse
+			},
+			requestBody: `{"qr_string": "invalid"}`,
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `"code":"invalid_qris"`,
+			},
+		},
+		{
+			name: "Parse: Success With Invalid CRC",
+			controller: &mockQRISController{
+				ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+				VerifyCRCFunc: func(qris *entities.QRIS) (bool, error) {
+					return false, nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Parse
+			},
+			requestBody: `{"qr_string": "valid"}`,
+			want: want{
+				code:     http.StatusOK,
+				response: `"crc_valid":false`,
+			},
+		},
+		{
+			name: "Parse: Canceled While Verifying CRC",
+			controller: &mockQRISController{
+				ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+				VerifyCRCFunc: func(qris *entities.QRIS) (bool, error) {
+					return false, controllers.NewError(controllers.ErrorKindCanceled, controllers.ErrorCodeCanceled, context.Canceled)
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Parse
+			},
+			requestBody: `{"qr_string": "valid"}`,
+			want: want{
+				code:     statusClientClosedRequest,
+				response: `"code":"canceled"`,
+			},
+		},
+		{
+			name: "Parse: Timeout While Verifying CRC",
+			controller: &mockQRISController{
+				ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+				VerifyCRCFunc: func(qris *entities.QRIS) (bool, error) {
+					return false, controllers.NewError(controllers.ErrorKindTimeout, controllers.ErrorCodeDeadlineExceeded, context.DeadlineExceeded)
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Parse
+			},
+			requestBody: `{"qr_string": "valid"}`,
+			want: want{
+				code:     http.StatusGatewayTimeout,
+				response: `"code":"deadline_exceeded"`,
+			},
+		},
+		{
+			name:       "Convert: Missing Payment Amount",
+			controller: &mockQRISController{},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Convert
+			},
+			requestBody: `{"qr_string": "valid", "payment_amount": 1337}`,
+			want: want{
+				code:     http.StatusBadRequest,
+				response: `"code":"invalid_request"`,
+			},
+		},
+		{
+			name: "Convert: Invalid Amount",
+			controller: &mockQRISController{
+				PatchFunc: func(qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+					return "", nil, controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInvalidAmount, fmt.Errorf("payment amount must be a positive decimal")), nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Convert
+			},
+			requestBody: `{"qr_string": "valid", "payment_amount": "1337,50"}`,
+			want: want{
+				code:     http.StatusUnprocessableEntity,
+				response: `"code":"invalid_amount"`,
+			},
+		},
+		{
+			name: "Convert: Merchant Not Allowed",
+			controller: &mockQRISController{
+				PatchFunc: func(qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+					return "", nil, controllers.NewError(controllers.ErrorKindForbidden, controllers.ErrorCodeMerchantNotAllowed, fmt.Errorf("merchant is not allowed for this API key")), nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Convert
+			},
+			requestBody: `{"qr_string": "valid", "payment_amount": "1337"}`,
+			want: want{
+				code:     http.StatusForbidden,
+				response: `"code":"merchant_not_allowed"`,
+			},
+		},
+		{
+			name: "Convert: Success",
+			controller: &mockQRISController{
+				PatchFunc: func(qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+					if patch.PaymentAmount != "1337.50" || patch.MerchantCity != nil || *patch.AdditionalInformation.TerminalLabel != "" || patch.AdditionalInformation.BillNumber != nil {
+						return "", nil, fmt.Errorf("unexpected patch %v", patch), nil
+					}
+					return "QRIS Modified String", &entities.QRIS{}, nil, nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Convert
+			},
+			requestBody: `{"qr_string": "valid", "payment_amount": "1337.50", "additional_information": {"terminal_label": ""}}`,
+			want: want{
+				code:     http.StatusOK,
+				response: `"qr_string":"QRIS Modified String"`,
+			},
+		},
+		{
+			name: "Generate: Invalid CRC",
+			controller: &mockQRISController{
+				GenerateFunc: func(qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+					return "", controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInvalidCRC, fmt.Errorf("invalid CRC16-CCITT code")), nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Generate
+			},
+			requestBody: `{"qr_string": "valid"}`,
+			want: want{
+				code:     http.StatusUnprocessableEntity,
+				response: `"code":"invalid_crc"`,
+			},
+		},
+		{
+			name: "Generate: Success",
+			controller: &mockQRISController{
+				GenerateFunc: func(qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+					if qrCodeOptions.Format != "svg" || qrCodeOptions.Size != 512 {
+						return "", fmt.Errorf("unexpected QR code options %v", qrCodeOptions), nil
+					}
+					return "data:image/svg+xml;base64,QRIS Code SVG Base64", nil, nil
+				},
+			},
+			handler: func(h QRISV2Interface) gin.HandlerFunc {
+				return h.Generate
+			},
+			requestBody: `{"qr_string": "valid", "qr_code": {"format": "svg", "size": 512}}`,
+			want: want{
+				code:     http.StatusOK,
+				response: `"qr_code":"data:image/svg+xml;base64,QRIS Code SVG Base64"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewQRISV2(test.controller)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.POST("/", test.handler(handler))
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(test.requestBody))
+			req.Header.Set(testHeaderContentType, testHeaderContentTypeValue)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != test.want.code {
+				t.Errorf(expectedStatusCode, test.want.code, recorder.Code)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.want.response)) {
+				t.Errorf(expectedResponseToContain, test.want.response, recorder.Body.String())
+			}
+		})
+	}
+}
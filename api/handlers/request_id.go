@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	requestLoggerKey = "go-qris/logger"
+)
+
+// requestIDPattern keeps client supplied IDs safe to log and echo.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type RequestID struct {
+	logger *slog.Logger
+}
+
+type RequestIDInterface interface {
+	Middleware(c *gin.Context)
+}
+
+func NewRequestID(logger *slog.Logger) RequestIDInterface {
+	return &RequestID{
+		logger: logger,
+	}
+}
+
+// Middleware reuses the caller's X-Request-ID or generates one, echoes it and
+// keeps a logger carrying it for the rest of the request. Every request ends
+// with one access log record.
+func (h *RequestID) Middleware(c *gin.Context) {
+	start := time.Now()
+	requestID := SafeRequestID(c.GetHeader(RequestIDHeader))
+	c.Header(RequestIDHeader, requestID)
+
+	logger := h.logger.With("request_id", requestID)
+	c.Set(requestLoggerKey, logger)
+	c.Next()
+
+	level := slog.LevelInfo
+	if c.Writer.Status() >= 500 {
+		level = slog.LevelError
+	}
+	attrs := []any{
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"status", c.Writer.Status(),
+		"duration", time.Since(start),
+		"client_ip", c.ClientIP(),
+	}
+	if c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
+		attrs = append(attrs, "client_cert", c.Request.TLS.PeerCertificates[0].Subject.CommonName)
+	}
+	logger.Log(c.Request.Context(), level, "request completed", attrs...)
+}
+
+// RequestLogger returns the logger of the current request, or the default
+// one outside the middleware.
+func RequestLogger(c *gin.Context) *slog.Logger {
+	if logger, ok := c.Value(requestLoggerKey).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// requestController scopes the controller to the logger and tenant of the
+// current request. Its methods take c.Request.Context(), so a client hanging
+// up stops the work.
+func requestController(c *gin.Context, qrisController controllers.QRISInterface) controllers.QRISInterface {
+	qrisController = qrisController.WithLogger(RequestLogger(c))
+	if tenant, ok := RequestTenant(c); ok {
+		qrisController = qrisController.WithTenant(tenant)
+	}
+
+	return qrisController
+}
+
+// SafeRequestID returns requestID when it is safe to log and echo, or a new
+// one otherwise.
+func SafeRequestID(requestID string) string {
+	if requestIDPattern.MatchString(requestID) {
+		return requestID
+	}
+
+	return newRequestID()
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
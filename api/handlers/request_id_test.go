@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	qrString := "00020101021126570011ID.DANA.WWW011893600915302259148102090225914810303UMI51440014ID.CO.QRIS.WWW0215ID10200176114730303UMI5204581253033605802ID5922Warung Sayur Bu Sugeng6010Kab. Demak610559567630458C7"
+
+	tests := []struct {
+		name      string
+		requestID string
+		want      string
+	}{
+		{
+			name:      "Success: Reuse Request ID",
+			requestID: "req-1337",
+			want:      "req-1337",
+		},
+		{
+			name:      "Success: Generate Missing Request ID",
+			requestID: "",
+		},
+		{
+			name:      "Success: Replace Unsafe Request ID",
+			requestID: "req 1337\nforged=true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{
+				ReplaceAttr: utils.RedactAttr,
+			}))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(NewRequestID(logger).Middleware)
+			router.POST("/parse", func(c *gin.Context) {
+				RequestLogger(c).Warn("QRIS operation failed", "qr_string", qrString)
+				c.Status(http.StatusNoContent)
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/parse", nil)
+			if test.requestID != "" {
+				request.Header.Set(RequestIDHeader, test.requestID)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			got := recorder.Header().Get(RequestIDHeader)
+			if test.want != "" && got != test.want {
+				t.Errorf(expectedButGotMessage, RequestIDHeader, test.want, got)
+			}
+			if !requestIDPattern.MatchString(got) {
+				t.Errorf(expectedButGotMessage, RequestIDHeader, requestIDPattern, got)
+			}
+
+			logs := output.String()
+			if count := strings.Count(logs, `"request_id":"`+got+`"`); count != 2 {
+				t.Errorf(expectedButGotMessage, "records with request_id", 2, logs)
+			}
+			if !strings.Contains(logs, `"msg":"request completed"`) || !strings.Contains(logs, `"route":"/parse"`) {
+				t.Errorf(expectedResponseToContain, "request completed", logs)
+			}
+			if strings.Contains(logs, qrString) || strings.Contains(logs, "ID1020017611473") {
+				t.Errorf(expectedButGotMessage, "redacted logs", "no QR string or NMID", logs)
+			}
+		})
+	}
+}
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	if got := RequestLogger(c); got != slog.Default() {
+		t.Errorf(expectedButGotMessage, "RequestLogger", slog.Default(), got)
+	}
+}
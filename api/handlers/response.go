@@ -1,8 +1,68 @@
 package handlers
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ErrorCodeInvalidRequest = "invalid_request"
+	ErrorCodeInternal       = controllers.ErrorCodeInternal
+)
+
+// statusClientClosedRequest is the nginx status for a client that went away
+// before the response was written.
+const statusClientClosedRequest = 499
+
+var errorStatusCodes = map[controllers.ErrorKind]int{
+	controllers.ErrorKindInternal:  http.StatusInternalServerError,
+	controllers.ErrorKindMalformed: http.StatusBadRequest,
+	controllers.ErrorKindInvalid:   http.StatusUnprocessableEntity,
+	controllers.ErrorKindForbidden: http.StatusForbidden,
+	controllers.ErrorKindTooLarge:  http.StatusRequestEntityTooLarge,
+	controllers.ErrorKindCanceled:  statusClientClosedRequest,
+	controllers.ErrorKindTimeout:   http.StatusGatewayTimeout,
+}
+
 type Response struct {
 	Success bool      `json:"success"`
+	Code    string    `json:"code,omitempty"`
 	Message string    `json:"message"`
 	Errors  *[]string `json:"errors"`
 	Data    any       `json:"data"`
 }
+
+// writeError responds with the status and code of a typed controller error,
+// any other error is treated as an internal one.
+func writeError(c *gin.Context, err error, errs *[]string) {
+	status, code := http.StatusInternalServerError, ErrorCodeInternal
+	var controllerErr *controllers.Error
+	if errors.As(err, &controllerErr) {
+		status, code = errorStatusCodes[controllerErr.Kind], controllerErr.Code
+	}
+
+	c.JSON(status, Response{
+		Success: false,
+		Code:    code,
+		Message: err.Error(),
+		Errors:  errs,
+		Data:    nil,
+	})
+}
+
+// writeBadRequest reports a body that can not be bound, or one cut off by
+// the body limit.
+func writeBadRequest(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(c, controllers.NewError(controllers.ErrorKindTooLarge, ErrorCodeRequestTooLarge, fmt.Errorf("request body exceeds %d bytes", maxBytesErr.Limit)), nil)
+		return
+	}
+
+	writeError(c, controllers.NewError(controllers.ErrorKindMalformed, ErrorCodeInvalidRequest, err), nil)
+}
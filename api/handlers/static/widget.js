@@ -0,0 +1,80 @@
+/*
+ * Go-QRIS widget: renders a dynamic QRIS for a static one and an amount.
+ *
+ *   <div data-go-qris data-qr-string="000201..." data-amount="1337"
+ *        data-publishable-key="..." data-format="svg" data-size="256"></div>
+ *   <script src="https://your-go-qris-host/widget.js" async></script>
+ *
+ * Only ever put a tenant's publishable key on a page: it can only convert,
+ * and only from the tenant's allowed origins. Secret API keys belong on a
+ * server, e.g. one that proxies /v1/convert for the page.
+ *
+ * Every element with data-go-qris is rendered on load and again whenever its
+ * data-amount changes. window.GoQRIS.render(element) renders on demand. The
+ * element receives a "go-qris:rendered" event with the dynamic QR string, or
+ * "go-qris:error" with the API response, in event.detail.
+ */
+(() => {
+  "use strict";
+
+  const script = document.currentScript;
+  const baseURL = new URL(".", script ? script.src : window.location.href).href;
+
+  const dispatch = (element, name, detail) => {
+    element.dispatchEvent(new CustomEvent(name, { bubbles: true, detail }));
+  };
+
+  const render = async (element) => {
+    const data = element.dataset;
+    const headers = { "Content-Type": "application/json" };
+    if (data.publishableKey) headers["X-API-Key"] = data.publishableKey;
+
+    element.setAttribute("aria-busy", "true");
+    try {
+      const response = await fetch(new URL("v1/convert", baseURL), {
+        method: "POST",
+        headers,
+        body: JSON.stringify({
+          qr_string: data.qrString || "",
+          payment_amount: Number(data.amount || 0),
+          qr_code_format: data.format || "svg",
+          qr_code_size: Number(data.size || 0),
+        }),
+      });
+      const body = await response.json();
+      if (!response.ok || !body.success) throw body;
+
+      const image = document.createElement("img");
+      image.src = body.data.qr_code;
+      image.alt = data.alt || "QRIS";
+      if (data.size) image.width = image.height = Number(data.size);
+      element.replaceChildren(image);
+      dispatch(element, "go-qris:rendered", { qrString: body.data.qr_string });
+    } catch (error) {
+      const message = document.createElement("p");
+      message.textContent = (error && error.message) || "Unable to render QRIS";
+      element.replaceChildren(message);
+      dispatch(element, "go-qris:error", error);
+    } finally {
+      element.removeAttribute("aria-busy");
+    }
+  };
+
+  const observer = new MutationObserver((mutations) => {
+    mutations.forEach((mutation) => render(mutation.target));
+  });
+
+  const mount = () => {
+    document.querySelectorAll("[data-go-qris]").forEach((element) => {
+      render(element);
+      observer.observe(element, { attributeFilter: ["data-amount"] });
+    });
+  };
+
+  window.GoQRIS = { render };
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", mount);
+  } else {
+    mount();
+  }
+})();
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed static/widget.js
+var widgetJS []byte
+
+type Widget struct{}
+
+type WidgetInterface interface {
+	Script(c *gin.Context)
+}
+
+func NewWidget() WidgetInterface {
+	return &Widget{}
+}
+
+// Script serves the embeddable widget, see static/widget.js for its markup.
+func (h *Widget) Script(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "text/javascript; charset=utf-8", widgetJS)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWidgetScript(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/widget.js", NewWidget().Script)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/widget.js", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf(expectedStatusCode, http.StatusOK, recorder.Code)
+	}
+	if got := recorder.Header().Get(testHeaderContentType); got != "text/javascript; charset=utf-8" {
+		t.Errorf(expectedButGotMessage, testHeaderContentType, "text/javascript; charset=utf-8", got)
+	}
+	if want := "v1/convert"; !bytes.Contains(recorder.Body.Bytes(), []byte(want)) {
+		t.Errorf(expectedResponseToContain, want, recorder.Body.String())
+	}
+}
@@ -0,0 +1,1868 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: qris/v1/qris.proto
+
+// Package qris.v1 is the gRPC counterpart of the REST API. Its methods mirror
+// controllers.QRISInterface and share its validation, tenants and limits.
+
+package qrisv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Data    string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	mi := &file_qris_v1_qris_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
+func (*Data) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Data) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Data) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Data) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type Acquirer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string          `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Content string          `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Data    string          `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Detail  *AcquirerDetail `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *Acquirer) Reset() {
+	*x = Acquirer{}
+	mi := &file_qris_v1_qris_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Acquirer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Acquirer) ProtoMessage() {}
+
+func (x *Acquirer) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Acquirer.ProtoReflect.Descriptor instead.
+func (*Acquirer) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Acquirer) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Acquirer) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Acquirer) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Acquirer) GetDetail() *AcquirerDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+type AcquirerDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Site       *Data `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	Mpan       *Data `protobuf:"bytes,2,opt,name=mpan,proto3" json:"mpan,omitempty"`
+	TerminalId *Data `protobuf:"bytes,3,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	Category   *Data `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *AcquirerDetail) Reset() {
+	*x = AcquirerDetail{}
+	mi := &file_qris_v1_qris_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquirerDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquirerDetail) ProtoMessage() {}
+
+func (x *AcquirerDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquirerDetail.ProtoReflect.Descriptor instead.
+func (*AcquirerDetail) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AcquirerDetail) GetSite() *Data {
+	if x != nil {
+		return x.Site
+	}
+	return nil
+}
+
+func (x *AcquirerDetail) GetMpan() *Data {
+	if x != nil {
+		return x.Mpan
+	}
+	return nil
+}
+
+func (x *AcquirerDetail) GetTerminalId() *Data {
+	if x != nil {
+		return x.TerminalId
+	}
+	return nil
+}
+
+func (x *AcquirerDetail) GetCategory() *Data {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type Switching struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string           `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Content string           `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Data    string           `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Detail  *SwitchingDetail `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *Switching) Reset() {
+	*x = Switching{}
+	mi := &file_qris_v1_qris_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Switching) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Switching) ProtoMessage() {}
+
+func (x *Switching) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Switching.ProtoReflect.Descriptor instead.
+func (*Switching) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Switching) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Switching) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Switching) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Switching) GetDetail() *SwitchingDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+type SwitchingDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Site     *Data `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	Nmid     *Data `protobuf:"bytes,2,opt,name=nmid,proto3" json:"nmid,omitempty"`
+	Category *Data `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *SwitchingDetail) Reset() {
+	*x = SwitchingDetail{}
+	mi := &file_qris_v1_qris_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchingDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchingDetail) ProtoMessage() {}
+
+func (x *SwitchingDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchingDetail.ProtoReflect.Descriptor instead.
+func (*SwitchingDetail) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SwitchingDetail) GetSite() *Data {
+	if x != nil {
+		return x.Site
+	}
+	return nil
+}
+
+func (x *SwitchingDetail) GetNmid() *Data {
+	if x != nil {
+		return x.Nmid
+	}
+	return nil
+}
+
+func (x *SwitchingDetail) GetCategory() *Data {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type AdditionalInformation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string                       `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Content string                       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Data    string                       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Detail  *AdditionalInformationDetail `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *AdditionalInformation) Reset() {
+	*x = AdditionalInformation{}
+	mi := &file_qris_v1_qris_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdditionalInformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalInformation) ProtoMessage() {}
+
+func (x *AdditionalInformation) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalInformation.ProtoReflect.Descriptor instead.
+func (*AdditionalInformation) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AdditionalInformation) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *AdditionalInformation) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *AdditionalInformation) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *AdditionalInformation) GetDetail() *AdditionalInformationDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+type AdditionalInformationDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BillNumber                    *Data `protobuf:"bytes,1,opt,name=bill_number,json=billNumber,proto3" json:"bill_number,omitempty"`
+	MobileNumber                  *Data `protobuf:"bytes,2,opt,name=mobile_number,json=mobileNumber,proto3" json:"mobile_number,omitempty"`
+	StoreLabel                    *Data `protobuf:"bytes,3,opt,name=store_label,json=storeLabel,proto3" json:"store_label,omitempty"`
+	LoyaltyNumber                 *Data `protobuf:"bytes,4,opt,name=loyalty_number,json=loyaltyNumber,proto3" json:"loyalty_number,omitempty"`
+	ReferenceLabel                *Data `protobuf:"bytes,5,opt,name=reference_label,json=referenceLabel,proto3" json:"reference_label,omitempty"`
+	CustomerLabel                 *Data `protobuf:"bytes,6,opt,name=customer_label,json=customerLabel,proto3" json:"customer_label,omitempty"`
+	TerminalLabel                 *Data `protobuf:"bytes,7,opt,name=terminal_label,json=terminalLabel,proto3" json:"terminal_label,omitempty"`
+	PurposeOfTransaction          *Data `protobuf:"bytes,8,opt,name=purpose_of_transaction,json=purposeOfTransaction,proto3" json:"purpose_of_transaction,omitempty"`
+	AdditionalConsumerDataRequest *Data `protobuf:"bytes,9,opt,name=additional_consumer_data_request,json=additionalConsumerDataRequest,proto3" json:"additional_consumer_data_request,omitempty"`
+	MerchantTaxId                 *Data `protobuf:"bytes,10,opt,name=merchant_tax_id,json=merchantTaxId,proto3" json:"merchant_tax_id,omitempty"`
+	MerchantChannel               *Data `protobuf:"bytes,11,opt,name=merchant_channel,json=merchantChannel,proto3" json:"merchant_channel,omitempty"`
+	Rfu                           *Data `protobuf:"bytes,12,opt,name=rfu,proto3" json:"rfu,omitempty"`
+	PaymentSystemSpecific         *Data `protobuf:"bytes,13,opt,name=payment_system_specific,json=paymentSystemSpecific,proto3" json:"payment_system_specific,omitempty"`
+}
+
+func (x *AdditionalInformationDetail) Reset() {
+	*x = AdditionalInformationDetail{}
+	mi := &file_qris_v1_qris_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdditionalInformationDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalInformationDetail) ProtoMessage() {}
+
+func (x *AdditionalInformationDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalInformationDetail.ProtoReflect.Descriptor instead.
+func (*AdditionalInformationDetail) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AdditionalInformationDetail) GetBillNumber() *Data {
+	if x != nil {
+		return x.BillNumber
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetMobileNumber() *Data {
+	if x != nil {
+		return x.MobileNumber
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetStoreLabel() *Data {
+	if x != nil {
+		return x.StoreLabel
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetLoyaltyNumber() *Data {
+	if x != nil {
+		return x.LoyaltyNumber
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetReferenceLabel() *Data {
+	if x != nil {
+		return x.ReferenceLabel
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetCustomerLabel() *Data {
+	if x != nil {
+		return x.CustomerLabel
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetTerminalLabel() *Data {
+	if x != nil {
+		return x.TerminalLabel
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetPurposeOfTransaction() *Data {
+	if x != nil {
+		return x.PurposeOfTransaction
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetAdditionalConsumerDataRequest() *Data {
+	if x != nil {
+		return x.AdditionalConsumerDataRequest
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetMerchantTaxId() *Data {
+	if x != nil {
+		return x.MerchantTaxId
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetMerchantChannel() *Data {
+	if x != nil {
+		return x.MerchantChannel
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetRfu() *Data {
+	if x != nil {
+		return x.Rfu
+	}
+	return nil
+}
+
+func (x *AdditionalInformationDetail) GetPaymentSystemSpecific() *Data {
+	if x != nil {
+		return x.PaymentSystemSpecific
+	}
+	return nil
+}
+
+type QRIS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version               *Data                  `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Category              *Data                  `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Acquirer              *Acquirer              `protobuf:"bytes,3,opt,name=acquirer,proto3" json:"acquirer,omitempty"`
+	Switching             *Switching             `protobuf:"bytes,4,opt,name=switching,proto3" json:"switching,omitempty"`
+	MerchantCategoryCode  *Data                  `protobuf:"bytes,5,opt,name=merchant_category_code,json=merchantCategoryCode,proto3" json:"merchant_category_code,omitempty"`
+	CurrencyCode          *Data                  `protobuf:"bytes,6,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	PaymentAmount         *Data                  `protobuf:"bytes,7,opt,name=payment_amount,json=paymentAmount,proto3" json:"payment_amount,omitempty"`
+	PaymentFeeCategory    *Data                  `protobuf:"bytes,8,opt,name=payment_fee_category,json=paymentFeeCategory,proto3" json:"payment_fee_category,omitempty"`
+	PaymentFee            *Data                  `protobuf:"bytes,9,opt,name=payment_fee,json=paymentFee,proto3" json:"payment_fee,omitempty"`
+	CountryCode           *Data                  `protobuf:"bytes,10,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	MerchantName          *Data                  `protobuf:"bytes,11,opt,name=merchant_name,json=merchantName,proto3" json:"merchant_name,omitempty"`
+	MerchantCity          *Data                  `protobuf:"bytes,12,opt,name=merchant_city,json=merchantCity,proto3" json:"merchant_city,omitempty"`
+	MerchantPostalCode    *Data                  `protobuf:"bytes,13,opt,name=merchant_postal_code,json=merchantPostalCode,proto3" json:"merchant_postal_code,omitempty"`
+	AdditionalInformation *AdditionalInformation `protobuf:"bytes,14,opt,name=additional_information,json=additionalInformation,proto3" json:"additional_information,omitempty"`
+	CrcCode               *Data                  `protobuf:"bytes,15,opt,name=crc_code,json=crcCode,proto3" json:"crc_code,omitempty"`
+}
+
+func (x *QRIS) Reset() {
+	*x = QRIS{}
+	mi := &file_qris_v1_qris_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRIS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRIS) ProtoMessage() {}
+
+func (x *QRIS) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QRIS.ProtoReflect.Descriptor instead.
+func (*QRIS) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QRIS) GetVersion() *Data {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *QRIS) GetCategory() *Data {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *QRIS) GetAcquirer() *Acquirer {
+	if x != nil {
+		return x.Acquirer
+	}
+	return nil
+}
+
+func (x *QRIS) GetSwitching() *Switching {
+	if x != nil {
+		return x.Switching
+	}
+	return nil
+}
+
+func (x *QRIS) GetMerchantCategoryCode() *Data {
+	if x != nil {
+		return x.MerchantCategoryCode
+	}
+	return nil
+}
+
+func (x *QRIS) GetCurrencyCode() *Data {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return nil
+}
+
+func (x *QRIS) GetPaymentAmount() *Data {
+	if x != nil {
+		return x.PaymentAmount
+	}
+	return nil
+}
+
+func (x *QRIS) GetPaymentFeeCategory() *Data {
+	if x != nil {
+		return x.PaymentFeeCategory
+	}
+	return nil
+}
+
+func (x *QRIS) GetPaymentFee() *Data {
+	if x != nil {
+		return x.PaymentFee
+	}
+	return nil
+}
+
+func (x *QRIS) GetCountryCode() *Data {
+	if x != nil {
+		return x.CountryCode
+	}
+	return nil
+}
+
+func (x *QRIS) GetMerchantName() *Data {
+	if x != nil {
+		return x.MerchantName
+	}
+	return nil
+}
+
+func (x *QRIS) GetMerchantCity() *Data {
+	if x != nil {
+		return x.MerchantCity
+	}
+	return nil
+}
+
+func (x *QRIS) GetMerchantPostalCode() *Data {
+	if x != nil {
+		return x.MerchantPostalCode
+	}
+	return nil
+}
+
+func (x *QRIS) GetAdditionalInformation() *AdditionalInformation {
+	if x != nil {
+		return x.AdditionalInformation
+	}
+	return nil
+}
+
+func (x *QRIS) GetCrcCode() *Data {
+	if x != nil {
+		return x.CrcCode
+	}
+	return nil
+}
+
+// QRCodeOptions overrides the server's, or the tenant's, QR code defaults.
+// Unset fields keep the default, margin has presence so that 0 can be asked
+// for.
+type QRCodeOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format               string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Size                 int32  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModuleSize           int32  `protobuf:"varint,3,opt,name=module_size,json=moduleSize,proto3" json:"module_size,omitempty"`
+	Margin               *int32 `protobuf:"varint,4,opt,name=margin,proto3,oneof" json:"margin,omitempty"`
+	ErrorCorrectionLevel string `protobuf:"bytes,5,opt,name=error_correction_level,json=errorCorrectionLevel,proto3" json:"error_correction_level,omitempty"`
+	ForegroundColor      string `protobuf:"bytes,6,opt,name=foreground_color,json=foregroundColor,proto3" json:"foreground_color,omitempty"`
+	BackgroundColor      string `protobuf:"bytes,7,opt,name=background_color,json=backgroundColor,proto3" json:"background_color,omitempty"`
+	Logo                 []byte `protobuf:"bytes,8,opt,name=logo,proto3" json:"logo,omitempty"`
+}
+
+func (x *QRCodeOptions) Reset() {
+	*x = QRCodeOptions{}
+	mi := &file_qris_v1_qris_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCodeOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCodeOptions) ProtoMessage() {}
+
+func (x *QRCodeOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QRCodeOptions.ProtoReflect.Descriptor instead.
+func (*QRCodeOptions) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QRCodeOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *QRCodeOptions) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *QRCodeOptions) GetModuleSize() int32 {
+	if x != nil {
+		return x.ModuleSize
+	}
+	return 0
+}
+
+func (x *QRCodeOptions) GetMargin() int32 {
+	if x != nil && x.Margin != nil {
+		return *x.Margin
+	}
+	return 0
+}
+
+func (x *QRCodeOptions) GetErrorCorrectionLevel() string {
+	if x != nil {
+		return x.ErrorCorrectionLevel
+	}
+	return ""
+}
+
+func (x *QRCodeOptions) GetForegroundColor() string {
+	if x != nil {
+		return x.ForegroundColor
+	}
+	return ""
+}
+
+func (x *QRCodeOptions) GetBackgroundColor() string {
+	if x != nil {
+		return x.BackgroundColor
+	}
+	return ""
+}
+
+func (x *QRCodeOptions) GetLogo() []byte {
+	if x != nil {
+		return x.Logo
+	}
+	return nil
+}
+
+type StickerOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format               string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Dpi                  int32  `protobuf:"varint,2,opt,name=dpi,proto3" json:"dpi,omitempty"`
+	ErrorCorrectionLevel string `protobuf:"bytes,3,opt,name=error_correction_level,json=errorCorrectionLevel,proto3" json:"error_correction_level,omitempty"`
+}
+
+func (x *StickerOptions) Reset() {
+	*x = StickerOptions{}
+	mi := &file_qris_v1_qris_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StickerOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StickerOptions) ProtoMessage() {}
+
+func (x *StickerOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StickerOptions.ProtoReflect.Descriptor instead.
+func (*StickerOptions) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StickerOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *StickerOptions) GetDpi() int32 {
+	if x != nil {
+		return x.Dpi
+	}
+	return 0
+}
+
+func (x *StickerOptions) GetErrorCorrectionLevel() string {
+	if x != nil {
+		return x.ErrorCorrectionLevel
+	}
+	return ""
+}
+
+type ParseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString string `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+}
+
+func (x *ParseRequest) Reset() {
+	*x = ParseRequest{}
+	mi := &file_qris_v1_qris_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseRequest) ProtoMessage() {}
+
+func (x *ParseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseRequest.ProtoReflect.Descriptor instead.
+func (*ParseRequest) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ParseRequest) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+type ParseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Qris     *QRIS `protobuf:"bytes,1,opt,name=qris,proto3" json:"qris,omitempty"`
+	CrcValid bool  `protobuf:"varint,2,opt,name=crc_valid,json=crcValid,proto3" json:"crc_valid,omitempty"`
+}
+
+func (x *ParseResponse) Reset() {
+	*x = ParseResponse{}
+	mi := &file_qris_v1_qris_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResponse) ProtoMessage() {}
+
+func (x *ParseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResponse.ProtoReflect.Descriptor instead.
+func (*ParseResponse) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ParseResponse) GetQris() *QRIS {
+	if x != nil {
+		return x.Qris
+	}
+	return nil
+}
+
+func (x *ParseResponse) GetCrcValid() bool {
+	if x != nil {
+		return x.CrcValid
+	}
+	return false
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString string `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_qris_v1_qris_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidateRequest) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Code is the REST error code, e.g. invalid_crc, when valid is false.
+	Code    string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Issues  []string `protobuf:"bytes,4,rep,name=issues,proto3" json:"issues,omitempty"`
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_qris_v1_qris_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetIssues() []string {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+type ConvertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString           string `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+	MerchantCity       string `protobuf:"bytes,2,opt,name=merchant_city,json=merchantCity,proto3" json:"merchant_city,omitempty"`
+	MerchantPostalCode string `protobuf:"bytes,3,opt,name=merchant_postal_code,json=merchantPostalCode,proto3" json:"merchant_postal_code,omitempty"`
+	PaymentAmount      uint32 `protobuf:"varint,4,opt,name=payment_amount,json=paymentAmount,proto3" json:"payment_amount,omitempty"`
+	// PaymentFeeCategory is FIXED or PERCENT, empty for no fee.
+	PaymentFeeCategory string         `protobuf:"bytes,5,opt,name=payment_fee_category,json=paymentFeeCategory,proto3" json:"payment_fee_category,omitempty"`
+	PaymentFee         uint32         `protobuf:"varint,6,opt,name=payment_fee,json=paymentFee,proto3" json:"payment_fee,omitempty"`
+	TerminalLabel      string         `protobuf:"bytes,7,opt,name=terminal_label,json=terminalLabel,proto3" json:"terminal_label,omitempty"`
+	QrCode             *QRCodeOptions `protobuf:"bytes,8,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_qris_v1_qris_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConvertRequest) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetMerchantCity() string {
+	if x != nil {
+		return x.MerchantCity
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetMerchantPostalCode() string {
+	if x != nil {
+		return x.MerchantPostalCode
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetPaymentAmount() uint32 {
+	if x != nil {
+		return x.PaymentAmount
+	}
+	return 0
+}
+
+func (x *ConvertRequest) GetPaymentFeeCategory() string {
+	if x != nil {
+		return x.PaymentFeeCategory
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetPaymentFee() uint32 {
+	if x != nil {
+		return x.PaymentFee
+	}
+	return 0
+}
+
+func (x *ConvertRequest) GetTerminalLabel() string {
+	if x != nil {
+		return x.TerminalLabel
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetQrCode() *QRCodeOptions {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+type ConvertResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString    string `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+	QrCode      []byte `protobuf:"bytes,2,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_qris_v1_qris_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConvertResponse) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetQrCode() []byte {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type GenerateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString string         `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+	QrCode   *QRCodeOptions `protobuf:"bytes,2,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_qris_v1_qris_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GenerateRequest) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetQrCode() *QRCodeOptions {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrCode      []byte `protobuf:"bytes,1,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_qris_v1_qris_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GenerateResponse) GetQrCode() []byte {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+func (x *GenerateResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type RenderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QrString string          `protobuf:"bytes,1,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+	Sticker  *StickerOptions `protobuf:"bytes,2,opt,name=sticker,proto3" json:"sticker,omitempty"`
+}
+
+func (x *RenderRequest) Reset() {
+	*x = RenderRequest{}
+	mi := &file_qris_v1_qris_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderRequest) ProtoMessage() {}
+
+func (x *RenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderRequest.ProtoReflect.Descriptor instead.
+func (*RenderRequest) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RenderRequest) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+func (x *RenderRequest) GetSticker() *StickerOptions {
+	if x != nil {
+		return x.Sticker
+	}
+	return nil
+}
+
+type RenderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sticker     []byte `protobuf:"bytes,1,opt,name=sticker,proto3" json:"sticker,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *RenderResponse) Reset() {
+	*x = RenderResponse{}
+	mi := &file_qris_v1_qris_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderResponse) ProtoMessage() {}
+
+func (x *RenderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_qris_v1_qris_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderResponse.ProtoReflect.Descriptor instead.
+func (*RenderResponse) Descriptor() ([]byte, []int) {
+	return file_qris_v1_qris_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RenderResponse) GetSticker() []byte {
+	if x != nil {
+		return x.Sticker
+	}
+	return nil
+}
+
+func (x *RenderResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+var File_qris_v1_qris_proto protoreflect.FileDescriptor
+
+var file_qris_v1_qris_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x71, 0x72, 0x69, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x46, 0x0a,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7b, 0x0a, 0x08, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x2f, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x22, 0xb1, 0x01, 0x0a, 0x0e, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x72, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x6d, 0x70, 0x61, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x70, 0x61, 0x6e, 0x12, 0x2e, 0x0a, 0x0b, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x7d, 0x0a, 0x09, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68,
+	0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77,
+	0x69, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x82, 0x01, 0x0a, 0x0f, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68,
+	0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x69, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x04,
+	0x6e, 0x6d, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6e, 0x6d, 0x69, 0x64, 0x12,
+	0x29, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x41,
+	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x22, 0x81, 0x06, 0x0a, 0x1b, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x12, 0x2e, 0x0a, 0x0b, 0x62, 0x69, 0x6c, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x62, 0x69, 0x6c, 0x6c, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x32, 0x0a, 0x0d, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0c, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x34, 0x0a, 0x0e, 0x6c, 0x6f, 0x79, 0x61, 0x6c, 0x74,
+	0x79, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x6c,
+	0x6f, 0x79, 0x61, 0x6c, 0x74, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x0f,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x34, 0x0a, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x34, 0x0a, 0x0e, 0x74, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x43, 0x0a, 0x16, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x14, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x4f, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x56, 0x0a, 0x20, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x1d,
+	0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a,
+	0x0f, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x78, 0x5f, 0x69, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x54,
+	0x61, 0x78, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x10, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0f, 0x6d,
+	0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1f,
+	0x0a, 0x03, 0x72, 0x66, 0x75, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x03, 0x72, 0x66, 0x75, 0x12,
+	0x45, 0x0a, 0x17, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x15, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x70,
+	0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x22, 0xb7, 0x06, 0x0a, 0x04, 0x51, 0x52, 0x49, 0x53, 0x12,
+	0x27, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x08, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x72, 0x52, 0x08, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x72, 0x12, 0x30, 0x0a, 0x09, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x73, 0x77, 0x69, 0x74, 0x63,
+	0x68, 0x69, 0x6e, 0x67, 0x12, 0x43, 0x0a, 0x16, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x14, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x0d, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x34, 0x0a,
+	0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x66,
+	0x65, 0x65, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x12, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x66, 0x65, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x46, 0x65, 0x65, 0x12, 0x30, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0c, 0x6d, 0x65,
+	0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x0d, 0x6d, 0x65,
+	0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x69, 0x74, 0x79, 0x12, 0x3f,
+	0x0a, 0x14, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x12, 0x6d, 0x65, 0x72,
+	0x63, 0x68, 0x61, 0x6e, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x55, 0x0a, 0x16, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x15, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x08, 0x63, 0x72, 0x63, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x63, 0x72, 0x63, 0x43, 0x6f, 0x64, 0x65,
+	0x22, 0xa4, 0x02, 0x0a, 0x0d, 0x51, 0x52, 0x43, 0x6f, 0x64, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1b, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x34, 0x0a, 0x16,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x6f, 0x72, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x6f,
+	0x72, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x29, 0x0a,
+	0x10, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x6f,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x6f, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x22, 0x70, 0x0a, 0x0e, 0x53, 0x74, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x70, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x64, 0x70, 0x69, 0x12, 0x34, 0x0a, 0x16, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x14, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x2b, 0x0a, 0x0c, 0x50, 0x61, 0x72,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x72, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x72,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x4f, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x71, 0x72, 0x69, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x52, 0x49, 0x53, 0x52, 0x04, 0x71, 0x72, 0x69, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x72,
+	0x63, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63,
+	0x72, 0x63, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x22, 0x2e, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x72,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71,
+	0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x6e, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x22, 0xd6, 0x02, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x72,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71,
+	0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68,
+	0x61, 0x6e, 0x74, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x69, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x14,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6d, 0x65, 0x72, 0x63,
+	0x68, 0x61, 0x6e, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x2f, 0x0a, 0x07, 0x71, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x52, 0x43, 0x6f, 0x64,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x22, 0x6a, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x72, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x12, 0x17, 0x0a, 0x07, 0x71, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5f, 0x0a, 0x0f,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x71, 0x72, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x71, 0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2f, 0x0a, 0x07,
+	0x71, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x52, 0x43, 0x6f, 0x64, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x4e, 0x0a,
+	0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x71, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5f, 0x0a,
+	0x0d, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x71, 0x72, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x71, 0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x73,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x71,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x22, 0x4d,
+	0x0a, 0x0e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x32, 0xc0, 0x02,
+	0x0a, 0x0b, 0x51, 0x52, 0x49, 0x53, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a,
+	0x05, 0x50, 0x61, 0x72, 0x73, 0x65, 0x12, 0x15, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x18, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x71, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x74, 0x12, 0x17, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x71, 0x72, 0x69,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x12, 0x18, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x71, 0x72, 0x69,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
+	0x16, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66,
+	0x79, 0x76, 0x72, 0x69, 0x2f, 0x67, 0x6f, 0x2d, 0x71, 0x72, 0x69, 0x73, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x71, 0x72, 0x69, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x71,
+	0x72, 0x69, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_qris_v1_qris_proto_rawDescOnce sync.Once
+	file_qris_v1_qris_proto_rawDescData = file_qris_v1_qris_proto_rawDesc
+)
+
+func file_qris_v1_qris_proto_rawDescGZIP() []byte {
+	file_qris_v1_qris_proto_rawDescOnce.Do(func() {
+		file_qris_v1_qris_proto_rawDescData = protoimpl.X.CompressGZIP(file_qris_v1_qris_proto_rawDescData)
+	})
+	return file_qris_v1_qris_proto_rawDescData
+}
+
+var file_qris_v1_qris_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_qris_v1_qris_proto_goTypes = []any{
+	(*Data)(nil),                        // 0: qris.v1.Data
+	(*Acquirer)(nil),                    // 1: qris.v1.Acquirer
+	(*AcquirerDetail)(nil),              // 2: qris.v1.AcquirerDetail
+	(*Switching)(nil),                   // 3: qris.v1.Switching
+	(*SwitchingDetail)(nil),             // 4: qris.v1.SwitchingDetail
+	(*AdditionalInformation)(nil),       // 5: qris.v1.AdditionalInformation
+	(*AdditionalInformationDetail)(nil), // 6: qris.v1.AdditionalInformationDetail
+	(*QRIS)(nil),                        // 7: qris.v1.QRIS
+	(*QRCodeOptions)(nil),               // 8: qris.v1.QRCodeOptions
+	(*StickerOptions)(nil),              // 9: qris.v1.StickerOptions
+	(*ParseRequest)(nil),                // 10: qris.v1.ParseRequest
+	(*ParseResponse)(nil),               // 11: qris.v1.ParseResponse
+	(*ValidateRequest)(nil),             // 12: qris.v1.ValidateRequest
+	(*ValidateResponse)(nil),            // 13: qris.v1.ValidateResponse
+	(*ConvertRequest)(nil),              // 14: qris.v1.ConvertRequest
+	(*ConvertResponse)(nil),             // 15: qris.v1.ConvertResponse
+	(*GenerateRequest)(nil),             // 16: qris.v1.GenerateRequest
+	(*GenerateResponse)(nil),            // 17: qris.v1.GenerateResponse
+	(*RenderRequest)(nil),               // 18: qris.v1.RenderRequest
+	(*RenderResponse)(nil),              // 19: qris.v1.RenderResponse
+}
+var file_qris_v1_qris_proto_depIdxs = []int32{
+	2,  // 0: qris.v1.Acquirer.detail:type_name -> qris.v1.AcquirerDetail
+	0,  // 1: qris.v1.AcquirerDetail.site:type_name -> qris.v1.Data
+	0,  // 2: qris.v1.AcquirerDetail.mpan:type_name -> qris.v1.Data
+	0,  // 3: qris.v1.AcquirerDetail.terminal_id:type_name -> qris.v1.Data
+	0,  // 4: qris.v1.AcquirerDetail.category:type_name -> qris.v1.Data
+	4,  // 5: qris.v1.Switching.detail:type_name -> qris.v1.SwitchingDetail
+	0,  // 6: qris.v1.SwitchingDetail.site:type_name -> qris.v1.Data
+	0,  // 7: qris.v1.SwitchingDetail.nmid:type_name -> qris.v1.Data
+	0,  // 8: qris.v1.SwitchingDetail.category:type_name -> qris.v1.Data
+	6,  // 9: qris.v1.AdditionalInformation.detail:type_name -> qris.v1.AdditionalInformationDetail
+	0,  // 10: qris.v1.AdditionalInformationDetail.bill_number:type_name -> qris.v1.Data
+	0,  // 11: qris.v1.AdditionalInformationDetail.mobile_number:type_name -> qris.v1.Data
+	0,  // 12: qris.v1.AdditionalInformationDetail.store_label:type_name -> qris.v1.Data
+	0,  // 13: qris.v1.AdditionalInformationDetail.loyalty_number:type_name -> qris.v1.Data
+	0,  // 14: qris.v1.AdditionalInformationDetail.reference_label:type_name -> qris.v1.Data
+	0,  // 15: qris.v1.AdditionalInformationDetail.customer_label:type_name -> qris.v1.Data
+	0,  // 16: qris.v1.AdditionalInformationDetail.terminal_label:type_name -> qris.v1.Data
+	0,  // 17: qris.v1.AdditionalInformationDetail.purpose_of_transaction:type_name -> qris.v1.Data
+	0,  // 18: qris.v1.AdditionalInformationDetail.additional_consumer_data_request:type_name -> qris.v1.Data
+	0,  // 19: qris.v1.AdditionalInformationDetail.merchant_tax_id:type_name -> qris.v1.Data
+	0,  // 20: qris.v1.AdditionalInformationDetail.merchant_channel:type_name -> qris.v1.Data
+	0,  // 21: qris.v1.AdditionalInformationDetail.rfu:type_name -> qris.v1.Data
+	0,  // 22: qris.v1.AdditionalInformationDetail.payment_system_specific:type_name -> qris.v1.Data
+	0,  // 23: qris.v1.QRIS.version:type_name -> qris.v1.Data
+	0,  // 24: qris.v1.QRIS.category:type_name -> qris.v1.Data
+	1,  // 25: qris.v1.QRIS.acquirer:type_name -> qris.v1.Acquirer
+	3,  // 26: qris.v1.QRIS.switching:type_name -> qris.v1.Switching
+	0,  // 27: qris.v1.QRIS.merchant_category_code:type_name -> qris.v1.Data
+	0,  // 28: qris.v1.QRIS.currency_code:type_name -> qris.v1.Data
+	0,  // 29: qris.v1.QRIS.payment_amount:type_name -> qris.v1.Data
+	0,  // 30: qris.v1.QRIS.payment_fee_category:type_name -> qris.v1.Data
+	0,  // 31: qris.v1.QRIS.payment_fee:type_name -> qris.v1.Data
+	0,  // 32: qris.v1.QRIS.country_code:type_name -> qris.v1.Data
+	0,  // 33: qris.v1.QRIS.merchant_name:type_name -> qris.v1.Data
+	0,  // 34: qris.v1.QRIS.merchant_city:type_name -> qris.v1.Data
+	0,  // 35: qris.v1.QRIS.merchant_postal_code:type_name -> qris.v1.Data
+	5,  // 36: qris.v1.QRIS.additional_information:type_name -> qris.v1.AdditionalInformation
+	0,  // 37: qris.v1.QRIS.crc_code:type_name -> qris.v1.Data
+	7,  // 38: qris.v1.ParseResponse.qris:type_name -> qris.v1.QRIS
+	8,  // 39: qris.v1.ConvertRequest.qr_code:type_name -> qris.v1.QRCodeOptions
+	8,  // 40: qris.v1.GenerateRequest.qr_code:type_name -> qris.v1.QRCodeOptions
+	9,  // 41: qris.v1.RenderRequest.sticker:type_name -> qris.v1.StickerOptions
+	10, // 42: qris.v1.QRISService.Parse:input_type -> qris.v1.ParseRequest
+	12, // 43: qris.v1.QRISService.Validate:input_type -> qris.v1.ValidateRequest
+	14, // 44: qris.v1.QRISService.Convert:input_type -> qris.v1.ConvertRequest
+	16, // 45: qris.v1.QRISService.Generate:input_type -> qris.v1.GenerateRequest
+	18, // 46: qris.v1.QRISService.Render:input_type -> qris.v1.RenderRequest
+	11, // 47: qris.v1.QRISService.Parse:output_type -> qris.v1.ParseResponse
+	13, // 48: qris.v1.QRISService.Validate:output_type -> qris.v1.ValidateResponse
+	15, // 49: qris.v1.QRISService.Convert:output_type -> qris.v1.ConvertResponse
+	17, // 50: qris.v1.QRISService.Generate:output_type -> qris.v1.GenerateResponse
+	19, // 51: qris.v1.QRISService.Render:output_type -> qris.v1.RenderResponse
+	47, // [47:52] is the sub-list for method output_type
+	42, // [42:47] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_qris_v1_qris_proto_init() }
+func file_qris_v1_qris_proto_init() {
+	if File_qris_v1_qris_proto != nil {
+		return
+	}
+	file_qris_v1_qris_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_qris_v1_qris_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_qris_v1_qris_proto_goTypes,
+		DependencyIndexes: file_qris_v1_qris_proto_depIdxs,
+		MessageInfos:      file_qris_v1_qris_proto_msgTypes,
+	}.Build()
+	File_qris_v1_qris_proto = out.File
+	file_qris_v1_qris_proto_rawDesc = nil
+	file_qris_v1_qris_proto_goTypes = nil
+	file_qris_v1_qris_proto_depIdxs = nil
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fyvri/go-qris/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAPIKeyTenants(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+
+	env := *testEnv
+	env.APIKeys = `{"tenants": [
+		{"name": "sintas", "api_keys": ["sintas-0123456789"], "allowed_nmids": ["ID2020034073193"], "allowed_endpoints": ["*"]},
+		{"name": "warung", "api_keys": ["warung-0123456789"], "allowed_nmids": ["ID1020017611473"], "allowed_endpoints": ["parse", "convert"]},
+		{"name": "kiosk", "publishable_keys": ["kiosk-0123456789"], "allowed_origins": ["https://kiosk.example.com"], "allowed_nmids": ["ID2020034073193"], "allowed_endpoints": ["*"]}
+	]}`
+	tenants, err := bootstrap.NewTenants(&env)
+	if err != nil {
+		t.Fatalf("Expected NewTenants() to succeed, but got = %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	app := &bootstrap.Application{
+		Env:     &env,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Ready:   &atomic.Bool{},
+		Tenants: tenants,
+	}
+	Setup(app, router, testController(app))
+
+	tests := []struct {
+		name   string
+		path   string
+		apiKey string
+		origin string
+		body   string
+		code   int
+		want   string
+	}{
+		{
+			name: "Error: Missing API Key",
+			path: "/v1/parse",
+			body: `{"qr_string": "` + qrString + `"}`,
+			code: http.StatusUnauthorized,
+			want: `"code":"unauthorized"`,
+		},
+		{
+			name:   "Error: Endpoint Not Allowed",
+			path:   "/sticker",
+			apiKey: "warung-0123456789",
+			body:   `{"qr_string": "` + qrString + `"}`,
+			code:   http.StatusForbidden,
+			want:   `"code":"endpoint_not_allowed"`,
+		},
+		{
+			name:   "Error: Convert Foreign Merchant",
+			path:   "/v1/convert",
+			apiKey: "warung-0123456789",
+			body:   `{"qr_string": "` + qrString + `", "payment_amount": 1337}`,
+			code:   http.StatusForbidden,
+			want:   `"code":"merchant_not_allowed"`,
+		},
+		{
+			name:   "Error: V2 Convert Foreign Merchant",
+			path:   "/v2/qris:convert",
+			apiKey: "warung-0123456789",
+			body:   `{"qr_string": "` + qrString + `", "payment_amount": "1337"}`,
+			code:   http.StatusForbidden,
+			want:   `"code":"merchant_not_allowed"`,
+		},
+		{
+			name:   "Success: Parse Foreign Merchant",
+			path:   "/v2/qris:parse",
+			apiKey: "warung-0123456789",
+			body:   `{"qr_string": "` + qrString + `"}`,
+			code:   http.StatusOK,
+			want:   `"success":true`,
+		},
+		{
+			name:   "Success: Convert Own Merchant",
+			path:   "/v1/convert",
+			apiKey: "sintas-0123456789",
+			body:   `{"qr_string": "` + qrString + `", "payment_amount": 1337}`,
+			code:   http.StatusOK,
+			want:   `"success":true`,
+		},
+		{
+			name:   "Error: Publishable Key Endpoint Not Allowed",
+			path:   "/v1/parse",
+			apiKey: "kiosk-0123456789",
+			origin: "https://kiosk.example.com",
+			body:   `{"qr_string": "` + qrString + `"}`,
+			code:   http.StatusForbidden,
+			want:   `"code":"endpoint_not_allowed"`,
+		},
+		{
+			name:   "Error: Publishable Key From Foreign Origin",
+			path:   "/v1/convert",
+			apiKey: "kiosk-0123456789",
+			origin: "https://evil.example.com",
+			body:   `{"qr_string": "` + qrString + `", "payment_amount": 1337}`,
+			code:   http.StatusForbidden,
+			want:   `"code":"origin_not_allowed"`,
+		},
+		{
+			name:   "Success: Publishable Key Convert",
+			path:   "/v1/convert",
+			apiKey: "kiosk-0123456789",
+			origin: "https://kiosk.example.com",
+			body:   `{"qr_string": "` + qrString + `", "payment_amount": 1337}`,
+			code:   http.StatusOK,
+			want:   `"success":true`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, test.path, bytes.NewBufferString(test.body))
+			request.Header.Set("Content-Type", "application/json")
+			if test.apiKey != "" {
+				request.Header.Set("X-API-Key", test.apiKey)
+			}
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.code {
+				t.Errorf("Expected status code %d, got %d", test.code, recorder.Code)
+			}
+			if !bytes.Contains(recorder.Body.Bytes(), []byte(test.want)) {
+				t.Errorf("Expected response to contain %s, but got %s", test.want, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	openAPITitle   = "Go-QRIS"
+	openAPIVersion = "1.0.0"
+)
+
+// OpenAPIOperations lists every QRIS endpoint with the structs its handler
+// binds and responds with, docs_test.go keeps it in line with the router.
+var OpenAPIOperations = append(append(
+	versionedOpenAPIOperations("/v1", "", false, qrisOpenAPIOperations),
+	versionedOpenAPIOperations("", "Legacy", true, qrisOpenAPIOperations)...),
+	qrisV2OpenAPIOperations...,
+)
+
+var qrisOpenAPIOperations = []handlers.OpenAPIOperation{
+	{
+		ID:      "parse",
+		Method:  http.MethodPost,
+		Path:    "/parse",
+		Summary: "Parse QRIS",
+		Request: handlers.ParseRequest{},
+		Data:    entities.QRIS{},
+		Example: handlers.ParseRequest{
+			QRString: exampleQRString,
+		},
+	},
+	{
+		ID:        "parseImage",
+		Method:    http.MethodPost,
+		Path:      "/parse-image",
+		Summary:   "Parse QRIS from an image",
+		FormFiles: []string{"image"},
+		Data:      entities.QRIS{},
+	},
+	{
+		ID:      "convert",
+		Method:  http.MethodPost,
+		Path:    "/convert",
+		Summary: "Convert QRIS into a dynamic version",
+		Request: handlers.ConvertRequest{},
+		Data:    handlers.ConvertResponse{},
+		Example: handlers.ConvertRequest{
+			QRString:           exampleQRString,
+			MerchantCity:       "Kota Yogyakarta",
+			MerchantPostalCode: "55000",
+			PaymentAmount:      1337,
+			PaymentFeeCategory: "FIXED",
+			PaymentFee:         666,
+			TerminalLabel:      "Made with love by Alvriyanto Azis",
+			QRCodeFormat:       "png",
+		},
+		Idempotent: true,
+	},
+	{
+		ID:      "isValid",
+		Method:  http.MethodPost,
+		Path:    "/is-valid",
+		Summary: "Validate QRIS",
+		Request: handlers.IsValidRequest{},
+		Example: handlers.IsValidRequest{
+			QRString: exampleQRString,
+		},
+	},
+	{
+		ID:      "sticker",
+		Method:  http.MethodPost,
+		Path:    "/sticker",
+		Summary: "Generate a printable QRIS sticker",
+		Request: handlers.StickerRequest{},
+		Data:    handlers.StickerResponse{},
+		Example: handlers.StickerRequest{
+			QRString: exampleQRString,
+			Format:   "pdf",
+			DPI:      300,
+		},
+	},
+}
+
+var qrisV2OpenAPIOperations = []handlers.OpenAPIOperation{
+	{
+		ID:      "parseV2",
+		Method:  http.MethodPost,
+		Path:    "/v2/qris:parse",
+		Summary: "Parse QRIS and check its CRC",
+		Request: handlers.ParseV2Request{},
+		Data:    handlers.ParseV2Response{},
+		Example: handlers.ParseV2Request{
+			QRString: exampleQRString,
+		},
+	},
+	{
+		ID:      "convertV2",
+		Method:  http.MethodPost,
+		Path:    "/v2/qris:convert",
+		Summary: "Convert QRIS into a dynamic version with a decimal amount and tag 62 changes",
+		Request: handlers.ConvertV2Request{},
+		Data:    handlers.ConvertV2Response{},
+		Example: handlers.ConvertV2Request{
+			QRString:      exampleQRString,
+			PaymentAmount: "1337.50",
+			PaymentFee: &entities.PaymentFeePatch{
+				Category: "FIXED",
+				Value:    "666",
+			},
+			MerchantCity: exampleText("Kota Yogyakarta"),
+			AdditionalInformation: &entities.AdditionalInformationPatch{
+				BillNumber:    exampleText("INV-1337"),
+				TerminalLabel: exampleText(""),
+			},
+		},
+		Idempotent: true,
+	},
+	{
+		ID:      "generateV2",
+		Method:  http.MethodPost,
+		Path:    "/v2/qris:generate",
+		Summary: "Render QRIS as a QR code",
+		Request: handlers.GenerateV2Request{},
+		Data:    handlers.GenerateV2Response{},
+		Example: handlers.GenerateV2Request{
+			QRString: exampleQRString,
+			QRCode: utils.QRCodeOptions{
+				Format: utils.QRCodeFormatSVG,
+			},
+		},
+		Idempotent: true,
+	},
+}
+
+// versionedOpenAPIOperations mounts operations under prefix, the legacy root
+// aliases get their own operation IDs and are marked deprecated.
+func versionedOpenAPIOperations(prefix string, idSuffix string, deprecated bool, operations []handlers.OpenAPIOperation) []handlers.OpenAPIOperation {
+	versioned := make([]handlers.OpenAPIOperation, len(operations))
+	for i, operation := range operations {
+		operation.ID += idSuffix
+		operation.Path = prefix + operation.Path
+		operation.Deprecated = deprecated
+		versioned[i] = operation
+	}
+
+	return versioned
+}
+
+func exampleText(value string) *string {
+	return &value
+}
+
+const exampleQRString = "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+
+func NewDocsRouter(group *gin.RouterGroup) {
+	docsHandler := handlers.NewDocs(handlers.NewOpenAPIDocument(openAPITitle, openAPIVersion, OpenAPIOperations))
+
+	group.GET("/openapi.json", docsHandler.OpenAPI)
+	group.GET("/docs", docsHandler.Viewer)
+}
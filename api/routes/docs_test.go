@@ -0,0 +1,249 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/bootstrap"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var expectedButGotMessage = "Expected %v = %v, but got = %v"
+
+var testApp = &bootstrap.Application{
+	Env:    testEnv,
+	Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	Ready:  &atomic.Bool{},
+}
+
+// testController wires the controller for app, like cmd/run.go does.
+func testController(app *bootstrap.Application) controllers.QRISInterface {
+	return NewQRISController(app.Env, app.Profile, app.Metrics, app.Logger)
+}
+
+var testEnv = &bootstrap.Env{
+	Port:                  "1337",
+	QRCodeFormat:          utils.QRCodeFormatPNG,
+	QRCodeSize:            256,
+	QRCodeMargin:          4,
+	QRCodeErrorCorrection: "L",
+	QRCodeForegroundColor: "#000000",
+	QRCodeBackgroundColor: "#FFFFFF",
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(testApp, router, testController(testApp))
+	spec := serveOpenAPI(t, router)
+
+	undocumented := map[string]bool{
+		"GET /":             true,
+		"GET /openapi.json": true,
+		"GET /docs":         true,
+		"GET /healthz":      true,
+		"GET /readyz":       true,
+		"GET /version":      true,
+		"GET /metrics":      true,
+		"GET /widget.js":    true,
+	}
+	var routed, documented []string
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		if strings.HasSuffix(route.Path, "/:method") {
+			for method := range qrisV2Methods(handlers.NewQRISV2(nil), handlers.NewAPIKey(nil), handlers.NewIdempotency(nil, 0)) {
+				routed = append(routed, strings.Replace(key, ":method", method, 1))
+			}
+		} else if !undocumented[key] {
+			routed = append(routed, key)
+		}
+	}
+	for path, methods := range spec["paths"].(map[string]any) {
+		for method := range methods.(map[string]any) {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routed)
+	sort.Strings(documented)
+	if !reflect.DeepEqual(routed, documented) {
+		t.Errorf("Expected documented routes = %v, but got = %v", routed, documented)
+	}
+}
+
+func TestOpenAPIMatchesHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(testApp, router, testController(testApp))
+	spec := serveOpenAPI(t, router)
+
+	qrCode, err := utils.NewQRCode().StringToPNG(exampleQRString, testEnv.QRCodeOptions())
+	if err != nil {
+		t.Fatalf("Expected StringToPNG() error = %v, but got = %v", nil, err)
+	}
+
+	for _, operation := range OpenAPIOperations {
+		t.Run(operation.ID, func(t *testing.T) {
+			var body bytes.Buffer
+			contentType := "application/json"
+			if operation.Request != nil {
+				if reflect.TypeOf(operation.Example) != reflect.TypeOf(operation.Request) {
+					t.Fatalf("Expected example type = %T, but got = %T", operation.Request, operation.Example)
+				}
+				json.NewEncoder(&body).Encode(operation.Example)
+			} else {
+				writer := multipart.NewWriter(&body)
+				for _, name := range operation.FormFiles {
+					part, _ := writer.CreateFormFile(name, name+".png")
+					part.Write(qrCode)
+				}
+				writer.Close()
+				contentType = writer.FormDataContentType()
+			}
+
+			req := httptest.NewRequest(operation.Method, operation.Path, &body)
+			req.Header.Set("Content-Type", contentType)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+			}
+
+			var response any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Expected JSON response, but got = %v", err)
+			}
+			item := spec["paths"].(map[string]any)[operation.Path].(map[string]any)[strings.ToLower(operation.Method)].(map[string]any)
+			schema := item["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+			if err := validateOpenAPISchema(spec, schema.(map[string]any), response, "response"); err != nil {
+				t.Errorf("Expected response to match the specification, but got = %v", err)
+			}
+		})
+	}
+}
+
+func serveOpenAPI(t *testing.T, router *gin.Engine) map[string]any {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var spec map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Expected JSON specification, but got = %v", err)
+	}
+
+	return spec
+}
+
+// resolveOpenAPISchema follows references and merges allOf into one schema.
+func resolveOpenAPISchema(spec map[string]any, schema map[string]any) map[string]any {
+	if reference, ok := schema["$ref"].(string); ok {
+		name := reference[strings.LastIndex(reference, "/")+1:]
+		return resolveOpenAPISchema(spec, spec["components"].(map[string]any)["schemas"].(map[string]any)[name].(map[string]any))
+	}
+	parts, ok := schema["allOf"].([]any)
+	if !ok {
+		return schema
+	}
+
+	merged := map[string]any{"properties": map[string]any{}}
+	var required []any
+	for _, part := range parts {
+		resolved := resolveOpenAPISchema(spec, part.(map[string]any))
+		for key, value := range resolved {
+			if key != "properties" && key != "required" {
+				merged[key] = value
+			}
+		}
+		if properties, ok := resolved["properties"].(map[string]any); ok {
+			for name, property := range properties {
+				merged["properties"].(map[string]any)[name] = property
+			}
+		}
+		if names, ok := resolved["required"].([]any); ok {
+			required = append(required, names...)
+		}
+	}
+	if nullable, ok := schema["nullable"]; ok {
+		merged["nullable"] = nullable
+	}
+	merged["required"] = required
+
+	return merged
+}
+
+// validateOpenAPISchema fails on missing required fields, undocumented fields
+// and values of the wrong type.
+func validateOpenAPISchema(spec map[string]any, schema map[string]any, value any, path string) error {
+	schema = resolveOpenAPISchema(spec, schema)
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schema["type"] == nil {
+			return nil
+		}
+		return fmt.Errorf("%s must not be null", path)
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must be an object", path)
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, exists := object[name.(string)]; !exists {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+		for name, field := range object {
+			property, exists := properties[name]
+			if !exists {
+				return fmt.Errorf("%s.%s is not documented", path, name)
+			}
+			if err := validateOpenAPISchema(spec, property.(map[string]any), field, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s must be an array", path)
+		}
+		for i, item := range items {
+			if err := validateOpenAPISchema(spec, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s must be a string", path)
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s must be a number", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", path)
+		}
+	}
+
+	return nil
+}
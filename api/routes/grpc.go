@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"crypto/tls"
+
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/api/rpc"
+	"github.com/fyvri/go-qris/bootstrap"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// NewGRPCServer serves the QRIS service with the controller, tenants and rate
+// limiter of the REST API. tlsConfig may be nil for plaintext.
+func NewGRPCServer(app *bootstrap.Application, qrisController controllers.QRISInterface, tlsConfig *tls.Config) *grpc.Server {
+	env := app.Env
+	interceptor := rpc.NewInterceptor(app.Logger, tenantStore(app), app.RateLimiter)
+
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptor.Unary),
+	}
+	if env.MaxBodyBytes > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(int(env.MaxBodyBytes)))
+	}
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(options...)
+	qrisv1.RegisterQRISServiceServer(server, rpc.NewQRIS(qrisController))
+
+	return server
+}
@@ -0,0 +1,271 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/bootstrap"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGRPCClientFor serves app with its own controller.
+func newGRPCClientFor(t *testing.T, app *bootstrap.Application) qrisv1.QRISServiceClient {
+	t.Helper()
+	return newGRPCClient(t, app, testController(app))
+}
+
+// newGRPCClient serves app with qrisController over an in-memory listener and
+// connects to it.
+func newGRPCClient(t *testing.T, app *bootstrap.Application, qrisController controllers.QRISInterface) qrisv1.QRISServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(app, qrisController, nil)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Expected grpc.NewClient() to succeed, but got = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return qrisv1.NewQRISServiceClient(conn)
+}
+
+func TestGRPCServer(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+	metrics := utils.NewMetrics()
+	client := newGRPCClientFor(t, &bootstrap.Application{
+		Env:     testEnv,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Ready:   &atomic.Bool{},
+		Metrics: metrics,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("Success: Parse", func(t *testing.T) {
+		var header metadata.MD
+		response, err := client.Parse(metadata.AppendToOutgoingContext(ctx, "x-request-id", "grpc-test"), &qrisv1.ParseRequest{QrString: qrString}, grpc.Header(&header))
+		if err != nil {
+			t.Fatalf(expectedButGotMessage, "Parse() error", nil, err)
+		}
+		if got := response.GetQris().GetSwitching().GetDetail().GetNmid().GetContent(); got != "ID2020034073193" {
+			t.Errorf(expectedButGotMessage, "NMID", "ID2020034073193", got)
+		}
+		if !response.GetCrcValid() {
+			t.Errorf(expectedButGotMessage, "CRC valid", true, false)
+		}
+		if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "grpc-test" {
+			t.Errorf(expectedButGotMessage, "x-request-id", "grpc-test", got)
+		}
+	})
+
+	t.Run("Error: Parse Malformed", func(t *testing.T) {
+		_, err := client.Parse(ctx, &qrisv1.ParseRequest{QrString: "000201"})
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf(expectedButGotMessage, "status code", codes.InvalidArgument, st.Code())
+		}
+		var reason string
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				reason = info.GetReason()
+			}
+		}
+		if reason != "invalid_qris" {
+			t.Errorf(expectedButGotMessage, "reason", "invalid_qris", reason)
+		}
+	})
+
+	t.Run("Success: Validate Invalid CRC", func(t *testing.T) {
+		response, err := client.Validate(ctx, &qrisv1.ValidateRequest{QrString: qrString[:len(qrString)-4] + "0000"})
+		if err != nil {
+			t.Fatalf(expectedButGotMessage, "Validate() error", nil, err)
+		}
+		if response.GetValid() || response.GetCode() != "invalid_crc" {
+			t.Errorf(expectedButGotMessage, "Validate()", "invalid_crc", response)
+		}
+	})
+
+	t.Run("Success: Convert", func(t *testing.T) {
+		response, err := client.Convert(ctx, &qrisv1.ConvertRequest{
+			QrString:      qrString,
+			PaymentAmount: 1337,
+			QrCode:        &qrisv1.QRCodeOptions{Format: utils.QRCodeFormatSVG},
+		})
+		if err != nil {
+			t.Fatalf(expectedButGotMessage, "Convert() error", nil, err)
+		}
+		if !bytes.Contains([]byte(response.GetQrString()), []byte("54041337")) {
+			t.Errorf(expectedButGotMessage, "QR string amount", "54041337", response.GetQrString())
+		}
+		if response.GetContentType() != "image/svg+xml" || !bytes.HasPrefix(response.GetQrCode(), []byte("<?xml")) {
+			t.Errorf(expectedButGotMessage, "QR code", "image/svg+xml", response.GetContentType())
+		}
+	})
+
+	t.Run("Success: Generate", func(t *testing.T) {
+		response, err := client.Generate(ctx, &qrisv1.GenerateRequest{QrString: qrString})
+		if err != nil {
+			t.Fatalf(expectedButGotMessage, "Generate() error", nil, err)
+		}
+		if response.GetContentType() != "image/png" || !bytes.HasPrefix(response.GetQrCode(), []byte("\x89PNG")) {
+			t.Errorf(expectedButGotMessage, "QR code", "image/png", response.GetContentType())
+		}
+	})
+
+	t.Run("Success: Render", func(t *testing.T) {
+		response, err := client.Render(ctx, &qrisv1.RenderRequest{QrString: qrString, Sticker: &qrisv1.StickerOptions{Format: "pdf"}})
+		if err != nil {
+			t.Fatalf(expectedButGotMessage, "Render() error", nil, err)
+		}
+		if response.GetContentType() != "application/pdf" || !bytes.HasPrefix(response.GetSticker(), []byte("%PDF")) {
+			t.Errorf(expectedButGotMessage, "sticker", "application/pdf", response.GetContentType())
+		}
+	})
+
+	var text bytes.Buffer
+	if err := metrics.WriteText(&text); err != nil {
+		t.Fatalf(expectedButGotMessage, "WriteText() error", nil, err)
+	}
+	if want := `operation="convert"`; !bytes.Contains(text.Bytes(), []byte(want)) {
+		t.Errorf(expectedButGotMessage, "shared metrics", want, text.String())
+	}
+}
+
+func TestGRPCServerTenants(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+
+	env := *testEnv
+	env.APIKeys = `{"tenants": [
+		{"name": "warung", "api_keys": ["warung-0123456789"], "allowed_nmids": ["ID1020017611473"], "allowed_endpoints": ["parse", "convert"]}
+	]}`
+	tenants, err := bootstrap.NewTenants(&env)
+	if err != nil {
+		t.Fatalf("Expected NewTenants() to succeed, but got = %v", err)
+	}
+	client := newGRPCClientFor(t, &bootstrap.Application{
+		Env:     &env,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Ready:   &atomic.Bool{},
+		Tenants: tenants,
+	})
+
+	tests := []struct {
+		name   string
+		apiKey string
+		call   func(ctx context.Context) error
+		code   codes.Code
+	}{
+		{
+			name: "Error: Missing API Key",
+			call: func(ctx context.Context) error {
+				_, err := client.Parse(ctx, &qrisv1.ParseRequest{QrString: qrString})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:   "Error: Endpoint Not Allowed",
+			apiKey: "warung-0123456789",
+			call: func(ctx context.Context) error {
+				_, err := client.Render(ctx, &qrisv1.RenderRequest{QrString: qrString})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:   "Error: Convert Foreign Merchant",
+			apiKey: "warung-0123456789",
+			call: func(ctx context.Context) error {
+				_, err := client.Convert(ctx, &qrisv1.ConvertRequest{QrString: qrString, PaymentAmount: 1337})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:   "Success: Parse Foreign Merchant",
+			apiKey: "warung-0123456789",
+			call: func(ctx context.Context) error {
+				_, err := client.Parse(ctx, &qrisv1.ParseRequest{QrString: qrString})
+				return err
+			},
+			code: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if test.apiKey != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+test.apiKey)
+			}
+
+			if got := status.Code(test.call(ctx)); got != test.code {
+				t.Errorf(expectedButGotMessage, "status code", test.code, got)
+			}
+		})
+	}
+}
+
+func TestGRPCServerSharesRESTController(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+	env := *testEnv
+	env.ParseCacheSize = 8
+	metrics := utils.NewMetrics()
+	app := &bootstrap.Application{
+		Env:     &env,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Ready:   &atomic.Bool{},
+		Metrics: metrics,
+	}
+	qrisController := testController(app)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(app, router, qrisController)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/parse", strings.NewReader(`{"qr_string": "`+qrString+`"}`)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf(expectedButGotMessage, "REST status code", http.StatusOK, recorder.Code)
+	}
+
+	client := newGRPCClient(t, app, qrisController)
+	if _, err := client.Parse(context.Background(), &qrisv1.ParseRequest{QrString: qrString}); err != nil {
+		t.Fatalf(expectedButGotMessage, "Parse() error", nil, err)
+	}
+
+	var text strings.Builder
+	metrics.WriteText(&text)
+	// A controller of its own would miss the cache again over gRPC.
+	if want := `goqris_cache_requests_total{cache="qris_template",result="miss"} 1`; !strings.Contains(text.String(), want) {
+		t.Errorf(expectedButGotMessage, "cache misses", want, text.String())
+	}
+}
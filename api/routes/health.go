@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"sync/atomic"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewHealthRouter(ready *atomic.Bool, group *gin.RouterGroup) {
+	healthHandler := handlers.NewHealth(ready.Load, bootstrap.NewBuildInfo())
+
+	group.GET("/healthz", healthHandler.Healthz)
+	group.GET("/readyz", healthHandler.Readyz)
+	group.GET("/version", healthHandler.Version)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewMetricsRouter has to run before any other router, gin only applies
+// middleware to routes registered after it.
+func NewMetricsRouter(metrics utils.MetricsInterface, ginEngine *gin.Engine) {
+	metricsHandler := handlers.NewMetrics(metrics)
+
+	ginEngine.Use(metricsHandler.Middleware)
+	ginEngine.GET("/metrics", metricsHandler.Serve)
+}
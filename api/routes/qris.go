@@ -1,9 +1,10 @@
 package routes
 
 import (
+	"log/slog"
+
 	"github.com/fyvri/go-qris/api/handlers"
 	"github.com/fyvri/go-qris/bootstrap"
-	"github.com/fyvri/go-qris/internal/config"
 	"github.com/fyvri/go-qris/internal/interface/controllers"
 	"github.com/fyvri/go-qris/internal/usecases"
 	"github.com/fyvri/go-qris/pkg/utils"
@@ -11,90 +12,29 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewQRISRouter(env *bootstrap.Env, group *gin.RouterGroup) {
-	qrisTags := &usecases.QRISTags{
-		Version:               config.VersionTag,
-		Category:              config.CategoryTag,
-		Acquirer:              config.AcquirerTag,
-		AcquirerBankTransfer:  config.AcquirerBankTransferTag,
-		Switching:             config.SwitchingTag,
-		MerchantCategoryCode:  config.MerchantCategoryCodeTag,
-		CurrencyCode:          config.CurrencyCodeTag,
-		PaymentAmount:         config.PaymentAmountTag,
-		PaymentFeeCategory:    config.PaymentFeeCategoryTag,
-		PaymentFeeFixed:       config.PaymentFeeFixedTag,
-		PaymentFeePercent:     config.PaymentFeePercentTag,
-		CountryCode:           config.CountryCodeTag,
-		MerchantName:          config.MerchantNameTag,
-		MerchantCity:          config.MerchantCityTag,
-		MerchantPostalCode:    config.MerchantPostalCodeTag,
-		AdditionalInformation: config.AdditionalInformationTag,
-		CRCCode:               config.CRCCodeTag,
-	}
-	qrisCategoryContents := &usecases.QRISCategoryContents{
-		Static:  config.CategoryStaticContent,
-		Dynamic: config.CategoryDynamicContent,
-	}
-	qrisPaymentFeeCategoryContents := &usecases.QRISPaymentFeeCategoryContents{
-		Fixed:   config.PaymentFeeCategoryFixedContent,
-		Percent: config.PaymentFeeCategoryPercentContent,
+// NewQRISController wires the controller for profile, the zero Profile being
+// the QRIS default.
+func NewQRISController(env *bootstrap.Env, profile usecases.Profile, metrics utils.MetricsInterface, logger *slog.Logger) controllers.QRISInterface {
+	if profile == (usecases.Profile{}) {
+		profile = usecases.DefaultProfile()
 	}
-	acquirerDetailTags := &usecases.AcquirerDetailTags{
-		Site:       config.AcquirerDetailSiteTag,
-		MPAN:       config.AcquirerDetailMPANTag,
-		TerminalID: config.AcquirerDetailTerminalIDTag,
-		Category:   config.AcquirerDetailCategoryTag,
+	qrisUsecase := usecases.NewQRISFromProfile(profile)
+	if env.ParseCacheSize > 0 {
+		qrisUsecase = usecases.NewQRISCache(qrisUsecase, &profile.CategoryContents, env.ParseCacheSize, env.ParseCacheTTL, metrics)
 	}
-	switchingDetailTags := &usecases.SwitchingDetailTags{
-		Site:     config.SwitchingDetailSiteTag,
-		NMID:     config.SwitchingDetailNMIDTag,
-		Category: config.SwitchingDetailCategoryTag,
-	}
-	qrisAdditionalInformationDetailTags := &usecases.AdditionalInformationDetailTags{
-		BillNumber:                    config.AdditionalInformationDetailBillNumberTag,
-		MobileNumber:                  config.AdditionalInformationDetailMobileNumberTag,
-		StoreLabel:                    config.AdditionalInformationDetailStoreLabelTag,
-		LoyaltyNumber:                 config.AdditionalInformationDetailLoyaltyNumberTag,
-		ReferenceLabel:                config.AdditionalInformationDetailReferenceLabelTag,
-		CustomerLabel:                 config.AdditionalInformationDetailCustomerLabelTag,
-		TerminalLabel:                 config.AdditionalInformationDetailTerminalLabelTag,
-		PurposeOfTransaction:          config.AdditionalInformationDetailPurposeOfTransactionTag,
-		AdditionalConsumerDataRequest: config.AdditionalInformationDetailAdditionalConsumerDataRequestTag,
-		MerchantTaxID:                 config.AdditionalInformationDetailMerchantTaxIDTag,
-		MerchantChannel:               config.AdditionalInformationDetailMerchantChannelTag,
-		RFUStart:                      config.AdditionalInformationDetailRFUTagStart,
-		RFUEnd:                        config.AdditionalInformationDetailRFUTagEnd,
-		PaymentSystemSpecificStart:    config.AdditionalInformationDetailPaymentSystemSpecificTagStart,
-		PaymentSystemSpecificEnd:      config.AdditionalInformationDetailPaymentSystemSpecificTagEnd,
-	}
-
-	dataUsecase := usecases.NewData()
-	acquirerUsecase := usecases.NewAcquirer(dataUsecase, acquirerDetailTags)
-	switchingUsecase := usecases.NewSwitching(dataUsecase, switchingDetailTags)
-	additionalInformationUsecase := usecases.NewAdditionalInformation(dataUsecase, qrisAdditionalInformationDetailTags)
-	paymentFeeUsecase := usecases.NewPaymentFee(qrisTags, qrisPaymentFeeCategoryContents)
-	fieldUsecase := usecases.NewField(acquirerUsecase, switchingUsecase, additionalInformationUsecase, qrisTags, qrisCategoryContents)
-	crc16CCITTUsecase := usecases.NewCRC16CCITT()
-
-	qrisUsecases := &usecases.QRISUsecases{
-		Data:                  dataUsecase,
-		Field:                 fieldUsecase,
-		PaymentFee:            paymentFeeUsecase,
-		AdditionalInformation: additionalInformationUsecase,
-		CRC16CCITT:            crc16CCITTUsecase,
-	}
-	qrisUsecase := usecases.NewQRIS(
-		qrisUsecases,
-		qrisTags,
-		qrisCategoryContents,
-		qrisPaymentFeeCategoryContents,
-	)
 	qrCodeUtil := utils.NewQRCode()
 	inputUtil := utils.NewInput()
-	qrisController := controllers.NewQRIS(inputUtil, qrCodeUtil, qrisUsecase, env.QRCodeSize)
+	stickerUtil := utils.NewSticker()
+
+	return controllers.NewQRIS(inputUtil, qrCodeUtil, stickerUtil, qrisUsecase, env.QRCodeOptions(), env.MaxQRStringLength, metrics, logger)
+}
+
+func NewQRISRouter(qrisController controllers.QRISInterface, apiKey handlers.APIKeyInterface, idempotency handlers.IdempotencyInterface, group *gin.RouterGroup) {
 	qrisHandler := handlers.NewQRIS(qrisController)
 
-	group.POST("/parse", qrisHandler.Parse)
-	group.POST("/convert", qrisHandler.Convert)
-	group.POST("/is-valid", qrisHandler.IsValid)
+	group.POST("/parse", apiKey.Authorize("parse"), qrisHandler.Parse)
+	group.POST("/parse-image", apiKey.Authorize("parse-image"), qrisHandler.ParseImage)
+	group.POST("/convert", apiKey.Authorize("convert"), idempotency.Wrap(qrisHandler.Convert))
+	group.POST("/is-valid", apiKey.Authorize("is-valid"), qrisHandler.IsValid)
+	group.POST("/sticker", apiKey.Authorize("sticker"), qrisHandler.Sticker)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewQRISV2Router(qrisController controllers.QRISInterface, apiKey handlers.APIKeyInterface, idempotency handlers.IdempotencyInterface, group *gin.RouterGroup) {
+	group.POST("/:method", handlers.Dispatch(qrisV2Methods(handlers.NewQRISV2(qrisController), apiKey, idempotency)))
+}
+
+func qrisV2Methods(qrisHandler handlers.QRISV2Interface, apiKey handlers.APIKeyInterface, idempotency handlers.IdempotencyInterface) map[string]gin.HandlerFunc {
+	return map[string]gin.HandlerFunc{
+		"qris:parse":    handlers.Chain(apiKey.Authorize("parse"), qrisHandler.Parse),
+		"qris:convert":  handlers.Chain(apiKey.Authorize("convert"), idempotency.Wrap(qrisHandler.Convert)),
+		"qris:generate": handlers.Chain(apiKey.Authorize("generate"), idempotency.Wrap(qrisHandler.Generate)),
+	}
+}
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestQRISV2RouterUnknownMethod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(testApp, router, testController(testApp))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v2/qris:sticker", bytes.NewBufferString(`{}`)))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	if want := `"code":"not_found"`; !bytes.Contains(recorder.Body.Bytes(), []byte(want)) {
+		t.Errorf("Expected response to contain %s, but got %s", want, recorder.Body.String())
+	}
+}
+
+func TestQRISV2RouterIdempotency(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+	env := *testEnv
+	env.IdempotencyTTL = time.Hour
+	app := *testApp
+	app.Env = &env
+	app.IdempotencyStore = utils.NewIdempotencyStore(0, 0)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(&app, router, testController(&app))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/v2/qris:convert", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(handlers.IdempotencyKeyHeader, "order-1337")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	body := `{"qr_string": "` + qrString + `", "payment_amount": "1337"}`
+	first, second := send(body), send(body)
+	if first.Code != http.StatusOK || second.Code != http.StatusOK {
+		t.Fatalf(expectedButGotMessage, "status codes", http.StatusOK, []int{first.Code, second.Code})
+	}
+	if !bytes.Equal(first.Body.Bytes(), second.Body.Bytes()) {
+		t.Errorf(expectedButGotMessage, "replayed body", first.Body.String(), second.Body.String())
+	}
+	if got := second.Header().Get(handlers.IdempotentReplayedHeader); got != "true" {
+		t.Errorf(expectedButGotMessage, handlers.IdempotentReplayedHeader, "true", got)
+	}
+
+	reused := send(`{"qr_string": "` + qrString + `", "payment_amount": "666"}`)
+	if reused.Code != http.StatusUnprocessableEntity {
+		t.Errorf(expectedButGotMessage, "reused key status code", http.StatusUnprocessableEntity, reused.Code)
+	}
+}
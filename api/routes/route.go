@@ -5,13 +5,25 @@ import (
 
 	"github.com/fyvri/go-qris/api/handlers"
 	"github.com/fyvri/go-qris/bootstrap"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(env *bootstrap.Env, ginEngine *gin.Engine) {
+// Setup serves the REST API with qrisController, the one NewGRPCServer is
+// given too, so both share its parse cache.
+func Setup(app *bootstrap.Application, ginEngine *gin.Engine, qrisController controllers.QRISInterface) {
+	env := app.Env
+	metrics := app.Metrics
+	if metrics == nil {
+		metrics = utils.NewMetrics()
+	}
+	ginEngine.Use(handlers.NewRequestID(app.Logger).Middleware)
+	ginEngine.Use(handlers.NewCORS(env.CORSAllowedOrigins).Middleware)
+	NewMetricsRouter(metrics, ginEngine)
 	publicRouter := ginEngine.Group("")
 
-	ginEngine.GET("/", func(c *gin.Context) {
+	overview := func(c *gin.Context) {
 		c.JSON(http.StatusOK, handlers.Response{
 			Success: true,
 			Message: "Made with love by Alvriyanto Azis",
@@ -22,7 +34,7 @@ func Setup(env *bootstrap.Env, ginEngine *gin.Engine) {
 					"https://github.com/fyvri/go-qris",
 					"https://documenter.getpostman.com/view/6937269/2sAYJ1jMc7",
 				},
-				"4_API_Endpoints": [3]any{
+				"4_API_Endpoints": [5]any{
 					map[string]any{
 						"1_Name":   "Parse QRIS",
 						"2_Method": "POST",
@@ -31,6 +43,14 @@ func Setup(env *bootstrap.Env, ginEngine *gin.Engine) {
 							"qr_string": "000201010211y0ur4w3soMEQr15STriN6",
 						},
 					},
+					map[string]any{
+						"1_Name":   "Parse QRIS From an Image",
+						"2_Method": "POST",
+						"3_Target": "/parse-image",
+						"4_Body": map[string]any{
+							"image": "multipart/form-data file, PNG, JPEG or GIF",
+						},
+					},
 					map[string]any{
 						"1_Name":   "Convert QRIS into a Dynamic Version",
 						"2_Method": "POST",
@@ -43,6 +63,7 @@ func Setup(env *bootstrap.Env, ginEngine *gin.Engine) {
 							"payment_fee_category": "FIXED",
 							"payment_fee":          666,
 							"terminal_label":       "Made with love by Alvriyanto Azis",
+							"qr_code_format":       "png",
 						},
 					},
 					map[string]any{
@@ -53,10 +74,47 @@ func Setup(env *bootstrap.Env, ginEngine *gin.Engine) {
 							"qr_string": "000201010211y0ur4w3soMEQr15STriN6",
 						},
 					},
+					map[string]any{
+						"1_Name":   "Generate a Printable QRIS Sticker",
+						"2_Method": "POST",
+						"3_Target": "/sticker",
+						"4_Body": map[string]any{
+							"qr_string": "000201010211y0ur4w3soMEQr15STriN6",
+							"format":    "pdf",
+							"dpi":       300,
+						},
+					},
 				},
 			},
 		})
-	})
+	}
+	root := overview
+	if env.PlaygroundEnabled {
+		root = handlers.NewPlayground(overview).Page
+	}
+	ginEngine.GET("/", root)
+
+	// The root routes predate versioning and stay as aliases of /v1.
+	apiKey := handlers.NewAPIKey(tenantStore(app))
+	limit := handlers.NewLimit(app.RateLimiter, env.MaxBodyBytes)
+	idempotency := handlers.NewIdempotency(app.IdempotencyStore, env.IdempotencyTTL)
+
+	// Authentication comes first so requests are throttled per tenant, and
+	// per client IP only without a known API key.
+	NewQRISRouter(qrisController, apiKey, idempotency, ginEngine.Group("", apiKey.Authenticate, limit.RateLimit, limit.BodyLimit))
+	NewQRISRouter(qrisController, apiKey, idempotency, ginEngine.Group("/v1", apiKey.Authenticate, limit.RateLimit, limit.BodyLimit))
+	NewQRISV2Router(qrisController, apiKey, idempotency, ginEngine.Group("/v2", apiKey.Authenticate, limit.RateLimit, limit.BodyLimit))
+	NewDocsRouter(publicRouter)
+	NewHealthRouter(app.Ready, publicRouter)
+	NewWidgetRouter(publicRouter)
+}
+
+// tenantStore keeps a nil *bootstrap.Tenants from becoming a non-nil
+// interface, which would turn authentication on without any tenant.
+func tenantStore(app *bootstrap.Application) handlers.TenantStoreInterface {
+	if app.Tenants == nil {
+		return nil
+	}
 
-	NewQRISRouter(env, publicRouter)
+	return app.Tenants
 }
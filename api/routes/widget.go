@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/fyvri/go-qris/api/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewWidgetRouter(group *gin.RouterGroup) {
+	widgetHandler := handlers.NewWidget()
+
+	group.GET("/widget.js", widgetHandler.Script)
+}
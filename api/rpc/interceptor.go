@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiKeyMetadata     = "x-api-key"
+	requestIDMetadata  = "x-request-id"
+	retryAfterMetadata = "retry-after"
+)
+
+// methodEndpoints names each method after the REST endpoint whose tenant
+// permission it needs.
+var methodEndpoints = map[string]string{
+	qrisv1.QRISService_Parse_FullMethodName:    "parse",
+	qrisv1.QRISService_Validate_FullMethodName: "is-valid",
+	qrisv1.QRISService_Convert_FullMethodName:  "convert",
+	qrisv1.QRISService_Generate_FullMethodName: "generate",
+	qrisv1.QRISService_Render_FullMethodName:   "sticker",
+}
+
+type loggerContextKey struct{}
+
+type Interceptor struct {
+	logger      *slog.Logger
+	tenants     handlers.TenantStoreInterface
+	rateLimiter utils.RateLimiterInterface
+}
+
+type InterceptorInterface interface {
+	Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error)
+}
+
+// NewInterceptor applies the REST middleware to gRPC calls: request IDs and
+// access logs, rate limits and API keys. A nil tenant store leaves the service
+// open and a nil limiter turns throttling off.
+func NewInterceptor(logger *slog.Logger, tenants handlers.TenantStoreInterface, rateLimiter utils.RateLimiterInterface) InterceptorInterface {
+	return &Interceptor{
+		logger:      logger,
+		tenants:     tenants,
+		rateLimiter: rateLimiter,
+	}
+}
+
+func (i *Interceptor) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := handlers.SafeRequestID(firstMetadata(md, requestIDMetadata))
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID))
+
+	logger := i.logger.With("request_id", requestID)
+	clientIP, clientCert := peerInfo(ctx)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Error("Request panicked", "panic", recovered)
+			err = status.Error(codes.Internal, "internal error")
+		}
+
+		level := slog.LevelInfo
+		code := status.Code(err)
+		if code == codes.Internal || code == codes.Unknown {
+			level = slog.LevelError
+		}
+		attrs := []any{
+			"method", "GRPC",
+			"route", info.FullMethod,
+			"status", code.String(),
+			"duration", time.Since(start),
+			"client_ip", clientIP,
+		}
+		if clientCert != "" {
+			attrs = append(attrs, "client_cert", clientCert)
+		}
+		logger.Log(ctx, level, "request completed", attrs...)
+	}()
+
+	// The tenant is resolved before throttling so that unknown API keys share
+	// their client IP's bucket instead of getting a fresh one each.
+	var tenant *entities.Tenant
+	if apiKey := requestAPIKey(md); i.tenants != nil && apiKey != "" {
+		if found, exists := i.tenants.Tenant(apiKey); exists {
+			tenant = found
+			logger = logger.With("tenant", tenant.Name)
+		}
+	}
+
+	if i.rateLimiter != nil {
+		allowed, retryAfter := i.rateLimiter.Allow(handlers.ClientKey(tenant, clientIP))
+		if !allowed {
+			grpc.SetTrailer(ctx, metadata.Pairs(retryAfterMetadata, strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+			return nil, status.Error(codes.ResourceExhausted, "too many requests, retry later")
+		}
+	}
+
+	if i.tenants != nil {
+		if tenant == nil {
+			return nil, status.Error(codes.Unauthenticated, "missing or unknown API key")
+		}
+
+		endpoint := methodEndpoints[info.FullMethod]
+		if !tenant.AllowsEndpoint(endpoint) {
+			logger.Warn("Endpoint not allowed", "endpoint", endpoint)
+			return nil, status.Errorf(codes.PermissionDenied, "endpoint %s is not allowed for this API key", endpoint)
+		}
+		// Publishable keys are for browsers, which only reach the REST API.
+		if tenant.Publishable {
+			logger.Warn("Publishable API key used over gRPC")
+			return nil, status.Error(codes.PermissionDenied, "publishable API keys are only accepted over REST")
+		}
+		ctx = controllers.ContextWithTenant(ctx, tenant)
+	}
+
+	return handler(context.WithValue(ctx, loggerContextKey{}, logger), req)
+}
+
+// requestController scopes the controller to the logger and tenant of the
+// current call. Its methods take the call's ctx, so they stop at its deadline.
+func requestController(ctx context.Context, qrisController controllers.QRISInterface) controllers.QRISInterface {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		qrisController = qrisController.WithLogger(logger)
+	}
+	if tenant, ok := controllers.TenantFromContext(ctx); ok {
+		qrisController = qrisController.WithTenant(tenant)
+	}
+
+	return qrisController
+}
+
+// requestAPIKey reads the x-api-key metadata or a bearer token.
+func requestAPIKey(md metadata.MD) string {
+	if apiKey := firstMetadata(md, apiKeyMetadata); apiKey != "" {
+		return apiKey
+	}
+	if apiKey, found := strings.CutPrefix(firstMetadata(md, "authorization"), "Bearer "); found {
+		return apiKey
+	}
+
+	return ""
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func peerInfo(ctx context.Context) (string, string) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	clientIP := p.Addr.String()
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+		return clientIP, tlsInfo.State.PeerCertificates[0].Subject.CommonName
+	}
+
+	return clientIP, ""
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptorUnary(t *testing.T) {
+	tenant := &entities.Tenant{Name: "warung", AllowedEndpoints: []string{"parse"}}
+	var tenants handlers.TenantStoreInterface = &mockTenantStore{
+		TenantFunc: func(apiKey string) (*entities.Tenant, bool) {
+			if apiKey == "publishable-0123456789" {
+				return &entities.Tenant{Name: "warung", AllowedEndpoints: []string{"parse"}, Publishable: true}, true
+			}
+			return tenant, apiKey == "warung-0123456789"
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tenants        handlers.TenantStoreInterface
+		allow          bool
+		method         string
+		metadata       []string
+		handlerPanics  bool
+		wantCode       codes.Code
+		wantTenant     bool
+		wantLimitKey   string
+		wantRequestID  string
+		wantRetryAfter string
+	}{
+		{
+			name:          "Success: Open",
+			allow:         true,
+			method:        qrisv1.QRISService_Render_FullMethodName,
+			metadata:      []string{"x-request-id", "grpc-test"},
+			wantRequestID: "grpc-test",
+		},
+		{
+			name:         "Success: API Key",
+			tenants:      tenants,
+			allow:        true,
+			method:       qrisv1.QRISService_Parse_FullMethodName,
+			metadata:     []string{"x-api-key", "warung-0123456789"},
+			wantTenant:   true,
+			wantLimitKey: "tenant:warung",
+		},
+		{
+			name:         "Error: Unknown API Key",
+			tenants:      tenants,
+			allow:        true,
+			method:       qrisv1.QRISService_Parse_FullMethodName,
+			metadata:     []string{"authorization", "Bearer unknown-0123456789"},
+			wantCode:     codes.Unauthenticated,
+			wantLimitKey: "ip:",
+		},
+		{
+			name:     "Error: Endpoint Not Allowed",
+			tenants:  tenants,
+			allow:    true,
+			method:   qrisv1.QRISService_Convert_FullMethodName,
+			metadata: []string{"authorization", "Bearer warung-0123456789"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Error: Publishable Key",
+			tenants:      tenants,
+			allow:        true,
+			method:       qrisv1.QRISService_Parse_FullMethodName,
+			metadata:     []string{"x-api-key", "publishable-0123456789"},
+			wantCode:     codes.PermissionDenied,
+			wantLimitKey: "publishable:warung:",
+		},
+		{
+			name:           "Error: Rate Limited",
+			allow:          false,
+			method:         qrisv1.QRISService_Parse_FullMethodName,
+			wantCode:       codes.ResourceExhausted,
+			wantRetryAfter: "2",
+		},
+		{
+			name:          "Error: Panic",
+			allow:         true,
+			method:        qrisv1.QRISService_Parse_FullMethodName,
+			handlerPanics: true,
+			wantCode:      codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotLimitKey string
+			rateLimiter := &mockRateLimiter{
+				AllowFunc: func(key string) (bool, time.Duration) {
+					gotLimitKey = key
+					return test.allow, 1200 * time.Millisecond
+				},
+			}
+			interceptor := NewInterceptor(slog.New(slog.NewTextHandler(io.Discard, nil)), test.tenants, rateLimiter)
+
+			stream := &mockServerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(test.metadata...))
+			var gotTenant bool
+			_, err := interceptor.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: test.method}, func(ctx context.Context, req any) (any, error) {
+				if test.handlerPanics {
+					panic("boom")
+				}
+				_, gotTenant = controllers.TenantFromContext(ctx)
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != test.wantCode {
+				t.Errorf(expectedButGotMessage, "status code", test.wantCode, code)
+			}
+			if test.wantLimitKey != "" && gotLimitKey != test.wantLimitKey {
+				t.Errorf(expectedButGotMessage, "rate limit key", test.wantLimitKey, gotLimitKey)
+			}
+			if gotTenant != test.wantTenant {
+				t.Errorf(expectedButGotMessage, "tenant", test.wantTenant, gotTenant)
+			}
+			requestID := strings.Join(stream.header.Get(requestIDMetadata), ",")
+			if requestID == "" || (test.wantRequestID != "" && requestID != test.wantRequestID) {
+				t.Errorf(expectedButGotMessage, requestIDMetadata, test.wantRequestID, requestID)
+			}
+			if got := strings.Join(stream.trailer.Get(retryAfterMetadata), ","); got != test.wantRetryAfter {
+				t.Errorf(expectedButGotMessage, retryAfterMetadata, test.wantRetryAfter, got)
+			}
+		})
+	}
+}
+
+func TestRequestController(t *testing.T) {
+	tenant := &entities.Tenant{Name: "warung"}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantTenant *entities.Tenant
+	}{
+		{
+			name: "Success: Without Tenant",
+			ctx:  context.Background(),
+		},
+		{
+			name:       "Success: Tenant From Context",
+			ctx:        controllers.ContextWithTenant(context.Background(), tenant),
+			wantTenant: tenant,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotTenant *entities.Tenant
+			requestController(test.ctx, &mockQRISController{
+				WithTenantFunc: func(tenant *entities.Tenant) controllers.QRISInterface {
+					gotTenant = tenant
+					return nil
+				},
+			})
+
+			if gotTenant != test.wantTenant {
+				t.Errorf(expectedButGotMessage, "tenant", test.wantTenant, gotTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "go-qris"
+
+var errorStatusCodes = map[controllers.ErrorKind]codes.Code{
+	controllers.ErrorKindInternal:  codes.Internal,
+	controllers.ErrorKindMalformed: codes.InvalidArgument,
+	controllers.ErrorKindInvalid:   codes.InvalidArgument,
+	controllers.ErrorKindForbidden: codes.PermissionDenied,
+	controllers.ErrorKindTooLarge:  codes.InvalidArgument,
+	controllers.ErrorKindCanceled:  codes.Canceled,
+	controllers.ErrorKindTimeout:   codes.DeadlineExceeded,
+}
+
+type QRIS struct {
+	qrisv1.UnimplementedQRISServiceServer
+
+	qrisController controllers.QRISInterface
+}
+
+func NewQRIS(qrisController controllers.QRISInterface) qrisv1.QRISServiceServer {
+	return &QRIS{
+		qrisController: qrisController,
+	}
+}
+
+func (s *QRIS) Parse(ctx context.Context, req *qrisv1.ParseRequest) (*qrisv1.ParseResponse, error) {
+	qrisController := requestController(ctx, s.qrisController)
+	qris, err, errs := qrisController.Parse(ctx, req.GetQrString())
+	if err != nil {
+		return nil, statusError(err, errs)
+	}
+	crcValid, err := qrisController.VerifyCRC(ctx, qris)
+	if err != nil {
+		return nil, statusError(err, nil)
+	}
+
+	return &qrisv1.ParseResponse{
+		Qris:     qrisMessage(qris),
+		CrcValid: crcValid,
+	}, nil
+}
+
+func (s *QRIS) Validate(ctx context.Context, req *qrisv1.ValidateRequest) (*qrisv1.ValidateResponse, error) {
+	err, errs := requestController(ctx, s.qrisController).IsValid(ctx, req.GetQrString())
+	if err == nil {
+		return &qrisv1.ValidateResponse{
+			Valid: true,
+		}, nil
+	}
+
+	var controllerErr *controllers.Error
+	if !errors.As(err, &controllerErr) || !isVerdict(controllerErr.Kind) {
+		return nil, statusError(err, errs)
+	}
+	response := &qrisv1.ValidateResponse{
+		Valid:   false,
+		Code:    controllerErr.Code,
+		Message: err.Error(),
+	}
+	if errs != nil {
+		response.Issues = *errs
+	}
+
+	return response, nil
+}
+
+func (s *QRIS) Convert(ctx context.Context, req *qrisv1.ConvertRequest) (*qrisv1.ConvertResponse, error) {
+	qrString, qrCode, err, errs := requestController(ctx, s.qrisController).Convert(
+		ctx,
+		req.GetQrString(),
+		req.GetMerchantCity(),
+		req.GetMerchantPostalCode(),
+		req.GetPaymentAmount(),
+		req.GetPaymentFeeCategory(),
+		req.GetPaymentFee(),
+		req.GetTerminalLabel(),
+		qrCodeOptions(req.GetQrCode()),
+	)
+	if err != nil {
+		return nil, statusError(err, errs)
+	}
+	contentType, data, err := decodeDataURI(qrCode)
+	if err != nil {
+		return nil, statusError(err, nil)
+	}
+
+	return &qrisv1.ConvertResponse{
+		QrString:    qrString,
+		QrCode:      data,
+		ContentType: contentType,
+	}, nil
+}
+
+func (s *QRIS) Generate(ctx context.Context, req *qrisv1.GenerateRequest) (*qrisv1.GenerateResponse, error) {
+	qrCode, err, errs := requestController(ctx, s.qrisController).Generate(ctx, req.GetQrString(), qrCodeOptions(req.GetQrCode()))
+	if err != nil {
+		return nil, statusError(err, errs)
+	}
+	contentType, data, err := decodeDataURI(qrCode)
+	if err != nil {
+		return nil, statusError(err, nil)
+	}
+
+	return &qrisv1.GenerateResponse{
+		QrCode:      data,
+		ContentType: contentType,
+	}, nil
+}
+
+func (s *QRIS) Render(ctx context.Context, req *qrisv1.RenderRequest) (*qrisv1.RenderResponse, error) {
+	var stickerOptions *utils.StickerOptions
+	if options := req.GetSticker(); options != nil {
+		stickerOptions = &utils.StickerOptions{
+			Format:               options.GetFormat(),
+			DPI:                  int(options.GetDpi()),
+			ErrorCorrectionLevel: options.GetErrorCorrectionLevel(),
+		}
+	}
+
+	sticker, err, errs := requestController(ctx, s.qrisController).Sticker(ctx, req.GetQrString(), stickerOptions)
+	if err != nil {
+		return nil, statusError(err, errs)
+	}
+	contentType, data, err := decodeDataURI(sticker)
+	if err != nil {
+		return nil, statusError(err, nil)
+	}
+
+	return &qrisv1.RenderResponse{
+		Sticker:     data,
+		ContentType: contentType,
+	}, nil
+}
+
+// isVerdict reports whether an error describes the QRIS itself, rather than a
+// failed or abandoned call.
+func isVerdict(kind controllers.ErrorKind) bool {
+	switch kind {
+	case controllers.ErrorKindInternal, controllers.ErrorKindCanceled, controllers.ErrorKindTimeout:
+		return false
+	}
+
+	return true
+}
+
+// statusError turns a typed controller error into a status carrying its code
+// as ErrorInfo and its issues as BadRequest details, any other error is an
+// internal one.
+func statusError(err error, errs *[]string) error {
+	code, reason := codes.Internal, controllers.ErrorCodeInternal
+	var controllerErr *controllers.Error
+	if errors.As(err, &controllerErr) {
+		code, reason = errorStatusCodes[controllerErr.Kind], controllerErr.Code
+	}
+
+	details := []*errdetails.BadRequest_FieldViolation{}
+	if errs != nil {
+		for _, issue := range *errs {
+			details = append(details, &errdetails.BadRequest_FieldViolation{
+				Field:       "qr_string",
+				Description: issue,
+			})
+		}
+	}
+
+	st := status.New(code, err.Error())
+	withDetails, detailsErr := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: details},
+	)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// decodeDataURI splits the base64 data URIs rendered by the controller, gRPC
+// clients get the raw bytes and their content type instead.
+func decodeDataURI(dataURI string) (string, []byte, error) {
+	header, encoded, found := strings.Cut(dataURI, ",")
+	contentType, isBase64 := strings.CutSuffix(strings.TrimPrefix(header, "data:"), ";base64")
+	if !found || !isBase64 || !strings.HasPrefix(header, "data:") {
+		return "", nil, errors.New("rendered output is not a base64 data URI")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return contentType, data, nil
+}
+
+func qrCodeOptions(options *qrisv1.QRCodeOptions) *utils.QRCodeOptions {
+	if options == nil {
+		return nil
+	}
+
+	return &utils.QRCodeOptions{
+		Format:               options.GetFormat(),
+		Size:                 int(options.GetSize()),
+		ModuleSize:           int(options.GetModuleSize()),
+		Margin:               int(options.GetMargin()),
+		ErrorCorrectionLevel: options.GetErrorCorrectionLevel(),
+		ForegroundColor:      options.GetForegroundColor(),
+		BackgroundColor:      options.GetBackgroundColor(),
+		Logo:                 options.GetLogo(),
+		MarginSet:            options.Margin != nil,
+	}
+}
+
+func dataMessage(data entities.Data) *qrisv1.Data {
+	return &qrisv1.Data{
+		Tag:     data.Tag,
+		Content: data.Content,
+		Data:    data.Data,
+	}
+}
+
+func qrisMessage(qris *entities.QRIS) *qrisv1.QRIS {
+	if qris == nil {
+		return nil
+	}
+	acquirer := qris.Acquirer.Detail
+	switching := qris.Switching.Detail
+	additionalInformation := qris.AdditionalInformation.Detail
+
+	return &qrisv1.QRIS{
+		Version:  dataMessage(qris.Version),
+		Category: dataMessage(qris.Category),
+		Acquirer: &qrisv1.Acquirer{
+			Tag:     qris.Acquirer.Tag,
+			Content: qris.Acquirer.Content,
+			Data:    qris.Acquirer.Data,
+			Detail: &qrisv1.AcquirerDetail{
+				Site:       dataMessage(acquirer.Site),
+				Mpan:       dataMessage(acquirer.MPAN),
+				TerminalId: dataMessage(acquirer.TerminalID),
+				Category:   dataMessage(acquirer.Category),
+			},
+		},
+		Switching: &qrisv1.Switching{
+			Tag:     qris.Switching.Tag,
+			Content: qris.Switching.Content,
+			Data:    qris.Switching.Data,
+			Detail: &qrisv1.SwitchingDetail{
+				Site:     dataMessage(switching.Site),
+				Nmid:     dataMessage(switching.NMID),
+				Category: dataMessage(switching.Category),
+			},
+		},
+		MerchantCategoryCode: dataMessage(qris.MerchantCategoryCode),
+		CurrencyCode:         dataMessage(qris.CurrencyCode),
+		PaymentAmount:        dataMessage(qris.PaymentAmount),
+		PaymentFeeCategory:   dataMessage(qris.PaymentFeeCategory),
+		PaymentFee:           dataMessage(qris.PaymentFee),
+		CountryCode:          dataMessage(qris.CountryCode),
+		MerchantName:         dataMessage(qris.MerchantName),
+		MerchantCity:         dataMessage(qris.MerchantCity),
+		MerchantPostalCode:   dataMessage(qris.MerchantPostalCode),
+		AdditionalInformation: &qrisv1.AdditionalInformation{
+			Tag:     qris.AdditionalInformation.Tag,
+			Content: qris.AdditionalInformation.Content,
+			Data:    qris.AdditionalInformation.Data,
+			Detail: &qrisv1.AdditionalInformationDetail{
+				BillNumber:                    dataMessage(additionalInformation.BillNumber),
+				MobileNumber:                  dataMessage(additionalInformation.MobileNumber),
+				StoreLabel:                    dataMessage(additionalInformation.StoreLabel),
+				LoyaltyNumber:                 dataMessage(additionalInformation.LoyaltyNumber),
+				ReferenceLabel:                dataMessage(additionalInformation.ReferenceLabel),
+				CustomerLabel:                 dataMessage(additionalInformation.CustomerLabel),
+				TerminalLabel:                 dataMessage(additionalInformation.TerminalLabel),
+				PurposeOfTransaction:          dataMessage(additionalInformation.PurposeOfTransaction),
+				AdditionalConsumerDataRequest: dataMessage(additionalInformation.AdditionalConsumerDataRequest),
+				MerchantTaxId:                 dataMessage(additionalInformation.MerchantTaxID),
+				MerchantChannel:               dataMessage(additionalInformation.MerchantChannel),
+				Rfu:                           dataMessage(additionalInformation.RFU),
+				PaymentSystemSpecific:         dataMessage(additionalInformation.PaymentSystemSpecific),
+			},
+		},
+		CrcCode: dataMessage(qris.CRCCode),
+	}
+}
@@ -0,0 +1,280 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	qrisv1 "github.com/fyvri/go-qris/api/proto/qris/v1"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQRISParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		crcValid     bool
+		err          error
+		wantCode     codes.Code
+		wantCRCValid bool
+	}{
+		{
+			name:         "Success",
+			crcValid:     true,
+			wantCRCValid: true,
+		},
+		{
+			name: "Success: Invalid CRC",
+		},
+		{
+			name:     "Error: Canceled",
+			err:      controllers.NewError(controllers.ErrorKindCanceled, controllers.ErrorCodeCanceled, context.Canceled),
+			wantCode: codes.Canceled,
+		},
+		{
+			name:     "Error: Deadline Exceeded",
+			err:      controllers.NewError(controllers.ErrorKindTimeout, controllers.ErrorCodeDeadlineExceeded, context.DeadlineExceeded),
+			wantCode: codes.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed := &entities.QRIS{}
+			server := NewQRIS(&mockQRISController{
+				ParseFunc: func(qrisString string) (*entities.QRIS, error, *[]string) {
+					return parsed, nil, nil
+				},
+				IsValidFunc: func(qrisString string) (error, *[]string) {
+					t.Errorf(expectedButGotMessage, "IsValid() calls", 0, 1)
+					return nil, nil
+				},
+				VerifyCRCFunc: func(qris *entities.QRIS) (bool, error) {
+					if qris != parsed {
+						t.Errorf(expectedButGotMessage, "VerifyCRC() QRIS", parsed, qris)
+					}
+					return test.crcValid, test.err
+				},
+			})
+
+			got, err := server.Parse(context.Background(), &qrisv1.ParseRequest{QrString: testQRISString})
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf(expectedButGotMessage, "status code", test.wantCode, code)
+			}
+			if got.GetCrcValid() != test.wantCRCValid {
+				t.Errorf(expectedButGotMessage, "CrcValid", test.wantCRCValid, got.GetCrcValid())
+			}
+		})
+	}
+}
+
+func TestQRISValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		errs     *[]string
+		want     *qrisv1.ValidateResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "Success: Valid",
+			want: &qrisv1.ValidateResponse{Valid: true},
+		},
+		{
+			name: "Success: Invalid CRC",
+			err:  controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInvalidCRC, fmt.Errorf("invalid CRC16-CCITT code")),
+			want: &qrisv1.ValidateResponse{Valid: false, Code: controllers.ErrorCodeInvalidCRC, Message: "invalid CRC16-CCITT code"},
+		},
+		{
+			name: "Success: Malformed With Issues",
+			err:  controllers.NewError(controllers.ErrorKindMalformed, controllers.ErrorCodeInvalidQRIS, fmt.Errorf("invalid QRIS")),
+			errs: &[]string{"CRC code tag is missing"},
+			want: &qrisv1.ValidateResponse{Valid: false, Code: controllers.ErrorCodeInvalidQRIS, Message: "invalid QRIS", Issues: []string{"CRC code tag is missing"}},
+		},
+		{
+			name:     "Error: Internal",
+			err:      errors.New("boom"),
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "Error: Canceled",
+			err:      controllers.NewError(controllers.ErrorKindCanceled, controllers.ErrorCodeCanceled, context.Canceled),
+			wantCode: codes.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := NewQRIS(&mockQRISController{
+				IsValidFunc: func(qrisString string) (error, *[]string) {
+					return test.err, test.errs
+				},
+			})
+
+			got, err := server.Validate(context.Background(), &qrisv1.ValidateRequest{QrString: testQRISString})
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf(expectedButGotMessage, "status code", test.wantCode, code)
+			}
+			if test.want != nil && (got.GetValid() != test.want.GetValid() || got.GetCode() != test.want.GetCode() || got.GetMessage() != test.want.GetMessage() || !reflect.DeepEqual(got.GetIssues(), test.want.GetIssues())) {
+				t.Errorf(expectedButGotMessage, "Validate()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQRISGenerate(t *testing.T) {
+	tests := []struct {
+		name            string
+		qrCode          string
+		err             error
+		wantCode        codes.Code
+		wantContentType string
+		wantQRCode      []byte
+	}{
+		{
+			name:            "Success",
+			qrCode:          "data:image/svg+xml;base64,PHN2Zz4=",
+			wantContentType: "image/svg+xml",
+			wantQRCode:      []byte("<svg>"),
+		},
+		{
+			name:     "Error: Invalid Options",
+			err:      controllers.NewError(controllers.ErrorKindInvalid, controllers.ErrorCodeInvalidQRCodeOptions, fmt.Errorf("invalid QR code format")),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Error: Not A Data URI",
+			qrCode:   "<svg>",
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotOptions *utils.QRCodeOptions
+			server := NewQRIS(&mockQRISController{
+				GenerateFunc: func(qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+					gotOptions = qrCodeOptions
+					return test.qrCode, test.err, nil
+				},
+			})
+
+			got, err := server.Generate(context.Background(), &qrisv1.GenerateRequest{
+				QrString: testQRISString,
+				QrCode:   &qrisv1.QRCodeOptions{Format: utils.QRCodeFormatSVG, Size: 300},
+			})
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf(expectedButGotMessage, "status code", test.wantCode, code)
+			}
+			if gotOptions == nil || gotOptions.Format != utils.QRCodeFormatSVG || gotOptions.Size != 300 {
+				t.Errorf(expectedButGotMessage, "QR code options", "svg 300", gotOptions)
+			}
+			if got.GetContentType() != test.wantContentType || !reflect.DeepEqual(got.GetQrCode(), test.wantQRCode) {
+				t.Errorf(expectedButGotMessage, "Generate()", string(test.wantQRCode), got)
+			}
+		})
+	}
+}
+
+func TestQRCodeOptions(t *testing.T) {
+	margin := int32(0)
+
+	tests := []struct {
+		name    string
+		options *qrisv1.QRCodeOptions
+		want    *utils.QRCodeOptions
+	}{
+		{
+			name: "Success: Nil",
+		},
+		{
+			name:    "Success: Unset Margin",
+			options: &qrisv1.QRCodeOptions{Format: utils.QRCodeFormatSVG, Size: 300},
+			want:    &utils.QRCodeOptions{Format: utils.QRCodeFormatSVG, Size: 300},
+		},
+		{
+			name:    "Success: Zero Margin",
+			options: &qrisv1.QRCodeOptions{Margin: &margin},
+			want:    &utils.QRCodeOptions{Margin: 0, MarginSet: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := qrCodeOptions(test.options); !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "qrCodeOptions()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		errs       *[]string
+		wantCode   codes.Code
+		wantReason string
+		wantIssues int
+	}{
+		{
+			name:       "Malformed With Issues",
+			err:        controllers.NewError(controllers.ErrorKindMalformed, controllers.ErrorCodeInvalidQRIS, fmt.Errorf("invalid QRIS")),
+			errs:       &[]string{"Version tag is missing", "CRC code tag is missing"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: controllers.ErrorCodeInvalidQRIS,
+			wantIssues: 2,
+		},
+		{
+			name:       "Forbidden",
+			err:        controllers.NewError(controllers.ErrorKindForbidden, controllers.ErrorCodeMerchantNotAllowed, fmt.Errorf("merchant is not allowed")),
+			wantCode:   codes.PermissionDenied,
+			wantReason: controllers.ErrorCodeMerchantNotAllowed,
+		},
+		{
+			name:       "Deadline Exceeded",
+			err:        controllers.NewError(controllers.ErrorKindTimeout, controllers.ErrorCodeDeadlineExceeded, context.DeadlineExceeded),
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: controllers.ErrorCodeDeadlineExceeded,
+		},
+		{
+			name:       "Untyped",
+			err:        errors.New("boom"),
+			wantCode:   codes.Internal,
+			wantReason: controllers.ErrorCodeInternal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			st := status.Convert(statusError(test.err, test.errs))
+			if st.Code() != test.wantCode {
+				t.Errorf(expectedButGotMessage, "status code", test.wantCode, st.Code())
+			}
+
+			var reason string
+			var issues int
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.GetReason()
+				case *errdetails.BadRequest:
+					issues = len(detail.GetFieldViolations())
+				}
+			}
+			if reason != test.wantReason {
+				t.Errorf(expectedButGotMessage, "reason", test.wantReason, reason)
+			}
+			if issues != test.wantIssues {
+				t.Errorf(expectedButGotMessage, "issues", test.wantIssues, issues)
+			}
+		})
+	}
+}
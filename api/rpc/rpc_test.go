@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	expectedButGotMessage = "Expected %v = %v, but got = %v"
+
+	testQRISString = "QR String"
+)
+
+type mockQRISController struct {
+	ParseFunc      func(qrisString string) (*entities.QRIS, error, *[]string)
+	ParseImageFunc func(imageData []byte) (*entities.QRIS, error, *[]string)
+	ConvertFunc    func(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string)
+	PatchFunc      func(qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string)
+	GenerateFunc   func(qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string)
+	IsValidFunc    func(qrisString string) (error, *[]string)
+	VerifyCRCFunc  func(qris *entities.QRIS) (bool, error)
+	StickerFunc    func(qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string)
+	WithTenantFunc func(tenant *entities.Tenant) controllers.QRISInterface
+}
+
+func (m *mockQRISController) Parse(ctx context.Context, qrisString string) (*entities.QRIS, error, *[]string) {
+	if m.ParseFunc != nil {
+		return m.ParseFunc(qrisString)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockQRISController) ParseImage(ctx context.Context, imageData []byte) (*entities.QRIS, error, *[]string) {
+	if m.ParseImageFunc != nil {
+		return m.ParseImageFunc(imageData)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockQRISController) Convert(ctx context.Context, qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
+	if m.ConvertFunc != nil {
+		return m.ConvertFunc(qrisString, merchantCityValue, merchantPostalCodeValue, paymentAmountValue, paymentFeeCategoryValue, paymentFeeValue, terminalLabelValue, qrCodeOptions)
+	}
+	return "", "", nil, nil
+}
+
+func (m *mockQRISController) Patch(ctx context.Context, qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(qrisString, patch)
+	}
+	return "", nil, nil, nil
+}
+
+func (m *mockQRISController) Generate(ctx context.Context, qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+	if m.GenerateFunc != nil {
+		return m.GenerateFunc(qrisString, qrCodeOptions)
+	}
+	return "", nil, nil
+}
+
+func (m *mockQRISController) IsValid(ctx context.Context, qrisString string) (error, *[]string) {
+	if m.IsValidFunc != nil {
+		return m.IsValidFunc(qrisString)
+	}
+	return nil, nil
+}
+
+func (m *mockQRISController) VerifyCRC(ctx context.Context, qris *entities.QRIS) (bool, error) {
+	if m.VerifyCRCFunc != nil {
+		return m.VerifyCRCFunc(qris)
+	}
+	return true, nil
+}
+
+func (m *mockQRISController) Sticker(ctx context.Context, qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string) {
+	if m.StickerFunc != nil {
+		return m.StickerFunc(qrisString, stickerOptions)
+	}
+	return "", nil, nil
+}
+
+func (m *mockQRISController) WithLogger(logger *slog.Logger) controllers.QRISInterface {
+	return m
+}
+
+func (m *mockQRISController) WithTenant(tenant *entities.Tenant) controllers.QRISInterface {
+	if m.WithTenantFunc != nil {
+		return m.WithTenantFunc(tenant)
+	}
+	return m
+}
+
+type mockTenantStore struct {
+	TenantFunc func(apiKey string) (*entities.Tenant, bool)
+}
+
+func (m *mockTenantStore) Tenant(apiKey string) (*entities.Tenant, bool) {
+	if m.TenantFunc != nil {
+		return m.TenantFunc(apiKey)
+	}
+	return nil, false
+}
+
+type mockRateLimiter struct {
+	AllowFunc func(key string) (bool, time.Duration)
+}
+
+func (m *mockRateLimiter) Allow(key string) (bool, time.Duration) {
+	if m.AllowFunc != nil {
+		return m.AllowFunc(key)
+	}
+	return true, 0
+}
+
+// mockServerTransportStream records the metadata a handler sets outside a
+// real server.
+type mockServerTransportStream struct {
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (m *mockServerTransportStream) Method() string {
+	return ""
+}
+
+func (m *mockServerTransportStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func (m *mockServerTransportStream) SendHeader(md metadata.MD) error {
+	return m.SetHeader(md)
+}
+
+func (m *mockServerTransportStream) SetTrailer(md metadata.MD) error {
+	m.trailer = metadata.Join(m.trailer, md)
+	return nil
+}
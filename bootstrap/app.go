@@ -1,12 +1,72 @@
 package bootstrap
 
+import (
+	"log"
+	"log/slog"
+	"os"
+	"sync/atomic"
+
+	"github.com/fyvri/go-qris/internal/usecases"
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Application holds what the REST and gRPC servers share, so a tenant's
+// rate limit and the metrics cover both.
 type Application struct {
-	Env *Env
+	Env              *Env
+	Logger           *slog.Logger
+	Ready            *atomic.Bool
+	Tenants          *Tenants
+	Metrics          utils.MetricsInterface
+	RateLimiter      utils.RateLimiterInterface
+	IdempotencyStore utils.IdempotencyStoreInterface
+	// Profile is the EMVCo tag profile QR strings are read and built with,
+	// the zero value being the QRIS default.
+	Profile usecases.Profile
 }
 
-func App() Application {
+// App loads the configuration, with args as command line flags, and exits
+// with every problem found.
+func App(args []string) Application {
+	env, err := LoadEnv(args)
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%s", err)
+	}
+
 	app := &Application{}
-	app.Env = NewEnv()
+	app.Env = env
+	app.Logger = NewLogger(app.Env, os.Stderr)
+	app.Ready = &atomic.Bool{}
+
+	// Anything written with the standard log package goes through the same
+	// redacting handler.
+	slog.SetDefault(app.Logger)
+
+	if app.Env.AppEnv == "release" {
+		gin.SetMode(gin.ReleaseMode)
+	} else {
+		app.Logger.Info("The App is running in development env")
+	}
+
+	tenants, err := NewTenants(app.Env)
+	if err != nil {
+		log.Fatalf("Invalid API keys: %s", err)
+	}
+	if tenants == nil {
+		app.Logger.Warn("API key authentication is disabled, set API_KEYS_FILE or API_KEYS to enable it")
+	}
+	app.Tenants = tenants
+
+	app.Metrics = utils.NewMetrics()
+	if app.Env.RateLimitRPS > 0 {
+		app.RateLimiter = utils.NewRateLimiter(app.Env.RateLimitRPS, app.Env.RateLimitBurst)
+	}
+	if app.Env.IdempotencyTTL > 0 {
+		app.IdempotencyStore = utils.NewIdempotencyStore(app.Env.IdempotencyMaxEntries, app.Env.IdempotencyMaxBytes)
+	}
+	app.Profile = usecases.DefaultProfile()
 
 	return *app
 }
@@ -0,0 +1,383 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ConfigFileEnv = "CONFIG_FILE"
+
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+
+	maskedSecret = "********"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	levelType    = reflect.TypeOf(slog.Level(0))
+)
+
+// LoadEnv layers the defaults, the YAML or TOML file named by -config or
+// CONFIG_FILE, the environment (and .env) and args, then validates the result.
+// Every problem is reported at once.
+func LoadEnv(args []string) (*Env, error) {
+	godotenv.Load()
+
+	env := DefaultEnv()
+	env.sources = map[string]string{}
+	fields := envFields(env)
+	var errs []error
+
+	flagValues := map[string]string{}
+	flags := flag.NewFlagSet("go-qris", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv(ConfigFileEnv), "YAML or TOML config file")
+	for _, field := range fields {
+		flags.Func(field.flag, field.usage, func(value string) error {
+			flagValues[field.key] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		errs = append(errs, fmt.Errorf("unexpected argument %s", flags.Arg(0)))
+	}
+
+	if *configFile != "" {
+		fileValues, err := readConfigFile(*configFile)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		known := map[string]bool{}
+		for _, field := range fields {
+			known[strings.ToLower(field.key)] = true
+		}
+		for _, key := range slices.Sorted(maps.Keys(fileValues)) {
+			if !known[key] {
+				errs = append(errs, fmt.Errorf("%s: unknown key %s", *configFile, key))
+			}
+		}
+		errs = append(errs, applyLayer(env, fields, SourceFile, func(key string) (string, bool) {
+			value, exists := fileValues[strings.ToLower(key)]
+			return value, exists
+		})...)
+	}
+	errs = append(errs, applyLayer(env, fields, SourceEnv, os.LookupEnv)...)
+	errs = append(errs, applyLayer(env, fields, SourceFlag, func(key string) (string, bool) {
+		value, exists := flagValues[key]
+		return value, exists
+	})...)
+
+	env.QRCodeFormat = strings.ToLower(env.QRCodeFormat)
+	env.QRCodeErrorCorrection = strings.ToUpper(env.QRCodeErrorCorrection)
+	env.LogFormat = strings.ToLower(env.LogFormat)
+	errs = append(errs, env.Validate()...)
+
+	return env, errors.Join(errs...)
+}
+
+// Validate reports every setting that can not work, in field order.
+func (env *Env) Validate() []error {
+	var errs []error
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if port, err := strconv.Atoi(env.Port); err != nil || port < 1 || port > 65535 {
+		invalid("PORT", "%q is not a port number", env.Port)
+	}
+	if port, err := strconv.Atoi(env.GRPCPort); env.GRPCPort != "" && (err != nil || port < 1 || port > 65535) {
+		invalid("GRPC_PORT", "%q is not a port number", env.GRPCPort)
+	} else if env.GRPCPort != "" && env.GRPCPort == env.Port {
+		invalid("GRPC_PORT", "must differ from PORT")
+	}
+	if env.QRCodeModuleSize == 0 && env.QRCodeSize < 60 {
+		invalid("QR_CODE_SIZE", "%d is below the minimum of 60", env.QRCodeSize)
+	}
+	if env.QRCodeModuleSize < 0 {
+		invalid("QR_CODE_MODULE_SIZE", "must not be negative")
+	}
+	if err := utils.NewQRCode().ValidateOptions(env.QRCodeOptions()); err != nil {
+		invalid("QR_CODE_*", "%s", err)
+	}
+	for _, timeout := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", env.ServerReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", env.ServerWriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", env.ServerIdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT", env.ServerShutdownTimeout},
+		{"TLS_RELOAD_INTERVAL", env.TLSReloadInterval},
+		{"PARSE_CACHE_TTL", env.ParseCacheTTL},
+		{"IDEMPOTENCY_TTL", env.IdempotencyTTL},
+	} {
+		if timeout.value < 0 {
+			invalid(timeout.key, "must not be negative")
+		}
+	}
+
+	if (env.TLSCertFile == "") != (env.TLSKeyFile == "") {
+		invalid("TLS_CERT_FILE", "must be set together with TLS_KEY_FILE")
+	}
+	if env.TLSClientCAFile != "" && env.TLSCertFile == "" {
+		invalid("TLS_CLIENT_CA_FILE", "requires TLS_CERT_FILE and TLS_KEY_FILE")
+	}
+	for _, file := range []struct {
+		key  string
+		path string
+	}{
+		{"TLS_CERT_FILE", env.TLSCertFile},
+		{"TLS_KEY_FILE", env.TLSKeyFile},
+		{"TLS_CLIENT_CA_FILE", env.TLSClientCAFile},
+		{"API_KEYS_FILE", env.APIKeysFile},
+	} {
+		if _, err := os.Stat(file.path); file.path != "" && err != nil {
+			invalid(file.key, "%s", err)
+		}
+	}
+	for _, origin := range env.CORSAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if parsed, err := url.Parse(origin); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || strings.TrimSuffix(parsed.Path, "/") != "" {
+			invalid("CORS_ALLOWED_ORIGINS", "%q is not an origin such as https://example.com", origin)
+		}
+	}
+
+	if env.LogFormat != LogFormatJSON && env.LogFormat != LogFormatText {
+		invalid("LOG_FORMAT", "%q is neither %s nor %s", env.LogFormat, LogFormatJSON, LogFormatText)
+	}
+	if env.RateLimitRPS < 0 {
+		invalid("RATE_LIMIT_RPS", "must not be negative")
+	}
+	if env.RateLimitBurst < 1 {
+		invalid("RATE_LIMIT_BURST", "must be at least 1")
+	}
+	for _, proxy := range env.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			invalid("TRUSTED_PROXIES", "%q is neither an IP nor a CIDR", proxy)
+		}
+	}
+	if env.MaxBodyBytes < 0 {
+		invalid("MAX_BODY_BYTES", "must not be negative")
+	}
+	if env.MaxQRStringLength < 0 {
+		invalid("MAX_QR_STRING_LENGTH", "must not be negative")
+	}
+	if env.ParseCacheSize < 0 {
+		invalid("PARSE_CACHE_SIZE", "must not be negative")
+	}
+	if env.IdempotencyMaxEntries < 0 {
+		invalid("IDEMPOTENCY_MAX_ENTRIES", "must not be negative")
+	}
+	if env.IdempotencyMaxBytes < 0 {
+		invalid("IDEMPOTENCY_MAX_BYTES", "must not be negative")
+	}
+	if env.APIKeysFile != "" && env.APIKeys != "" {
+		invalid("API_KEYS", "set either API_KEYS_FILE or API_KEYS, not both")
+	}
+
+	return errs
+}
+
+// Print writes the effective configuration as KEY="value" lines commented
+// with where each value came from. Secrets are masked.
+func (env *Env) Print(w io.Writer) error {
+	for _, field := range envFields(env) {
+		value := formatEnvValue(field.value)
+		if field.secret && value != "" {
+			value = maskedSecret
+		}
+		source := env.sources[field.key]
+		if source == "" {
+			source = SourceDefault
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s # %s\n", field.key, strconv.Quote(value), source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type envField struct {
+	key    string
+	flag   string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+func envFields(env *Env) []envField {
+	var fields []envField
+	value := reflect.ValueOf(env).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		fields = append(fields, envField{
+			key:    key,
+			flag:   strings.ReplaceAll(strings.ToLower(key), "_", "-"),
+			usage:  field.Tag.Get("usage"),
+			secret: field.Tag.Get("secret") == "true",
+			value:  value.Field(i),
+		})
+	}
+
+	return fields
+}
+
+func applyLayer(env *Env, fields []envField, source string, lookup func(key string) (string, bool)) []error {
+	var errs []error
+	for _, field := range fields {
+		raw, exists := lookup(field.key)
+		if !exists {
+			continue
+		}
+
+		if err := parseEnvValue(field.value, raw); err != nil {
+			value := strconv.Quote(raw)
+			if field.secret {
+				value = maskedSecret
+			}
+			errs = append(errs, fmt.Errorf("%s: invalid value %s from %s: %s", field.key, value, source, err))
+			continue
+		}
+		env.sources[field.key] = source
+	}
+
+	return errs
+}
+
+func parseEnvValue(value reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case value.Type() == durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+	case value.Type() == levelType:
+		return value.Addr().Interface().(*slog.Level).UnmarshalText([]byte(raw))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int, value.Kind() == reflect.Int64:
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		value.SetInt(number)
+	case value.Kind() == reflect.Bool:
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("not a boolean")
+		}
+		value.SetBool(enabled)
+	case value.Kind() == reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("not a number")
+		}
+		value.SetFloat(number)
+	case value.Kind() == reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", value.Type())
+	}
+
+	return nil
+}
+
+func formatEnvValue(value reflect.Value) string {
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case slog.Level:
+		return strings.ToLower(v.String())
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// readConfigFile flattens a YAML or TOML file into lower-cased keys. Lists are
+// joined with commas like their environment variables and tables, such as
+// inline API keys, become JSON.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	document := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		err = toml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	for key, value := range document {
+		switch v := value.(type) {
+		case map[string]any:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+			}
+			value = string(encoded)
+		case []any:
+			var parts []string
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			value = strings.Join(parts, ",")
+		}
+		values[strings.ToLower(key)] = fmt.Sprint(value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,160 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var expectedButGotMessage = "Expected %v = %v, but got = %v"
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %s", path, err)
+	}
+	return path
+}
+
+func TestLoadEnvLayers(t *testing.T) {
+	yamlFile := writeConfigFile(t, "config.yaml", "port: 8080\nqr_code_size: 300\nserver_read_timeout: 5s\ncors_allowed_origins: [https://a.example, https://b.example]\n")
+	tomlFile := writeConfigFile(t, "config.toml", "port = 9090\nlog_format = \"TEXT\"\n")
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		args        []string
+		want        func(env *Env) any
+		wantValue   any
+		wantSources map[string]string
+	}{
+		{
+			name:        "Success: Defaults",
+			want:        func(env *Env) any { return env.Port },
+			wantValue:   "1337",
+			wantSources: map[string]string{"PORT": ""},
+		},
+		{
+			name:        "Success: YAML File",
+			args:        []string{"-config", yamlFile},
+			want:        func(env *Env) any { return strings.Join(env.CORSAllowedOrigins, " ") },
+			wantValue:   "https://a.example https://b.example",
+			wantSources: map[string]string{"PORT": SourceFile, "SERVER_READ_TIMEOUT": SourceFile},
+		},
+		{
+			name:        "Success: TOML File From Environment",
+			env:         map[string]string{ConfigFileEnv: tomlFile},
+			want:        func(env *Env) any { return env.LogFormat },
+			wantValue:   LogFormatText,
+			wantSources: map[string]string{"PORT": SourceFile, "LOG_FORMAT": SourceFile},
+		},
+		{
+			name:        "Success: Environment Over File",
+			env:         map[string]string{"QR_CODE_SIZE": "320"},
+			args:        []string{"-config", yamlFile},
+			want:        func(env *Env) any { return env.QRCodeSize },
+			wantValue:   320,
+			wantSources: map[string]string{"PORT": SourceFile, "QR_CODE_SIZE": SourceEnv},
+		},
+		{
+			name:        "Success: Boolean From Environment",
+			env:         map[string]string{"PLAYGROUND_ENABLED": "true"},
+			want:        func(env *Env) any { return env.PlaygroundEnabled },
+			wantValue:   true,
+			wantSources: map[string]string{"PLAYGROUND_ENABLED": SourceEnv},
+		},
+		{
+			name:        "Success: Flag Over Environment",
+			env:         map[string]string{"SERVER_READ_TIMEOUT": "10s"},
+			args:        []string{"-config", yamlFile, "-server-read-timeout", "20s"},
+			want:        func(env *Env) any { return env.ServerReadTimeout },
+			wantValue:   20 * time.Second,
+			wantSources: map[string]string{"SERVER_READ_TIMEOUT": SourceFlag},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			env, err := LoadEnv(test.args)
+			if err != nil {
+				t.Fatalf(expectedButGotMessage, "LoadEnv() error", nil, err)
+			}
+			if got := test.want(env); got != test.wantValue {
+				t.Errorf(expectedButGotMessage, "LoadEnv()", test.wantValue, got)
+			}
+			for key, want := range test.wantSources {
+				if got := env.sources[key]; got != want {
+					t.Errorf(expectedButGotMessage, key+" source", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEnvReportsEveryProblem(t *testing.T) {
+	configFile := writeConfigFile(t, "config.yaml", "port: 0\nqr_cod_size: 256\n")
+	t.Setenv("QR_CODE_SIZE", "20")
+	t.Setenv("RATE_LIMIT_RPS", "fast")
+	t.Setenv("API_KEYS", `{"tenants": []}`)
+	t.Setenv("PARSE_CACHE_SIZE", "-1")
+	t.Setenv("IDEMPOTENCY_MAX_ENTRIES", "-1")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,proxy.internal")
+
+	_, err := LoadEnv([]string{"-config", configFile, "-log-format", "xml", "-tls-key-file", configFile, "-grpc-port", "70000"})
+	if err == nil {
+		t.Fatalf(expectedButGotMessage, "LoadEnv() error", "problems", nil)
+	}
+
+	for _, want := range []string{
+		"unknown key qr_cod_size",
+		`RATE_LIMIT_RPS: invalid value "fast" from env`,
+		`PORT: "0" is not a port number`,
+		`GRPC_PORT: "70000" is not a port number`,
+		"QR_CODE_SIZE: 20 is below the minimum of 60",
+		"TLS_CERT_FILE: must be set together with TLS_KEY_FILE",
+		`LOG_FORMAT: "xml" is neither json nor text`,
+		"PARSE_CACHE_SIZE: must not be negative",
+		"IDEMPOTENCY_MAX_ENTRIES: must not be negative",
+		`TRUSTED_PROXIES: "proxy.internal" is neither an IP nor a CIDR`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf(expectedButGotMessage, "LoadEnv() error", want, err)
+		}
+	}
+}
+
+func TestEnvPrint(t *testing.T) {
+	t.Setenv("API_KEYS", `{"tenants": [{"name": "sintas", "api_keys": ["a-long-random-secret"]}]}`)
+
+	env, err := LoadEnv([]string{"-port", "8080"})
+	if err != nil {
+		t.Fatalf(expectedButGotMessage, "LoadEnv() error", nil, err)
+	}
+
+	var b strings.Builder
+	if err := env.Print(&b); err != nil {
+		t.Fatalf(expectedButGotMessage, "Print() error", nil, err)
+	}
+	got := b.String()
+
+	for _, want := range []string{
+		`PORT="8080" # flag` + "\n",
+		`SERVER_IDLE_TIMEOUT="1m0s" # default` + "\n",
+		`LOG_LEVEL="info" # default` + "\n",
+		`API_KEYS="********" # env` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf(expectedButGotMessage, "Print()", want, got)
+		}
+	}
+	if strings.Contains(got, "a-long-random-secret") {
+		t.Errorf(expectedButGotMessage, "Print()", "masked API keys", got)
+	}
+}
@@ -2,55 +2,99 @@ package bootstrap
 
 import (
 	"log"
-	"os"
-	"strconv"
+	"log/slog"
+	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/fyvri/go-qris/pkg/utils"
 )
 
+// Env is the effective configuration. Each field is set, in increasing
+// precedence, by its default, the config file key (the lower-cased name in the
+// env tag), the environment variable and the command line flag.
 type Env struct {
-	AppEnv     string `mapstructure:"APP_ENV"`
-	Port       string `mapstructure:"PORT"`
-	QRCodeSize int    `mapstructure:"QR_CODE_SIZE"`
-}
+	AppEnv                string        `env:"APP_ENV" usage:"application environment, release turns off gin debug output"`
+	Port                  string        `env:"PORT" usage:"port to listen on"`
+	GRPCPort              string        `env:"GRPC_PORT" usage:"port to serve the gRPC API on, empty turns it off"`
+	QRCodeFormat          string        `env:"QR_CODE_FORMAT" usage:"default QR code format: png, svg or pdf"`
+	QRCodeSize            int           `env:"QR_CODE_SIZE" usage:"default QR code size in pixels, at least 60"`
+	QRCodeModuleSize      int           `env:"QR_CODE_MODULE_SIZE" usage:"default QR code module size, overrides the size when set"`
+	QRCodeMargin          int           `env:"QR_CODE_MARGIN" usage:"default QR code quiet zone in modules"`
+	QRCodeErrorCorrection string        `env:"QR_CODE_ERROR_CORRECTION_LEVEL" usage:"default QR code error correction level: L, M, Q or H"`
+	QRCodeForegroundColor string        `env:"QR_CODE_FOREGROUND_COLOR" usage:"default QR code foreground hex color"`
+	QRCodeBackgroundColor string        `env:"QR_CODE_BACKGROUND_COLOR" usage:"default QR code background hex color"`
+	ServerReadTimeout     time.Duration `env:"SERVER_READ_TIMEOUT" usage:"maximum duration for reading a request"`
+	ServerWriteTimeout    time.Duration `env:"SERVER_WRITE_TIMEOUT" usage:"maximum duration for writing a response"`
+	ServerIdleTimeout     time.Duration `env:"SERVER_IDLE_TIMEOUT" usage:"maximum duration a keep-alive connection stays idle"`
+	ServerShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" usage:"maximum duration in-flight requests get on shutdown"`
+	TLSCertFile           string        `env:"TLS_CERT_FILE" usage:"PEM certificate to serve HTTPS with"`
+	TLSKeyFile            string        `env:"TLS_KEY_FILE" usage:"PEM private key of the certificate"`
+	TLSClientCAFile       string        `env:"TLS_CLIENT_CA_FILE" usage:"PEM CA bundle that client certificates must chain to"`
+	TLSReloadInterval     time.Duration `env:"TLS_RELOAD_INTERVAL" usage:"how often the TLS files are checked for changes, 0 turns reloading off"`
+	CORSAllowedOrigins    []string      `env:"CORS_ALLOWED_ORIGINS" usage:"comma separated origins allowed to call the API from a browser, or *"`
+	PlaygroundEnabled     bool          `env:"PLAYGROUND_ENABLED" usage:"serve the web playground at / to browsers"`
+	LogLevel              slog.Level    `env:"LOG_LEVEL" usage:"minimum log level: debug, info, warn or error"`
+	LogFormat             string        `env:"LOG_FORMAT" usage:"log format: json or text"`
+	RateLimitRPS          float64       `env:"RATE_LIMIT_RPS" usage:"requests a second per tenant or client IP, 0 turns rate limiting off"`
+	RateLimitBurst        int           `env:"RATE_LIMIT_BURST" usage:"requests a tenant or client IP may burst"`
+	TrustedProxies        []string      `env:"TRUSTED_PROXIES" usage:"comma separated proxy IPs or CIDRs whose X-Forwarded-For sets the client IP, empty trusts none"`
+	MaxBodyBytes          int64         `env:"MAX_BODY_BYTES" usage:"maximum request body size, 0 for no limit"`
+	MaxQRStringLength     int           `env:"MAX_QR_STRING_LENGTH" usage:"maximum QR string length, 0 for no limit"`
+	ParseCacheSize        int           `env:"PARSE_CACHE_SIZE" usage:"parsed static QRIS templates kept in memory, 0 turns the cache off"`
+	ParseCacheTTL         time.Duration `env:"PARSE_CACHE_TTL" usage:"how long a parsed template is kept, 0 until it is evicted"`
+	IdempotencyTTL        time.Duration `env:"IDEMPOTENCY_TTL" usage:"how long responses to an Idempotency-Key are replayed, 0 turns idempotency keys off"`
+	IdempotencyMaxEntries int           `env:"IDEMPOTENCY_MAX_ENTRIES" usage:"idempotency keys kept in memory before the oldest answered ones are evicted, 0 for no limit"`
+	IdempotencyMaxBytes   int64         `env:"IDEMPOTENCY_MAX_BYTES" usage:"bytes of kept responses before the oldest answered ones are evicted, 0 for no limit"`
+	APIKeysFile           string        `env:"API_KEYS_FILE" usage:"JSON file with the tenants and their API keys"`
+	APIKeys               string        `env:"API_KEYS" usage:"inline JSON with the tenants and their API keys" secret:"true"`
 
-func NewEnv() *Env {
-	env := Env{}
-	godotenv.Load()
+	sources map[string]string
+}
 
-	// Load App Env
-	appEnv, exists := os.LookupEnv("APP_ENV")
-	if exists {
-		env.AppEnv = appEnv
+// DefaultEnv is the configuration before any file, variable or flag.
+func DefaultEnv() *Env {
+	return &Env{
+		Port:                  "1337",
+		QRCodeFormat:          utils.QRCodeFormatPNG,
+		QRCodeSize:            256,
+		QRCodeMargin:          4,
+		QRCodeErrorCorrection: "L",
+		QRCodeForegroundColor: "#000000",
+		QRCodeBackgroundColor: "#FFFFFF",
+		ServerReadTimeout:     15 * time.Second,
+		ServerWriteTimeout:    30 * time.Second,
+		ServerIdleTimeout:     60 * time.Second,
+		ServerShutdownTimeout: 15 * time.Second,
+		TLSReloadInterval:     30 * time.Second,
+		LogLevel:              slog.LevelInfo,
+		LogFormat:             LogFormatJSON,
+		RateLimitBurst:        10,
+		MaxBodyBytes:          4 << 20,
+		MaxQRStringLength:     512,
+		ParseCacheTTL:         10 * time.Minute,
+		IdempotencyMaxEntries: 10000,
+		IdempotencyMaxBytes:   64 << 20,
 	}
+}
 
-	// Load Port
-	port, exists := os.LookupEnv("PORT")
-	if exists {
-		env.Port = port
-	} else {
-		env.Port = "1337"
+// NewEnv loads the configuration without command line flags and exits with
+// every problem found.
+func NewEnv() *Env {
+	env, err := LoadEnv(nil)
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%s", err)
 	}
 
-	// Load QR Code Size
-	qrCodeSize, exists := os.LookupEnv("QR_CODE_SIZE")
-	if exists {
-		size, err := strconv.Atoi(qrCodeSize)
-		if err != nil {
-			log.Fatalf("Invalid QR_CODE_SIZE value: %s", qrCodeSize)
-		}
-		env.QRCodeSize = size
-	}
-	if env.QRCodeSize < 60 {
-		env.QRCodeSize = 256
-	}
+	return env
+}
 
-	if env.AppEnv == "release" {
-		gin.SetMode(gin.ReleaseMode)
-	} else {
-		log.Println("The App is running in development env")
+func (env *Env) QRCodeOptions() *utils.QRCodeOptions {
+	return &utils.QRCodeOptions{
+		Format:               env.QRCodeFormat,
+		Size:                 env.QRCodeSize,
+		ModuleSize:           env.QRCodeModuleSize,
+		Margin:               env.QRCodeMargin,
+		ErrorCorrectionLevel: env.QRCodeErrorCorrection,
+		ForegroundColor:      env.QRCodeForegroundColor,
+		BackgroundColor:      env.QRCodeBackgroundColor,
 	}
-
-	return &env
 }
@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// NewLogger writes env.LogFormat records at env.LogLevel and up, with QR
+// strings, MPANs, NMIDs and mobile numbers redacted.
+func NewLogger(env *Env, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level:       env.LogLevel,
+		ReplaceAttr: utils.RedactAttr,
+	}
+	if env.LogFormat == LogFormatText {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+
+	return slog.New(slog.NewJSONHandler(w, options))
+}
@@ -0,0 +1,65 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+func NewServer(env *Env, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         ":" + env.Port,
+		Handler:      handler,
+		ReadTimeout:  env.ServerReadTimeout,
+		WriteTimeout: env.ServerWriteTimeout,
+		IdleTimeout:  env.ServerIdleTimeout,
+	}
+}
+
+// Serve runs server until ctx is done and then shuts it down gracefully.
+// ready is only true while new requests are accepted, so load balancers stop
+// routing before in-flight requests are drained.
+func Serve(ctx context.Context, server *http.Server, env *Env, ready *atomic.Bool) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+
+	return ServeListener(ctx, server, listener, env, ready)
+}
+
+// ServeListener is Serve on an existing listener, speaking TLS when the
+// server has a TLS config.
+func ServeListener(ctx context.Context, server *http.Server, listener net.Listener, env *Env, ready *atomic.Bool) error {
+	if server.TLSConfig != nil {
+		listener = tls.NewListener(listener, server.TLSConfig)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	ready.Store(true)
+
+	select {
+	case err := <-serveErr:
+		ready.Store(false)
+		return err
+	case <-ctx.Done():
+	}
+	ready.Store(false)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), env.ServerShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
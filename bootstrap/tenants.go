@@ -0,0 +1,121 @@
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/internal/interface/controllers"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+// minAPIKeyLength keeps keys long enough not to be guessed.
+const minAPIKeyLength = 16
+
+// Tenants is the API key store, keys are only kept as SHA-256 hashes.
+type Tenants struct {
+	tenants map[[sha256.Size]byte]*entities.Tenant
+}
+
+type tenantsFile struct {
+	Tenants []*entities.Tenant `json:"tenants"`
+}
+
+// NewTenants loads the tenants from API_KEYS_FILE or the inline API_KEYS JSON.
+// Without either the API stays open and nil is returned.
+func NewTenants(env *Env) (*Tenants, error) {
+	data := []byte(env.APIKeys)
+	if env.APIKeysFile != "" {
+		if env.APIKeys != "" {
+			return nil, fmt.Errorf("set either API_KEYS_FILE or API_KEYS, not both")
+		}
+
+		var err error
+		data, err = os.ReadFile(env.APIKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read API keys file: %w", err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var file tenantsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	if len(file.Tenants) == 0 {
+		return nil, fmt.Errorf("no tenants are configured")
+	}
+
+	names := map[string]bool{}
+	tenants := &Tenants{
+		tenants: map[[sha256.Size]byte]*entities.Tenant{},
+	}
+	for _, tenant := range file.Tenants {
+		if tenant.Name == "" || names[tenant.Name] {
+			return nil, fmt.Errorf("tenant name %q is empty or duplicated", tenant.Name)
+		}
+		names[tenant.Name] = true
+
+		if len(tenant.APIKeys) == 0 && len(tenant.PublishableKeys) == 0 {
+			return nil, fmt.Errorf("tenant %s has no API keys", tenant.Name)
+		}
+		if len(tenant.AllowedEndpoints) == 0 {
+			return nil, fmt.Errorf("tenant %s allows no endpoints", tenant.Name)
+		}
+		if tenant.QRCodeOptions != nil {
+			if err := utils.NewQRCode().ValidateOptions(utils.MergeQRCodeOptions(env.QRCodeOptions(), controllers.TenantQRCodeOptions(tenant.QRCodeOptions))); err != nil {
+				return nil, fmt.Errorf("tenant %s has invalid QR code options: %w", tenant.Name, err)
+			}
+		}
+
+		if len(tenant.PublishableKeys) > 0 {
+			if !tenant.AllowsEndpoint(entities.TenantPublishableEndpoint) {
+				return nil, fmt.Errorf("tenant %s has publishable keys but does not allow %s", tenant.Name, entities.TenantPublishableEndpoint)
+			}
+			if len(tenant.AllowedOrigins) == 0 || slices.Contains(tenant.AllowedOrigins, entities.TenantWildcard) {
+				return nil, fmt.Errorf("tenant %s has publishable keys but no list of allowed origins", tenant.Name)
+			}
+
+			// Publishable keys resolve to a copy of the tenant that may only
+			// call the widget's endpoint.
+			publishable := *tenant
+			publishable.APIKeys, publishable.PublishableKeys = nil, nil
+			publishable.AllowedEndpoints = []string{entities.TenantPublishableEndpoint}
+			publishable.Publishable = true
+			if err := tenants.add(&publishable, tenant.PublishableKeys); err != nil {
+				return nil, err
+			}
+		}
+		if err := tenants.add(tenant, tenant.APIKeys); err != nil {
+			return nil, err
+		}
+		tenant.APIKeys, tenant.PublishableKeys = nil, nil
+	}
+
+	return tenants, nil
+}
+
+func (t *Tenants) add(tenant *entities.Tenant, apiKeys []string) error {
+	for _, apiKey := range apiKeys {
+		hash := sha256.Sum256([]byte(apiKey))
+		if len(apiKey) < minAPIKeyLength {
+			return fmt.Errorf("tenant %s has an API key shorter than %d characters", tenant.Name, minAPIKeyLength)
+		}
+		if _, exists := t.tenants[hash]; exists {
+			return fmt.Errorf("tenant %s reuses an API key", tenant.Name)
+		}
+		t.tenants[hash] = tenant
+	}
+
+	return nil
+}
+
+func (t *Tenants) Tenant(apiKey string) (*entities.Tenant, bool) {
+	tenant, exists := t.tenants[sha256.Sum256([]byte(apiKey))]
+	return tenant, exists
+}
@@ -0,0 +1,142 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSReloader serves the certificate and client CA bundle from env, reloading
+// them whenever the files change so renewed certificates need no restart.
+type TLSReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	logger       *slog.Logger
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	fingerprint []byte
+}
+
+func NewTLSReloader(env *Env, logger *slog.Logger) (*TLSReloader, error) {
+	reloader := &TLSReloader{
+		certFile:     env.TLSCertFile,
+		keyFile:      env.TLSKeyFile,
+		clientCAFile: env.TLSClientCAFile,
+		logger:       logger,
+	}
+	if _, err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// Reload reads the files again and swaps them in if they changed. A broken
+// certificate or bundle keeps the previous one in use.
+func (r *TLSReloader) Reload() (bool, error) {
+	certPEM, err := os.ReadFile(r.certFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS key: %w", err)
+	}
+	var clientCAPEM []byte
+	if r.clientCAFile != "" {
+		clientCAPEM, err = os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read TLS client CA bundle: %w", err)
+		}
+	}
+
+	hash := sha256.New()
+	for _, data := range [][]byte{certPEM, keyPEM, clientCAPEM} {
+		sum := sha256.Sum256(data)
+		hash.Write(sum[:])
+	}
+	fingerprint := hash.Sum(nil)
+
+	r.mu.RLock()
+	unchanged := bytes.Equal(fingerprint, r.fingerprint)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCAPEM) {
+			return false, fmt.Errorf("no certificates found in TLS client CA bundle %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.fingerprint = fingerprint
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// Watch reloads every interval until ctx is done.
+func (r *TLSReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := r.Reload()
+		if err != nil {
+			r.logger.Error("Failed to reload TLS certificate, keeping the current one", "error", err)
+		} else if reloaded {
+			r.logger.Info("Reloaded TLS certificate")
+		}
+	}
+}
+
+// TLSConfig looks the certificate and client CAs up on every handshake.
+// Clients must present a certificate signed by the bundle when one is set.
+func (r *TLSReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.certificate},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = r.clientCAs
+			}
+
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,245 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// newTestCertificate issues a certificate signed by parent, or a self-signed
+// CA without one.
+func newTestCertificate(t *testing.T, commonName string, serial int64, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %s", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write %s: %s", path, err)
+	}
+}
+
+// serveTestTLS serves a 204 with env's TLS files and returns its address.
+func serveTestTLS(t *testing.T, env *Env) (string, *TLSReloader) {
+	t.Helper()
+	reloader, err := NewTLSReloader(env, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("Failed to create TLS reloader: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	server := NewServer(env, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLSConfig = reloader.TLSConfig()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeListener(ctx, server, listener, env, &atomic.Bool{})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf(expectedButGotMessage, "ServeListener()", nil, err)
+		}
+	})
+
+	return listener.Addr().String(), reloader
+}
+
+// getTestTLS returns the serial number of the server certificate.
+func getTestTLS(address string, ca *testCertificate, client *testCertificate) (int64, error) {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	config := &tls.Config{RootCAs: roots}
+	if client != nil {
+		certificate, _ := tls.X509KeyPair(client.certPEM, client.keyPEM)
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: config, ForceAttemptHTTP2: true}}
+	defer httpClient.CloseIdleConnections()
+	response, err := httpClient.Get("https://" + address)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	return response.TLS.PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestServeListenerTLS(t *testing.T) {
+	ca := newTestCertificate(t, "go-qris test CA", 1, nil)
+	server := newTestCertificate(t, "127.0.0.1", 2, ca)
+	partner := newTestCertificate(t, "partner", 3, ca)
+	strangerCA := newTestCertificate(t, "stranger CA", 4, nil)
+	stranger := newTestCertificate(t, "stranger", 5, strangerCA)
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "server.pem"), server.certPEM)
+	writeTestFile(t, filepath.Join(dir, "server.key"), server.keyPEM)
+	writeTestFile(t, filepath.Join(dir, "ca.pem"), ca.certPEM)
+
+	tests := []struct {
+		name      string
+		clientCA  bool
+		client    *testCertificate
+		wantError bool
+	}{
+		{name: "Success: TLS", clientCA: false},
+		{name: "Success: mTLS With Partner Certificate", clientCA: true, client: partner},
+		{name: "Error: mTLS Without Client Certificate", clientCA: true, wantError: true},
+		{name: "Error: mTLS With Unknown Client Certificate", clientCA: true, client: stranger, wantError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := DefaultEnv()
+			env.TLSCertFile = filepath.Join(dir, "server.pem")
+			env.TLSKeyFile = filepath.Join(dir, "server.key")
+			if test.clientCA {
+				env.TLSClientCAFile = filepath.Join(dir, "ca.pem")
+			}
+			address, _ := serveTestTLS(t, env)
+
+			serial, err := getTestTLS(address, ca, test.client)
+			if (err != nil) != test.wantError {
+				t.Fatalf(expectedButGotMessage, "error", test.wantError, err)
+			}
+			if err == nil && serial != 2 {
+				t.Errorf(expectedButGotMessage, "serial number", 2, serial)
+			}
+		})
+	}
+}
+
+func TestTLSReloaderReload(t *testing.T) {
+	ca := newTestCertificate(t, "go-qris test CA", 1, nil)
+	dir := t.TempDir()
+	env := DefaultEnv()
+	env.TLSCertFile = filepath.Join(dir, "server.pem")
+	env.TLSKeyFile = filepath.Join(dir, "server.key")
+
+	rotate := func(serial int64) {
+		certificate := newTestCertificate(t, "127.0.0.1", serial, ca)
+		writeTestFile(t, env.TLSCertFile, certificate.certPEM)
+		writeTestFile(t, env.TLSKeyFile, certificate.keyPEM)
+	}
+	rotate(2)
+	address, reloader := serveTestTLS(t, env)
+
+	if reloaded, err := reloader.Reload(); reloaded || err != nil {
+		t.Errorf(expectedButGotMessage, "Reload() of unchanged files", false, err)
+	}
+
+	rotate(3)
+	if reloaded, err := reloader.Reload(); !reloaded || err != nil {
+		t.Errorf(expectedButGotMessage, "Reload() of renewed files", true, err)
+	}
+	if serial, err := getTestTLS(address, ca, nil); serial != 3 {
+		t.Errorf(expectedButGotMessage, "serial number", 3, err)
+	}
+
+	writeTestFile(t, env.TLSCertFile, []byte("not a certificate"))
+	if _, err := reloader.Reload(); err == nil {
+		t.Errorf(expectedButGotMessage, "Reload() of a broken certificate", "error", nil)
+	}
+	if serial, err := getTestTLS(address, ca, nil); serial != 3 {
+		t.Errorf(expectedButGotMessage, "serial number after a failed reload", 3, err)
+	}
+}
+
+func TestTLSReloaderWatch(t *testing.T) {
+	ca := newTestCertificate(t, "go-qris test CA", 1, nil)
+	dir := t.TempDir()
+	env := DefaultEnv()
+	env.TLSCertFile = filepath.Join(dir, "server.pem")
+	env.TLSKeyFile = filepath.Join(dir, "server.key")
+
+	rotate := func(serial int64) {
+		certificate := newTestCertificate(t, "127.0.0.1", serial, ca)
+		writeTestFile(t, env.TLSCertFile, certificate.certPEM)
+		writeTestFile(t, env.TLSKeyFile, certificate.keyPEM)
+	}
+	rotate(2)
+	address, reloader := serveTestTLS(t, env)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx, 10*time.Millisecond)
+
+	rotate(3)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		serial, err := getTestTLS(address, ca, nil)
+		if serial == 3 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf(expectedButGotMessage, "serial number", 3, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
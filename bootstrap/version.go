@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version, Commit and BuildDate are set at link time, see the ldflags in
+// deployments/goreleaser.yml.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// BuildInfo is what GET /version serves and the version command prints.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// NewBuildInfo describes the running binary. Builds without ldflags, e.g. go
+// run or go install, fall back to the VCS stamp of the Go toolchain.
+func NewBuildInfo() *BuildInfo {
+	buildInfo := &BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && buildInfo.Commit == "":
+				buildInfo.Commit = setting.Value
+			case setting.Key == "vcs.time" && buildInfo.BuildDate == "":
+				buildInfo.BuildDate = setting.Value
+			}
+		}
+	}
+
+	return buildInfo
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/fyvri/go-qris/bootstrap"
+)
+
+func config(args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		log.Fatalf("Usage: go-qris config print [-config file] [-<setting> value ...]")
+	}
+
+	env, err := bootstrap.LoadEnv(args[1:])
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%s", err)
+	}
+	if err := env.Print(os.Stdout); err != nil {
+		log.Fatalf("Failed to print configuration: %s", err)
+	}
+}
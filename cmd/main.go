@@ -1,18 +1,32 @@
 package main
 
 import (
-	"github.com/fyvri/go-qris/api/routes"
-	"github.com/fyvri/go-qris/bootstrap"
-
-	"github.com/gin-gonic/gin"
+	"log"
+	"os"
 )
 
 func main() {
-	app := bootstrap.App()
-	env := app.Env
-
-	gin := gin.Default()
-	routes.Setup(env, gin)
+	command := "run"
+	args := []string{}
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+		args = os.Args[2:]
+	}
 
-	gin.Run(":" + env.Port)
+	switch command {
+	case "run":
+		run(args)
+	case "parse":
+		parse(args)
+	case "render":
+		render(args)
+	case "sticker":
+		sticker(args)
+	case "config":
+		config(args)
+	case "version":
+		version(args)
+	default:
+		log.Fatalf("Unknown command: %s", command)
+	}
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fyvri/go-qris/pkg/services"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+func parse(args []string) {
+	flags := flag.NewFlagSet("parse", flag.ExitOnError)
+	image := flags.String("image", "", "PNG, JPEG or GIF image to decode the QR string from")
+	flags.Parse(args)
+
+	var qrString string
+	switch {
+	case *image != "" && flags.NArg() == 0:
+		imageData, err := os.ReadFile(*image)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %s", *image, err)
+		}
+		qrString, err = utils.NewQRCode().ImageToString(imageData)
+		if err != nil {
+			log.Fatalf("Failed to decode QR code: %s", err)
+		}
+	case *image == "" && flags.NArg() == 1:
+		qrString = flags.Arg(0)
+	default:
+		log.Fatalf("Usage: go-qris parse [-image file] [qr_string]")
+	}
+
+	qris, err, errs := services.NewQRIS().Parse(qrString)
+	if err != nil {
+		if errs != nil {
+			log.Fatalf("Failed to parse QRIS: %s: %s", err, strings.Join(*errs, ", "))
+		}
+		log.Fatalf("Failed to parse QRIS: %s", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(qris); err != nil {
+		log.Fatalf("Failed to encode QRIS: %s", err)
+	}
+}
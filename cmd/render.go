@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/fyvri/go-qris/bootstrap"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+func render(args []string) {
+	// Only the QR code defaults are needed, the server and its API keys are
+	// not built so they can not stop an offline render.
+	qrCodeOptions := bootstrap.NewEnv().QRCodeOptions()
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	flags.StringVar(&qrCodeOptions.Format, "format", qrCodeOptions.Format, "output format: png, svg or pdf")
+	flags.IntVar(&qrCodeOptions.Size, "size", qrCodeOptions.Size, "output size in pixels (png) or points (svg, pdf)")
+	flags.IntVar(&qrCodeOptions.ModuleSize, "module-size", qrCodeOptions.ModuleSize, "size of a single module, overrides -size when set")
+	flags.IntVar(&qrCodeOptions.Margin, "margin", qrCodeOptions.Margin, "quiet zone width in modules")
+	flags.StringVar(&qrCodeOptions.ErrorCorrectionLevel, "ecc", qrCodeOptions.ErrorCorrectionLevel, "error correction level: L, M, Q or H")
+	flags.StringVar(&qrCodeOptions.ForegroundColor, "foreground", qrCodeOptions.ForegroundColor, "foreground hex color")
+	flags.StringVar(&qrCodeOptions.BackgroundColor, "background", qrCodeOptions.BackgroundColor, "background hex color")
+	logo := flags.String("logo", "", "PNG or JPEG logo placed in the centre, forces error correction level H")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		log.Fatalf("Usage: go-qris render [-format png|svg|pdf] [-size n] [-module-size n] [-margin n] [-ecc L|M|Q|H] [-foreground hex] [-background hex] [-logo file] [-output file] <qr_string|->")
+	}
+	if *logo != "" {
+		logoData, err := os.ReadFile(*logo)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %s", *logo, err)
+		}
+		qrCodeOptions.Logo = logoData
+	}
+
+	qrString := flags.Arg(0)
+	if qrString == "-" {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("Failed to read QR string from stdin: %s", err)
+		}
+		qrString = string(input)
+	}
+	qrString = utils.NewInput().Sanitize(qrString)
+
+	qrCode, err := utils.NewQRCode().StringToFormat(qrString, qrCodeOptions)
+	if err != nil {
+		log.Fatalf("Failed to render QR code: %s", err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(qrCode)
+		return
+	}
+	if err := os.WriteFile(*output, qrCode, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %s", *output, err)
+	}
+}
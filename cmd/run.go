@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fyvri/go-qris/api/handlers"
+	"github.com/fyvri/go-qris/api/routes"
+	"github.com/fyvri/go-qris/bootstrap"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+func run(args []string) {
+	app := bootstrap.App(args)
+	env := app.Env
+
+	// Access logs come from the request ID middleware, so only recovery is
+	// kept from gin's defaults and panics are logged like everything else.
+	gin := gin.New()
+	if err := gin.SetTrustedProxies(env.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %s", err)
+	}
+	gin.Use(ginRecovery())
+	// REST and gRPC share one controller, and so one parse cache.
+	qrisController := routes.NewQRISController(env, app.Profile, app.Metrics, app.Logger)
+	routes.Setup(&app, gin, qrisController)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	server := bootstrap.NewServer(env, gin)
+	if env.TLSCertFile != "" {
+		tlsReloader, err := bootstrap.NewTLSReloader(env, app.Logger)
+		if err != nil {
+			log.Fatalf("Failed to load TLS certificate: %s", err)
+		}
+		server.TLSConfig = tlsReloader.TLSConfig()
+		if env.TLSReloadInterval > 0 {
+			go tlsReloader.Watch(ctx, env.TLSReloadInterval)
+		}
+	}
+
+	if env.GRPCPort != "" {
+		grpcServer := routes.NewGRPCServer(&app, qrisController, server.TLSConfig)
+		listener, err := net.Listen("tcp", ":"+env.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %s", err)
+		}
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Failed to serve gRPC: %s", err)
+			}
+		}()
+		defer stopGRPC(grpcServer, env.ServerShutdownTimeout)
+		app.Logger.Info("Listening for gRPC", "port", env.GRPCPort)
+	}
+
+	app.Logger.Info("Listening", "port", env.Port, "tls", server.TLSConfig != nil, "mtls", env.TLSClientCAFile != "")
+	if err := bootstrap.Serve(ctx, server, env, app.Ready); err != nil {
+		log.Fatalf("Failed to serve: %s", err)
+	}
+	app.Logger.Info("Server stopped gracefully")
+}
+
+// stopGRPC lets in-flight calls finish like the HTTP shutdown does, and cuts
+// them off after timeout.
+func stopGRPC(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		server.Stop()
+	}
+}
+
+func ginRecovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		handlers.RequestLogger(c).Error("Request panicked", "panic", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fyvri/go-qris/pkg/services"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+func sticker(args []string) {
+	stickerOptions := utils.DefaultStickerOptions()
+	flags := flag.NewFlagSet("sticker", flag.ExitOnError)
+	flags.StringVar(&stickerOptions.Format, "format", stickerOptions.Format, "output format: png or pdf")
+	flags.IntVar(&stickerOptions.DPI, "dpi", stickerOptions.DPI, "print resolution in dots per inch (png)")
+	flags.StringVar(&stickerOptions.ErrorCorrectionLevel, "ecc", stickerOptions.ErrorCorrectionLevel, "error correction level: L, M, Q or H")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		log.Fatalf("Usage: go-qris sticker [-format png|pdf] [-dpi n] [-ecc L|M|Q|H] [-output file] <qr_string>")
+	}
+	stickerOptions.Format = strings.ToLower(stickerOptions.Format)
+
+	qrisService := services.NewQRIS()
+	qris, err, errs := qrisService.Parse(flags.Arg(0))
+	if err != nil {
+		if errs != nil {
+			log.Fatalf("Failed to parse QRIS: %s: %s", err, strings.Join(*errs, ", "))
+		}
+		log.Fatalf("Failed to parse QRIS: %s", err)
+	}
+
+	sticker, err := qrisService.Sticker(qris, stickerOptions)
+	if err != nil {
+		log.Fatalf("Failed to render sticker: %s", err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(sticker)
+		return
+	}
+	if err := os.WriteFile(*output, sticker, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %s", *output, err)
+	}
+}
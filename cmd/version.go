@@ -0,0 +1,17 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/fyvri/go-qris/bootstrap"
+)
+
+func version(args []string) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bootstrap.NewBuildInfo()); err != nil {
+		log.Fatalf("Failed to write build information: %s", err)
+	}
+}
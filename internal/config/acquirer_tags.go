@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	AcquirerDetailSiteTag       = "00"
 	AcquirerDetailMPANTag       = "01"
 	AcquirerDetailTerminalIDTag = "02"
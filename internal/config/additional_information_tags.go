@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	AdditionalInformationDetailBillNumberTag                    = "01"
 	AdditionalInformationDetailMobileNumberTag                  = "02"
 	AdditionalInformationDetailStoreLabelTag                    = "03"
@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	CategoryStaticContent  = "11"
 	CategoryDynamicContent = "12"
 )
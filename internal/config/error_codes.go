@@ -0,0 +1,20 @@
+package config
+
+// Error codes name failures in API responses and in the operation metrics of
+// both the API and pkg/services.
+const (
+	ErrorCodeInternal             = "internal_error"
+	ErrorCodeInvalidQRIS          = "invalid_qris"
+	ErrorCodeInvalidCRC           = "invalid_crc"
+	ErrorCodeInputTooLong         = "input_too_long"
+	ErrorCodeInvalidAmount        = "invalid_amount"
+	ErrorCodeInvalidPaymentFee    = "invalid_payment_fee"
+	ErrorCodeInvalidQRCodeOptions = "invalid_qr_code_options"
+	ErrorCodeQRCodeNotRenderable  = "qr_code_not_renderable"
+	ErrorCodeQRCodeNotReadable    = "qr_code_not_readable"
+	ErrorCodeStickerNotRenderable = "sticker_not_renderable"
+	ErrorCodeMerchantNotAllowed   = "merchant_not_allowed"
+	ErrorCodeQRStringTooLong      = "qr_string_too_long"
+	ErrorCodeCanceled             = "canceled"
+	ErrorCodeDeadlineExceeded     = "deadline_exceeded"
+)
@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	PaymentFeeCategoryFixedContent   = "02"
 	PaymentFeeCategoryPercentContent = "03"
 )
@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	VersionTag               = "00"
 	CategoryTag              = "01"
 	AcquirerTag              = "26"
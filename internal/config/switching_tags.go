@@ -1,6 +1,6 @@
 package config
 
-var (
+const (
 	SwitchingDetailSiteTag     = "00"
 	SwitchingDetailNMIDTag     = "02"
 	SwitchingDetailCategoryTag = "03"
@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fyvri/go-qris/internal/config"
+)
+
+// QRISPatch describes changes to a parsed QRIS. Nil fields are left as they
+// are and empty strings remove the field. Amounts are decimal strings such as
+// "1337.50".
+type QRISPatch struct {
+	PaymentAmount         string                      `json:"payment_amount"`
+	PaymentFee            *PaymentFeePatch            `json:"payment_fee"`
+	MerchantCity          *string                     `json:"merchant_city"`
+	MerchantPostalCode    *string                     `json:"merchant_postal_code"`
+	AdditionalInformation *AdditionalInformationPatch `json:"additional_information"`
+}
+
+type PaymentFeePatch struct {
+	Category string `json:"category"`
+	Value    string `json:"value"`
+}
+
+type AdditionalInformationPatch struct {
+	BillNumber                    *string `json:"bill_number"`
+	MobileNumber                  *string `json:"mobile_number"`
+	StoreLabel                    *string `json:"store_label"`
+	LoyaltyNumber                 *string `json:"loyalty_number"`
+	ReferenceLabel                *string `json:"reference_label"`
+	CustomerLabel                 *string `json:"customer_label"`
+	TerminalLabel                 *string `json:"terminal_label"`
+	PurposeOfTransaction          *string `json:"purpose_of_transaction"`
+	AdditionalConsumerDataRequest *string `json:"additional_consumer_data_request"`
+	MerchantTaxID                 *string `json:"merchant_tax_id"`
+	MerchantChannel               *string `json:"merchant_channel"`
+}
+
+// decimalPattern matches EMVCo amounts, e.g. "1337" or "1337.50".
+var decimalPattern = regexp.MustCompile(`^(0|[1-9][0-9]*)(\.[0-9]{1,2})?$`)
+
+// Sanitized returns a copy of the patch with every value passed through
+// sanitize and the fee category in upper case, the patch is left untouched.
+func (p *QRISPatch) Sanitized(sanitize func(value string) string) *QRISPatch {
+	sanitizePointer := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		sanitized := sanitize(*value)
+		return &sanitized
+	}
+
+	sanitized := &QRISPatch{
+		PaymentAmount:      sanitize(p.PaymentAmount),
+		MerchantCity:       sanitizePointer(p.MerchantCity),
+		MerchantPostalCode: sanitizePointer(p.MerchantPostalCode),
+	}
+	if p.PaymentFee != nil {
+		sanitized.PaymentFee = &PaymentFeePatch{
+			Category: strings.ToUpper(sanitize(p.PaymentFee.Category)),
+			Value:    sanitize(p.PaymentFee.Value),
+		}
+	}
+	if p.AdditionalInformation != nil {
+		additionalInformation := *p.AdditionalInformation
+		for _, field := range additionalInformation.fields() {
+			*field.value = sanitizePointer(*field.value)
+		}
+		sanitized.AdditionalInformation = &additionalInformation
+	}
+
+	return sanitized
+}
+
+// Validate checks that every field fits its tag. It returns the error code of
+// the first problem found, e.g. config.ErrorCodeInvalidAmount, with the
+// fields that are too long.
+func (p *QRISPatch) Validate() (string, error, *[]string) {
+	if !isDecimal(p.PaymentAmount, 13, 0) {
+		return config.ErrorCodeInvalidAmount, fmt.Errorf("payment amount must be a positive decimal of at most 13 characters"), nil
+	}
+
+	if p.PaymentFee != nil {
+		isValidFee := false
+		switch p.PaymentFee.Category {
+		case "":
+			isValidFee = true
+		case "FIXED":
+			isValidFee = isDecimal(p.PaymentFee.Value, 13, 0)
+		case "PERCENT":
+			isValidFee = isDecimal(p.PaymentFee.Value, 5, 100)
+		}
+		if !isValidFee {
+			return config.ErrorCodeInvalidPaymentFee, fmt.Errorf("payment fee must be a positive FIXED amount or a PERCENT of at most 100"), nil
+		}
+	}
+
+	errs := &[]string{}
+	if p.MerchantCity != nil && (*p.MerchantCity == "" || len(*p.MerchantCity) > 15) {
+		*errs = append(*errs, "merchant city must be between 1 and 15 characters")
+	}
+	if p.MerchantPostalCode != nil && len(*p.MerchantPostalCode) > 10 {
+		*errs = append(*errs, "merchant postal code exceeds 10 characters")
+	}
+	if p.AdditionalInformation != nil {
+		for _, field := range p.AdditionalInformation.fields() {
+			if *field.value != nil && len(**field.value) > field.maxLength {
+				*errs = append(*errs, fmt.Sprintf("%s exceeds %d characters", field.name, field.maxLength))
+			}
+		}
+	}
+
+	if len(*errs) > 0 {
+		return config.ErrorCodeInputTooLong, fmt.Errorf("input length exceeds the maximum permitted characters"), errs
+	}
+
+	return "", nil, nil
+}
+
+type additionalInformationPatchField struct {
+	name      string
+	value     **string
+	maxLength int
+}
+
+// fields lists the tag 62 fields with the longest value each may hold.
+func (p *AdditionalInformationPatch) fields() []additionalInformationPatchField {
+	return []additionalInformationPatchField{
+		{"bill number", &p.BillNumber, 25},
+		{"mobile number", &p.MobileNumber, 25},
+		{"store label", &p.StoreLabel, 25},
+		{"loyalty number", &p.LoyaltyNumber, 25},
+		{"reference label", &p.ReferenceLabel, 25},
+		{"customer label", &p.CustomerLabel, 25},
+		{"terminal label", &p.TerminalLabel, 25},
+		{"purpose of transaction", &p.PurposeOfTransaction, 25},
+		{"additional consumer data request", &p.AdditionalConsumerDataRequest, 3},
+		{"merchant tax ID", &p.MerchantTaxID, 25},
+		{"merchant channel", &p.MerchantChannel, 3},
+	}
+}
+
+// isDecimal reports whether value is a positive decimal of at most maxLength
+// characters and, when maxValue is set, not above it.
+func isDecimal(value string, maxLength int, maxValue float64) bool {
+	if len(value) > maxLength || !decimalPattern.MatchString(value) {
+		return false
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return false
+	}
+
+	return maxValue == 0 || number <= maxValue
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// TenantWildcard allows every NMID or endpoint.
+	TenantWildcard = "*"
+
+	// TenantPublishableEndpoint is the only endpoint a publishable key may
+	// call, the one the browser widget needs.
+	TenantPublishableEndpoint = "convert"
+)
+
+// Tenant is the owner of an API key. Only the listed merchant NMIDs can be
+// converted and only the listed endpoints called, e.g. "parse" or "convert".
+// Publishable keys are meant to be embedded in web pages: they may only call
+// TenantPublishableEndpoint and only from the AllowedOrigins.
+type Tenant struct {
+	Name             string         `json:"name"`
+	APIKeys          []string       `json:"api_keys"`
+	PublishableKeys  []string       `json:"publishable_keys"`
+	AllowedOrigins   []string       `json:"allowed_origins"`
+	AllowedNMIDs     []string       `json:"allowed_nmids"`
+	AllowedEndpoints []string       `json:"allowed_endpoints"`
+	QRCodeOptions    *QRCodeOptions `json:"qr_code"`
+
+	// Publishable marks the tenant a publishable key resolves to.
+	Publishable bool `json:"-"`
+}
+
+// QRCodeOptions are a tenant's QR code defaults, every unset field keeping the
+// server's. Margin is a pointer so that a margin of 0 can be asked for.
+type QRCodeOptions struct {
+	Format               string `json:"format"`
+	Size                 int    `json:"size"`
+	ModuleSize           int    `json:"module_size"`
+	Margin               *int   `json:"margin"`
+	ErrorCorrectionLevel string `json:"error_correction_level"`
+	ForegroundColor      string `json:"foreground_color"`
+	BackgroundColor      string `json:"background_color"`
+	Logo                 []byte `json:"logo,omitempty"`
+}
+
+func (t *Tenant) AllowsNMID(nmid string) bool {
+	return nmid != "" && (slices.Contains(t.AllowedNMIDs, TenantWildcard) || slices.Contains(t.AllowedNMIDs, nmid))
+}
+
+func (t *Tenant) AllowsEndpoint(endpoint string) bool {
+	return slices.Contains(t.AllowedEndpoints, TenantWildcard) || slices.Contains(t.AllowedEndpoints, endpoint)
+}
+
+// AllowsOrigin reports whether a request sent from origin may use the key.
+// Secret keys work from anywhere, publishable ones only from AllowedOrigins.
+func (t *Tenant) AllowsOrigin(origin string) bool {
+	if !t.Publishable {
+		return true
+	}
+
+	origin = strings.TrimSuffix(origin, "/")
+	return origin != "" && slices.ContainsFunc(t.AllowedOrigins, func(allowed string) bool {
+		return strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+	})
+}
@@ -1,7 +1,13 @@
 package controllers
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
 	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
 )
 
 var (
@@ -15,13 +21,22 @@ var (
 
 	testQRISString         = "QR String"
 	testQRISModifiedString = "QRIS Modified String"
-	testQRCodeSize         = 125
+	testQRCodeOptions      = &utils.QRCodeOptions{
+		Format:               utils.QRCodeFormatPNG,
+		Size:                 512,
+		Margin:               4,
+		ErrorCorrectionLevel: "L",
+		ForegroundColor:      "#000000",
+		BackgroundColor:      "#FFFFFF",
+	}
+	testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
 )
 
 type mockQRISUsecase struct {
 	ParseFunc    func(qrString string) (*entities.QRIS, error, *[]string)
 	IsValidFunc  func(qris *entities.QRIS) bool
 	ModifyFunc   func(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS
+	PatchFunc    func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS
 	ToStringFunc func(qris *entities.QRIS) string
 }
 
@@ -46,6 +61,13 @@ func (m *mockQRISUsecase) Modify(qris *entities.QRIS, merchantCityValue string,
 	return nil
 }
 
+func (m *mockQRISUsecase) Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(qris, patch)
+	}
+	return nil
+}
+
 func (m *mockQRISUsecase) ToString(qris *entities.QRIS) string {
 	if m.ToStringFunc != nil {
 		return m.ToStringFunc(qris)
@@ -54,7 +76,14 @@ func (m *mockQRISUsecase) ToString(qris *entities.QRIS) string {
 }
 
 type mockQRCodeUtil struct {
-	StringToImageBase64Func func(qrString string, qrCodeSize int) (string, error)
+	StringToImageBase64Func  func(qrString string, qrCodeSize int) (string, error)
+	StringToPNGFunc          func(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	StringToSVGFunc          func(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	StringToPDFFunc          func(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	StringToFormatFunc       func(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	StringToFormatBase64Func func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error)
+	ValidateOptionsFunc      func(qrCodeOptions *utils.QRCodeOptions) error
+	ImageToStringFunc        func(imageData []byte) (string, error)
 }
 
 func (m *mockQRCodeUtil) StringToImageBase64(qrString string, qrCodeSize int) (string, error) {
@@ -64,6 +93,96 @@ func (m *mockQRCodeUtil) StringToImageBase64(qrString string, qrCodeSize int) (s
 	return "", nil
 }
 
+func (m *mockQRCodeUtil) StringToPNG(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	if m.StringToPNGFunc != nil {
+		return m.StringToPNGFunc(qrString, qrCodeOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockQRCodeUtil) StringToSVG(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	if m.StringToSVGFunc != nil {
+		return m.StringToSVGFunc(qrString, qrCodeOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockQRCodeUtil) StringToPDF(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	if m.StringToPDFFunc != nil {
+		return m.StringToPDFFunc(qrString, qrCodeOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockQRCodeUtil) StringToFormat(qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	if m.StringToFormatFunc != nil {
+		return m.StringToFormatFunc(qrString, qrCodeOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockQRCodeUtil) StringToFormatBase64(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+	if m.StringToFormatBase64Func != nil {
+		return m.StringToFormatBase64Func(qrString, qrCodeOptions)
+	}
+	return "", nil
+}
+
+func (m *mockQRCodeUtil) ValidateOptions(qrCodeOptions *utils.QRCodeOptions) error {
+	if m.ValidateOptionsFunc != nil {
+		return m.ValidateOptionsFunc(qrCodeOptions)
+	}
+	return nil
+}
+
+func (m *mockQRCodeUtil) ImageToString(imageData []byte) (string, error) {
+	if m.ImageToStringFunc != nil {
+		return m.ImageToStringFunc(imageData)
+	}
+	return "", nil
+}
+
+// The Context methods share the mocks of the methods they extend.
+func (m *mockQRCodeUtil) ImageToStringContext(ctx context.Context, imageData []byte) (string, error) {
+	return m.ImageToString(imageData)
+}
+
+func (m *mockQRCodeUtil) StringToFormatContext(ctx context.Context, qrString string, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	return m.StringToFormat(qrString, qrCodeOptions)
+}
+
+func (m *mockQRCodeUtil) StringToFormatBase64Context(ctx context.Context, qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+	return m.StringToFormatBase64(qrString, qrCodeOptions)
+}
+
+type mockStickerUtil struct {
+	RenderFunc       func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error)
+	RenderBase64Func func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error)
+}
+
+func (m *mockStickerUtil) Render(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	if m.RenderFunc != nil {
+		return m.RenderFunc(stickerContent, stickerOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockStickerUtil) RenderBase64(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+	if m.RenderBase64Func != nil {
+		return m.RenderBase64Func(stickerContent, stickerOptions)
+	}
+	return "", nil
+}
+
+// The Context methods share the mocks of the methods they extend.
+func (m *mockStickerUtil) RenderContext(ctx context.Context, stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	return m.Render(stickerContent, stickerOptions)
+}
+
+func (m *mockStickerUtil) RenderBase64Context(ctx context.Context, stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+	return m.RenderBase64(stickerContent, stickerOptions)
+}
+
 type mockInputUtil struct {
 	SanitizeFunc func(input string) string
 }
@@ -74,3 +193,42 @@ func (m *mockInputUtil) Sanitize(input string) string {
 	}
 	return ""
 }
+
+type mockMetrics struct {
+	ObserveRequestFunc   func(method string, route string, status int, duration time.Duration)
+	ObserveOperationFunc func(operation string, code string, issues []string, duration time.Duration)
+	ObserveRenderFunc    func(kind string, format string, qrString string, duration time.Duration)
+	ObserveCacheFunc     func(cache string, hit bool)
+	WriteTextFunc        func(w io.Writer) error
+}
+
+func (m *mockMetrics) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	if m.ObserveRequestFunc != nil {
+		m.ObserveRequestFunc(method, route, status, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveOperation(operation string, code string, issues []string, duration time.Duration) {
+	if m.ObserveOperationFunc != nil {
+		m.ObserveOperationFunc(operation, code, issues, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveRender(kind string, format string, qrString string, duration time.Duration) {
+	if m.ObserveRenderFunc != nil {
+		m.ObserveRenderFunc(kind, format, qrString, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveCache(cache string, hit bool) {
+	if m.ObserveCacheFunc != nil {
+		m.ObserveCacheFunc(cache, hit)
+	}
+}
+
+func (m *mockMetrics) WriteText(w io.Writer) error {
+	if m.WriteTextFunc != nil {
+		return m.WriteTextFunc(w)
+	}
+	return nil
+}
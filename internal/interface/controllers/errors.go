@@ -0,0 +1,62 @@
+package controllers
+
+import "github.com/fyvri/go-qris/internal/config"
+
+type ErrorKind int
+
+const (
+	// ErrorKindInternal is a fault on our side, e.g. an untyped error.
+	ErrorKindInternal ErrorKind = iota
+	// ErrorKindMalformed is input that can not be read at all.
+	ErrorKindMalformed
+	// ErrorKindInvalid is readable input that fails validation.
+	ErrorKindInvalid
+	// ErrorKindForbidden is input the caller is not allowed to use.
+	ErrorKindForbidden
+	// ErrorKindTooLarge is input above a configured size limit.
+	ErrorKindTooLarge
+	// ErrorKindCanceled is a request whose context was canceled.
+	ErrorKindCanceled
+	// ErrorKindTimeout is a request whose context deadline passed.
+	ErrorKindTimeout
+)
+
+// The error codes live in config, so pkg/services reports the same ones.
+const (
+	ErrorCodeInternal             = config.ErrorCodeInternal
+	ErrorCodeInvalidQRIS          = config.ErrorCodeInvalidQRIS
+	ErrorCodeInvalidCRC           = config.ErrorCodeInvalidCRC
+	ErrorCodeInputTooLong         = config.ErrorCodeInputTooLong
+	ErrorCodeInvalidAmount        = config.ErrorCodeInvalidAmount
+	ErrorCodeInvalidPaymentFee    = config.ErrorCodeInvalidPaymentFee
+	ErrorCodeInvalidQRCodeOptions = config.ErrorCodeInvalidQRCodeOptions
+	ErrorCodeQRCodeNotRenderable  = config.ErrorCodeQRCodeNotRenderable
+	ErrorCodeQRCodeNotReadable    = config.ErrorCodeQRCodeNotReadable
+	ErrorCodeStickerNotRenderable = config.ErrorCodeStickerNotRenderable
+	ErrorCodeMerchantNotAllowed   = config.ErrorCodeMerchantNotAllowed
+	ErrorCodeQRStringTooLong      = config.ErrorCodeQRStringTooLong
+	ErrorCodeCanceled             = config.ErrorCodeCanceled
+	ErrorCodeDeadlineExceeded     = config.ErrorCodeDeadlineExceeded
+)
+
+type Error struct {
+	Kind ErrorKind
+	Code string
+	Err  error
+}
+
+func NewError(kind ErrorKind, code string, err error) *Error {
+	return &Error{
+		Kind: kind,
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
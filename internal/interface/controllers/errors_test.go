@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+func TestError(t *testing.T) {
+	cause := fmt.Errorf(testErrMessageInvalidFormatCode)
+	err := error(NewError(ErrorKindMalformed, ErrorCodeInvalidQRIS, cause))
+
+	if err.Error() != testErrMessageInvalidFormatCode {
+		t.Errorf(expectedButGotMessage, "Error()", testErrMessageInvalidFormatCode, err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf(expectedButGotMessage, "errors.Is()", true, false)
+	}
+}
+
+func TestQRISErrorKinds(t *testing.T) {
+	identity := &mockInputUtil{
+		SanitizeFunc: func(input string) string {
+			return input
+		},
+	}
+	parsed := &mockQRISUsecase{
+		ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+			return &entities.QRIS{}, nil, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		call     func(c *QRIS) error
+		fields   QRIS
+		wantKind ErrorKind
+		wantCode string
+	}{
+		{
+			name: "Malformed: Parse",
+			call: func(c *QRIS) error {
+				_, err, _ := c.Parse(context.Background(), testQRISString)
+				return err
+			},
+			fields: QRIS{
+				inputUtil: identity,
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						return nil, fmt.Errorf(testErrMessageInvalidFormatCode), nil
+					},
+				},
+			},
+			wantKind: ErrorKindMalformed,
+			wantCode: ErrorCodeInvalidQRIS,
+		},
+		{
+			name: "TooLarge: Parse",
+			call: func(c *QRIS) error {
+				_, err, _ := c.Parse(context.Background(), testQRISString+testQRISString)
+				return err
+			},
+			fields: QRIS{
+				inputUtil:   identity,
+				qrisUsecase: parsed,
+				maxQRLength: len(testQRISString),
+			},
+			wantKind: ErrorKindTooLarge,
+			wantCode: ErrorCodeQRStringTooLong,
+		},
+		{
+			name: "Invalid: ParseImage",
+			call: func(c *QRIS) error {
+				_, err, _ := c.ParseImage(context.Background(), []byte("image"))
+				return err
+			},
+			fields: QRIS{
+				qrCodeUtil: &mockQRCodeUtil{
+					ImageToStringFunc: func(imageData []byte) (string, error) {
+						return "", fmt.Errorf("can not decode QR code in image")
+					},
+				},
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeQRCodeNotReadable,
+		},
+		{
+			name: "Invalid: Convert Input Too Long",
+			call: func(c *QRIS) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, strings.Repeat("a", 16), "", 1337, "", 0, "", nil)
+				return err
+			},
+			fields: QRIS{
+				inputUtil: identity,
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeInputTooLong,
+		},
+		{
+			name: "Invalid: Convert QR Code Options",
+			call: func(c *QRIS) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			fields: QRIS{
+				inputUtil: identity,
+				qrCodeUtil: &mockQRCodeUtil{
+					ValidateOptionsFunc: func(qrCodeOptions *utils.QRCodeOptions) error {
+						return fmt.Errorf("unsupported QR code format gif")
+					},
+				},
+				qrCodeOptions: testQRCodeOptions,
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeInvalidQRCodeOptions,
+		},
+		{
+			name: "Invalid: Convert QR Code Not Renderable",
+			call: func(c *QRIS) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			fields: QRIS{
+				inputUtil: identity,
+				qrCodeUtil: &mockQRCodeUtil{
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+						return "", fmt.Errorf("QR code is too small for a logo")
+					},
+				},
+				qrisUsecase:   parsed,
+				qrCodeOptions: testQRCodeOptions,
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeQRCodeNotRenderable,
+		},
+		{
+			name: "Invalid: IsValid",
+			call: func(c *QRIS) error {
+				err, _ := c.IsValid(context.Background(), testQRISString)
+				return err
+			},
+			fields: QRIS{
+				inputUtil:   identity,
+				qrisUsecase: parsed,
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeInvalidCRC,
+		},
+		{
+			name: "Invalid: Sticker",
+			call: func(c *QRIS) error {
+				_, err, _ := c.Sticker(context.Background(), testQRISString, nil)
+				return err
+			},
+			fields: QRIS{
+				inputUtil:   identity,
+				qrisUsecase: parsed,
+				stickerUtil: &mockStickerUtil{
+					RenderBase64Func: func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+						return "", fmt.Errorf("unsupported sticker format gif")
+					},
+				},
+			},
+			wantKind: ErrorKindInvalid,
+			wantCode: ErrorCodeStickerNotRenderable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &QRIS{
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				stickerUtil:   test.fields.stickerUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
+				maxQRLength:   test.fields.maxQRLength,
+			}
+
+			var got *Error
+			if err := test.call(c); !errors.As(err, &got) {
+				t.Fatalf(expectedErrorButGotMessage, "*Error", test.wantCode, err)
+			}
+			if got.Kind != test.wantKind {
+				t.Errorf(expectedButGotMessage, "Kind", test.wantKind, got.Kind)
+			}
+			if got.Code != test.wantCode {
+				t.Errorf(expectedButGotMessage, "Code", test.wantCode, got.Code)
+			}
+		})
+	}
+}
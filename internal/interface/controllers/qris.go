@@ -1,8 +1,12 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
 	"github.com/fyvri/go-qris/internal/usecases"
@@ -10,34 +14,198 @@ import (
 )
 
 type QRIS struct {
-	inputUtil   utils.InputInterface
-	qrCodeUtil  utils.QRCodeInterface
-	qrisUsecase usecases.QRISInterface
-	qrCodeSize  int
+	inputUtil     utils.InputInterface
+	qrCodeUtil    utils.QRCodeInterface
+	stickerUtil   utils.StickerInterface
+	qrisUsecase   usecases.QRISInterface
+	qrCodeOptions *utils.QRCodeOptions
+	maxQRLength   int
+	metrics       utils.MetricsInterface
+	logger        *slog.Logger
+	tenant        *entities.Tenant
 }
 
+type tenantContextKey struct{}
+
 type QRISInterface interface {
-	Parse(qrisString string) (*entities.QRIS, error, *[]string)
-	Convert(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) (string, string, error, *[]string)
-	IsValid(qrisString string) (error, *[]string)
+	Parse(ctx context.Context, qrisString string) (*entities.QRIS, error, *[]string)
+	ParseImage(ctx context.Context, imageData []byte) (*entities.QRIS, error, *[]string)
+	Convert(ctx context.Context, qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string)
+	Patch(ctx context.Context, qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string)
+	Generate(ctx context.Context, qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string)
+	IsValid(ctx context.Context, qrisString string) (error, *[]string)
+	VerifyCRC(ctx context.Context, qris *entities.QRIS) (bool, error)
+	Sticker(ctx context.Context, qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string)
+	WithLogger(logger *slog.Logger) QRISInterface
+	WithTenant(tenant *entities.Tenant) QRISInterface
 }
 
-func NewQRIS(inputUtil utils.InputInterface, qrCodeUtil utils.QRCodeInterface, qrisUsecase usecases.QRISInterface, qrCodeSize int) QRISInterface {
+func NewQRIS(inputUtil utils.InputInterface, qrCodeUtil utils.QRCodeInterface, stickerUtil utils.StickerInterface, qrisUsecase usecases.QRISInterface, qrCodeOptions *utils.QRCodeOptions, maxQRLength int, metrics utils.MetricsInterface, logger *slog.Logger) QRISInterface {
 	return &QRIS{
-		inputUtil:   inputUtil,
-		qrisUsecase: qrisUsecase,
-		qrCodeUtil:  qrCodeUtil,
-		qrCodeSize:  qrCodeSize,
+		inputUtil:     inputUtil,
+		qrisUsecase:   qrisUsecase,
+		qrCodeUtil:    qrCodeUtil,
+		stickerUtil:   stickerUtil,
+		qrCodeOptions: qrCodeOptions,
+		maxQRLength:   maxQRLength,
+		metrics:       metrics,
+		logger:        logger,
+	}
+}
+
+// WithLogger returns a copy of the controller that logs to logger, e.g. one
+// carrying the request ID.
+func (c *QRIS) WithLogger(logger *slog.Logger) QRISInterface {
+	controller := *c
+	controller.logger = logger
+
+	return &controller
+}
+
+// WithTenant returns a copy of the controller that renders with the tenant's
+// QR code defaults and only converts the tenant's merchants.
+func (c *QRIS) WithTenant(tenant *entities.Tenant) QRISInterface {
+	controller := *c
+	controller.tenant = tenant
+	controller.qrCodeOptions = utils.MergeQRCodeOptions(c.qrCodeOptions, TenantQRCodeOptions(tenant.QRCodeOptions))
+
+	return &controller
+}
+
+// TenantQRCodeOptions turns a tenant's QR code defaults into overrides for
+// utils.MergeQRCodeOptions, nil staying nil.
+func TenantQRCodeOptions(options *entities.QRCodeOptions) *utils.QRCodeOptions {
+	if options == nil {
+		return nil
+	}
+
+	qrCodeOptions := &utils.QRCodeOptions{
+		Format:               options.Format,
+		Size:                 options.Size,
+		ModuleSize:           options.ModuleSize,
+		ErrorCorrectionLevel: options.ErrorCorrectionLevel,
+		ForegroundColor:      options.ForegroundColor,
+		BackgroundColor:      options.BackgroundColor,
+		Logo:                 options.Logo,
+	}
+	if options.Margin != nil {
+		qrCodeOptions.Margin = *options.Margin
+		qrCodeOptions.MarginSet = true
+	}
+
+	return qrCodeOptions
+}
+
+// ContextWithTenant returns a copy of ctx carrying the authenticated tenant.
+func ContextWithTenant(ctx context.Context, tenant *entities.Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant stored with ContextWithTenant, if any.
+func TenantFromContext(ctx context.Context) (*entities.Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(*entities.Tenant)
+	return tenant, ok
+}
+
+func (c *QRIS) Parse(ctx context.Context, qrisString string) (*entities.QRIS, error, *[]string) {
+	start := time.Now()
+	qris, err, errs := c.parse(ctx, qrisString)
+	c.observe(ctx, "parse", start, err, errs)
+
+	return qris, err, errs
+}
+
+func (c *QRIS) ParseImage(ctx context.Context, imageData []byte) (*entities.QRIS, error, *[]string) {
+	start := time.Now()
+	qris, err, errs := c.parseImage(ctx, imageData)
+	c.observe(ctx, "parse_image", start, err, errs)
+
+	return qris, err, errs
+}
+
+func (c *QRIS) Convert(ctx context.Context, qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
+	start := time.Now()
+	qrString, qrCode, err, errs := c.convert(ctx, qrisString, merchantCityValue, merchantPostalCodeValue, paymentAmountValue, paymentFeeCategoryValue, paymentFeeValue, terminalLabelValue, qrCodeOptions)
+	c.observe(ctx, "convert", start, err, errs)
+
+	return qrString, qrCode, err, errs
+}
+
+func (c *QRIS) Patch(ctx context.Context, qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+	start := time.Now()
+	qrString, qris, err, errs := c.patch(ctx, qrisString, patch)
+	c.observe(ctx, "patch", start, err, errs)
+
+	return qrString, qris, err, errs
+}
+
+func (c *QRIS) Generate(ctx context.Context, qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+	start := time.Now()
+	qrCode, err, errs := c.generate(ctx, qrisString, qrCodeOptions)
+	c.observe(ctx, "generate", start, err, errs)
+
+	return qrCode, err, errs
+}
+
+func (c *QRIS) IsValid(ctx context.Context, qrisString string) (error, *[]string) {
+	start := time.Now()
+	err, errs := c.isValid(ctx, qrisString)
+	c.observe(ctx, "is_valid", start, err, errs)
+
+	return err, errs
+}
+
+// VerifyCRC reports whether the CRC of an already parsed qris matches its
+// content, so callers holding the entity do not parse the string again. The
+// error is only set once ctx is done.
+func (c *QRIS) VerifyCRC(ctx context.Context, qris *entities.QRIS) (bool, error) {
+	if err := contextError(ctx); err != nil {
+		return false, err
 	}
+
+	return c.qrisUsecase.IsValid(qris), nil
+}
+
+func (c *QRIS) Sticker(ctx context.Context, qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string) {
+	start := time.Now()
+	sticker, err, errs := c.sticker(ctx, qrisString, stickerOptions)
+	c.observe(ctx, "sticker", start, err, errs)
+
+	return sticker, err, errs
 }
 
-func (c *QRIS) Parse(qrisString string) (*entities.QRIS, error, *[]string) {
+func (c *QRIS) parse(ctx context.Context, qrisString string) (*entities.QRIS, error, *[]string) {
+	if err := contextError(ctx); err != nil {
+		return nil, err, nil
+	}
+	if c.maxQRLength > 0 && len(qrisString) > c.maxQRLength {
+		return nil, NewError(ErrorKindTooLarge, ErrorCodeQRStringTooLong, fmt.Errorf("QR string exceeds %d characters", c.maxQRLength)), nil
+	}
 	qrisString = c.inputUtil.Sanitize(qrisString)
+	qris, err, errs := c.qrisUsecase.Parse(qrisString)
+	if err != nil {
+		return nil, NewError(ErrorKindMalformed, ErrorCodeInvalidQRIS, err), errs
+	}
+
+	return qris, nil, nil
+}
+
+func (c *QRIS) parseImage(ctx context.Context, imageData []byte) (*entities.QRIS, error, *[]string) {
+	if err := contextError(ctx); err != nil {
+		return nil, err, nil
+	}
+	qrisString, err := c.qrCodeUtil.ImageToStringContext(ctx, imageData)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return nil, ctxErr, nil
+		}
+		return nil, NewError(ErrorKindInvalid, ErrorCodeQRCodeNotReadable, err), nil
+	}
 
-	return c.qrisUsecase.Parse(qrisString)
+	return c.parse(ctx, qrisString)
 }
 
-func (c *QRIS) Convert(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) (string, string, error, *[]string) {
+func (c *QRIS) convert(ctx context.Context, qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string, qrCodeOptions *utils.QRCodeOptions) (string, string, error, *[]string) {
 	errs := &[]string{}
 	merchantCityValue = c.inputUtil.Sanitize(merchantCityValue)
 	if len(merchantCityValue) > 15 {
@@ -52,38 +220,213 @@ func (c *QRIS) Convert(qrisString string, merchantCityValue string, merchantPost
 		*errs = append(*errs, "terminal label exceeds 99 characters")
 	}
 	if len(*errs) > 0 {
-		return "", "", fmt.Errorf("input length exceeds the maximum permitted characters"), errs
+		return "", "", NewError(ErrorKindInvalid, ErrorCodeInputTooLong, fmt.Errorf("input length exceeds the maximum permitted characters")), errs
 	}
 
-	qrisString = c.inputUtil.Sanitize(qrisString)
-	qris, err, errs := c.qrisUsecase.Parse(qrisString)
+	qrCodeOptions = utils.MergeQRCodeOptions(c.qrCodeOptions, qrCodeOptions)
+	qrCodeOptions.Format = strings.ToLower(c.inputUtil.Sanitize(qrCodeOptions.Format))
+	if err := c.qrCodeUtil.ValidateOptions(qrCodeOptions); err != nil {
+		return "", "", NewError(ErrorKindInvalid, ErrorCodeInvalidQRCodeOptions, err), nil
+	}
+
+	qris, err, errs := c.parse(ctx, qrisString)
 	if err != nil {
 		return "", "", err, errs
 	}
+	if err := c.authorizeMerchant(qris); err != nil {
+		return "", "", err, nil
+	}
 
 	paymentFeeCategoryValue = strings.ToUpper(c.inputUtil.Sanitize(paymentFeeCategoryValue))
 	qris = c.qrisUsecase.Modify(qris, merchantCityValue, merchantPostalCodeValue, paymentAmountValue, paymentFeeCategoryValue, paymentFeeValue, terminalLabelValue)
 	qrisString = c.qrisUsecase.ToString(qris)
+	if err := contextError(ctx); err != nil {
+		return "", "", err, nil
+	}
 
-	qrCode, err := c.qrCodeUtil.StringToImageBase64(qrisString, c.qrCodeSize)
+	start := time.Now()
+	qrCode, err := c.qrCodeUtil.StringToFormatBase64Context(ctx, qrisString, qrCodeOptions)
+	c.observeRender("qr_code", qrCodeOptions.Format, qrisString, start)
 	if err != nil {
-		return qrisString, "", err, nil
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return "", "", ctxErr, nil
+		}
+		return qrisString, "", NewError(ErrorKindInvalid, ErrorCodeQRCodeNotRenderable, err), nil
 	}
 
 	return qrisString, qrCode, nil, nil
 }
 
-func (c *QRIS) IsValid(qrisString string) (error, *[]string) {
-	qrisString = c.inputUtil.Sanitize(qrisString)
-	qris, err, errs := c.qrisUsecase.Parse(qrisString)
+func (c *QRIS) patch(ctx context.Context, qrisString string, patch *entities.QRISPatch) (string, *entities.QRIS, error, *[]string) {
+	patch, err, errs := c.sanitizePatch(patch)
+	if err != nil {
+		return "", nil, err, errs
+	}
+
+	qris, err, errs := c.parse(ctx, qrisString)
+	if err != nil {
+		return "", nil, err, errs
+	}
+	if err := c.authorizeMerchant(qris); err != nil {
+		return "", nil, err, nil
+	}
+
+	qris = c.qrisUsecase.Patch(qris, patch)
+	if len(qris.AdditionalInformation.Content) > 99 {
+		return "", nil, NewError(ErrorKindInvalid, ErrorCodeInputTooLong, fmt.Errorf("input length exceeds the maximum permitted characters")), &[]string{"additional information exceeds 99 characters"}
+	}
+
+	return c.qrisUsecase.ToString(qris), qris, nil, nil
+}
+
+func (c *QRIS) generate(ctx context.Context, qrisString string, qrCodeOptions *utils.QRCodeOptions) (string, error, *[]string) {
+	qris, err, errs := c.parse(ctx, qrisString)
+	if err != nil {
+		return "", err, errs
+	}
+	if !c.qrisUsecase.IsValid(qris) {
+		return "", NewError(ErrorKindInvalid, ErrorCodeInvalidCRC, fmt.Errorf("invalid CRC16-CCITT code")), nil
+	}
+
+	qrCodeOptions = utils.MergeQRCodeOptions(c.qrCodeOptions, qrCodeOptions)
+	qrCodeOptions.Format = strings.ToLower(c.inputUtil.Sanitize(qrCodeOptions.Format))
+	if err := c.qrCodeUtil.ValidateOptions(qrCodeOptions); err != nil {
+		return "", NewError(ErrorKindInvalid, ErrorCodeInvalidQRCodeOptions, err), nil
+	}
+
+	qrisString = c.qrisUsecase.ToString(qris)
+	if err := contextError(ctx); err != nil {
+		return "", err, nil
+	}
+	start := time.Now()
+	qrCode, err := c.qrCodeUtil.StringToFormatBase64Context(ctx, qrisString, qrCodeOptions)
+	c.observeRender("qr_code", qrCodeOptions.Format, qrisString, start)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return "", ctxErr, nil
+		}
+		return "", NewError(ErrorKindInvalid, ErrorCodeQRCodeNotRenderable, err), nil
+	}
+
+	return qrCode, nil, nil
+}
+
+func (c *QRIS) isValid(ctx context.Context, qrisString string) (error, *[]string) {
+	qris, err, errs := c.parse(ctx, qrisString)
 	if err != nil {
 		return err, errs
 	}
 
 	isValid := c.qrisUsecase.IsValid(qris)
 	if !isValid {
-		return fmt.Errorf("invalid CRC16-CCITT code"), nil
+		return NewError(ErrorKindInvalid, ErrorCodeInvalidCRC, fmt.Errorf("invalid CRC16-CCITT code")), nil
 	}
 
 	return nil, nil
 }
+
+func (c *QRIS) sticker(ctx context.Context, qrisString string, stickerOptions *utils.StickerOptions) (string, error, *[]string) {
+	qris, err, errs := c.parse(ctx, qrisString)
+	if err != nil {
+		return "", err, errs
+	}
+
+	options := utils.MergeStickerOptions(utils.DefaultStickerOptions(), stickerOptions)
+	if stickerOptions != nil && stickerOptions.Format != "" {
+		options.Format = strings.ToLower(c.inputUtil.Sanitize(stickerOptions.Format))
+	}
+
+	qrisString = c.qrisUsecase.ToString(qris)
+	if err := contextError(ctx); err != nil {
+		return "", err, nil
+	}
+	start := time.Now()
+	sticker, err := c.stickerUtil.RenderBase64Context(ctx, &utils.StickerContent{
+		QRString:     qrisString,
+		MerchantName: qris.MerchantName.Content,
+		NMID:         qris.Switching.Detail.NMID.Content,
+		TerminalID:   qris.Acquirer.Detail.TerminalID.Content,
+	}, options)
+	c.observeRender("sticker", options.Format, qrisString, start)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return "", ctxErr, nil
+		}
+		return "", NewError(ErrorKindInvalid, ErrorCodeStickerNotRenderable, err), nil
+	}
+
+	return sticker, nil, nil
+}
+
+// sanitizePatch returns a sanitized copy of the patch once every field fits
+// its tag, the caller's patch is left untouched.
+func (c *QRIS) sanitizePatch(patch *entities.QRISPatch) (*entities.QRISPatch, error, *[]string) {
+	sanitized := patch.Sanitized(c.inputUtil.Sanitize)
+	if code, err, errs := sanitized.Validate(); err != nil {
+		return nil, NewError(ErrorKindInvalid, code, err), errs
+	}
+
+	return sanitized, nil, nil
+}
+
+// authorizeMerchant refuses a QRIS whose switching NMID is not owned by the
+// tenant, if any.
+func (c *QRIS) authorizeMerchant(qris *entities.QRIS) error {
+	if c.tenant == nil || c.tenant.AllowsNMID(qris.Switching.Detail.NMID.Content) {
+		return nil
+	}
+
+	return NewError(ErrorKindForbidden, ErrorCodeMerchantNotAllowed, fmt.Errorf("merchant is not allowed for this API key"))
+}
+
+// observe records an operation in the metrics and the log, if any. Untyped
+// errors are counted as internal ones, like the handlers report them.
+func (c *QRIS) observe(ctx context.Context, operation string, start time.Time, err error, errs *[]string) {
+	duration := time.Since(start)
+	code := ""
+	if err != nil {
+		code = ErrorCodeInternal
+		var controllerErr *Error
+		if errors.As(err, &controllerErr) {
+			code = controllerErr.Code
+		}
+	}
+	var issues []string
+	if errs != nil {
+		issues = *errs
+	}
+
+	if c.metrics != nil {
+		c.metrics.ObserveOperation(operation, code, issues, duration)
+	}
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		level := slog.LevelWarn
+		if code == ErrorCodeInternal {
+			level = slog.LevelError
+		}
+		c.logger.Log(ctx, level, "QRIS operation failed", "operation", operation, "code", code, "error", err, "issues", issues, "duration", duration)
+		return
+	}
+	c.logger.DebugContext(ctx, "QRIS operation succeeded", "operation", operation, "duration", duration)
+}
+
+// contextError reports a canceled or expired context as a typed error.
+func contextError(ctx context.Context) error {
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewError(ErrorKindTimeout, ErrorCodeDeadlineExceeded, err)
+	case err != nil:
+		return NewError(ErrorKindCanceled, ErrorCodeCanceled, err)
+	}
+
+	return nil
+}
+
+func (c *QRIS) observeRender(kind string, format string, qrisString string, start time.Time) {
+	if c.metrics != nil {
+		c.metrics.ObserveRender(kind, format, qrisString, time.Since(start))
+	}
+}
@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
 	"github.com/fyvri/go-qris/internal/usecases"
@@ -24,23 +30,31 @@ func TestNewQRIS(t *testing.T) {
 		{
 			name: "Success: With Field",
 			fields: QRIS{
-				inputUtil:   &utils.Input{},
-				qrCodeUtil:  &utils.QRCode{},
-				qrisUsecase: &usecases.QRIS{},
-				qrCodeSize:  testQRCodeSize,
+				inputUtil:     &utils.Input{},
+				qrCodeUtil:    &utils.QRCode{},
+				stickerUtil:   &utils.Sticker{},
+				qrisUsecase:   &usecases.QRIS{},
+				qrCodeOptions: testQRCodeOptions,
+				maxQRLength:   512,
+				metrics:       &utils.Metrics{},
+				logger:        testLogger,
 			},
 			want: &QRIS{
-				inputUtil:   &utils.Input{},
-				qrCodeUtil:  &utils.QRCode{},
-				qrisUsecase: &usecases.QRIS{},
-				qrCodeSize:  testQRCodeSize,
+				inputUtil:     &utils.Input{},
+				qrCodeUtil:    &utils.QRCode{},
+				stickerUtil:   &utils.Sticker{},
+				qrisUsecase:   &usecases.QRIS{},
+				qrCodeOptions: testQRCodeOptions,
+				maxQRLength:   512,
+				metrics:       &utils.Metrics{},
+				logger:        testLogger,
 			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			uc := NewQRIS(test.fields.inputUtil, test.fields.qrCodeUtil, test.fields.qrisUsecase, test.fields.qrCodeSize)
+			uc := NewQRIS(test.fields.inputUtil, test.fields.qrCodeUtil, test.fields.stickerUtil, test.fields.qrisUsecase, test.fields.qrCodeOptions, test.fields.maxQRLength, test.fields.metrics, test.fields.logger)
 
 			if uc == nil {
 				t.Errorf(expectedReturnNonNil, "NewQRIS", "QRISInterface")
@@ -127,13 +141,13 @@ func TestQRISParse(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := &QRIS{
-				inputUtil:   test.fields.inputUtil,
-				qrCodeUtil:  test.fields.qrCodeUtil,
-				qrisUsecase: test.fields.qrisUsecase,
-				qrCodeSize:  test.fields.qrCodeSize,
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
 			}
 
-			got, err, _ := c.Parse(test.args.qrString)
+			got, err, _ := c.Parse(context.Background(), test.args.qrString)
 			if err != nil && err.Error() != test.wantError.Error() {
 				t.Errorf(expectedErrorButGotMessage, "Parse()", test.wantError, err)
 			}
@@ -144,6 +158,108 @@ func TestQRISParse(t *testing.T) {
 	}
 }
 
+func TestQRISParseImage(t *testing.T) {
+	type args struct {
+		imageData []byte
+	}
+
+	tests := []struct {
+		name      string
+		fields    QRIS
+		args      args
+		want      *entities.QRIS
+		wantError error
+	}{
+		{
+			name: "Error: c.qrCodeUtil.ImageToString()",
+			fields: QRIS{
+				qrCodeUtil: &mockQRCodeUtil{
+					ImageToStringFunc: func(imageData []byte) (string, error) {
+						return "", fmt.Errorf("can not find QR code in image")
+					},
+				},
+			},
+			args: args{
+				imageData: []byte("image"),
+			},
+			want:      nil,
+			wantError: fmt.Errorf("can not find QR code in image"),
+		},
+		{
+			name: testNameErrorParse,
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					ImageToStringFunc: func(imageData []byte) (string, error) {
+						return testQRISString, nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						return nil, fmt.Errorf(testErrMessageInvalidFormatCode), nil
+					},
+				},
+			},
+			args: args{
+				imageData: []byte("image"),
+			},
+			want:      nil,
+			wantError: fmt.Errorf(testErrMessageInvalidFormatCode),
+		},
+		{
+			name: "Success",
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					ImageToStringFunc: func(imageData []byte) (string, error) {
+						return testQRISString, nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						if qrString != testQRISString {
+							return nil, fmt.Errorf("unexpected QR string %s", qrString), nil
+						}
+						return &entities.QRIS{}, nil, nil
+					},
+				},
+			},
+			args: args{
+				imageData: []byte("image"),
+			},
+			want:      &entities.QRIS{},
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &QRIS{
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
+			}
+
+			got, err, _ := c.ParseImage(context.Background(), test.args.imageData)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "ParseImage()", test.wantError, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "ParseImage()", test.want, got)
+			}
+		})
+	}
+}
+
 func TestQRISConvert(t *testing.T) {
 	type args struct {
 		qrString           string
@@ -153,6 +269,7 @@ func TestQRISConvert(t *testing.T) {
 		paymentFeeCategory string
 		paymentFee         uint32
 		terminalLabel      string
+		qrCodeOptions      *utils.QRCodeOptions
 	}
 
 	type want struct {
@@ -204,6 +321,34 @@ func TestQRISConvert(t *testing.T) {
 			},
 			wantError: fmt.Errorf("input length exceeds the maximum permitted characters"),
 		},
+		{
+			name: "Error: c.qrCodeUtil.ValidateOptions()",
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					ValidateOptionsFunc: func(qrCodeOptions *utils.QRCodeOptions) error {
+						return fmt.Errorf("unsupported QR code format %s", qrCodeOptions.Format)
+					},
+				},
+				qrCodeOptions: testQRCodeOptions,
+			},
+			args: args{
+				qrString:      testQRISString,
+				paymentAmount: testPaymentAmount,
+				qrCodeOptions: &utils.QRCodeOptions{
+					Format: "gif",
+				},
+			},
+			want: want{
+				qrString: "",
+				qrCode:   "",
+			},
+			wantError: fmt.Errorf("unsupported QR code format gif"),
+		},
 		{
 			name: testNameErrorParse,
 			fields: QRIS{
@@ -212,6 +357,7 @@ func TestQRISConvert(t *testing.T) {
 						return testQRISString
 					},
 				},
+				qrCodeUtil: &mockQRCodeUtil{},
 				qrisUsecase: &mockQRISUsecase{
 					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
 						return nil, fmt.Errorf("invalid QRIS format"), nil
@@ -233,7 +379,7 @@ func TestQRISConvert(t *testing.T) {
 			wantError: fmt.Errorf("invalid QRIS format"),
 		},
 		{
-			name: "Error: c.qrCodeUtil.StringToImageBase64()",
+			name: "Error: c.qrCodeUtil.StringToFormatBase64()",
 			fields: QRIS{
 				inputUtil: &mockInputUtil{
 					SanitizeFunc: func(input string) string {
@@ -264,7 +410,7 @@ func TestQRISConvert(t *testing.T) {
 					},
 				},
 				qrCodeUtil: &mockQRCodeUtil{
-					StringToImageBase64Func: func(qrString string, qrCodeSize int) (string, error) {
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
 						return "", fmt.Errorf("unsupported QR code format")
 					},
 				},
@@ -303,7 +449,7 @@ func TestQRISConvert(t *testing.T) {
 					},
 				},
 				qrCodeUtil: &mockQRCodeUtil{
-					StringToImageBase64Func: func(qrString string, qrCodeSize int) (string, error) {
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
 						return "data:image/png;base64,QRIS Modified Code Image Base64", nil
 					},
 				},
@@ -330,7 +476,7 @@ func TestQRISConvert(t *testing.T) {
 						return testQRISModifiedString
 					},
 				},
-				qrCodeSize: 125,
+				qrCodeOptions: testQRCodeOptions,
 			},
 			args: args{
 				qrString:           testQRISString,
@@ -347,19 +493,62 @@ func TestQRISConvert(t *testing.T) {
 			},
 			wantError: nil,
 		},
+		{
+			name: "Success: SVG Format",
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+						if qrCodeOptions.Format != utils.QRCodeFormatSVG || qrCodeOptions.ErrorCorrectionLevel != "Q" || qrCodeOptions.Size != 512 {
+							return "", fmt.Errorf("unexpected QR code options %v", qrCodeOptions)
+						}
+						return "data:image/svg+xml;base64,QRIS Modified Code SVG Base64", nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						return &entities.QRIS{}, nil, nil
+					},
+					ModifyFunc: func(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS {
+						return qris
+					},
+					ToStringFunc: func(qris *entities.QRIS) string {
+						return testQRISModifiedString
+					},
+				},
+				qrCodeOptions: testQRCodeOptions,
+			},
+			args: args{
+				qrString:      testQRISString,
+				paymentAmount: testPaymentAmount,
+				qrCodeOptions: &utils.QRCodeOptions{
+					Format:               "SVG",
+					ErrorCorrectionLevel: "Q",
+				},
+			},
+			want: want{
+				qrString: testQRISModifiedString,
+				qrCode:   "data:image/svg+xml;base64,QRIS Modified Code SVG Base64",
+			},
+			wantError: nil,
+		},
 	}
 
 	funcName := "Convert()"
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := &QRIS{
-				inputUtil:   test.fields.inputUtil,
-				qrCodeUtil:  test.fields.qrCodeUtil,
-				qrisUsecase: test.fields.qrisUsecase,
-				qrCodeSize:  test.fields.qrCodeSize,
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
 			}
 
-			got1, got2, err, _ := c.Convert(test.args.qrString, test.args.merchantCity, test.args.merchantPostalCode, test.args.paymentAmount, test.args.paymentFeeCategory, test.args.paymentFee, test.args.terminalLabel)
+			got1, got2, err, _ := c.Convert(context.Background(), test.args.qrString, test.args.merchantCity, test.args.merchantPostalCode, test.args.paymentAmount, test.args.paymentFeeCategory, test.args.paymentFee, test.args.terminalLabel, test.args.qrCodeOptions)
 			if err != nil && err.Error() != test.wantError.Error() {
 				t.Errorf(expectedErrorButGotMessage, funcName, test.wantError, err)
 			}
@@ -464,16 +653,949 @@ func TestQRISIsValid(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := &QRIS{
-				inputUtil:   test.fields.inputUtil,
-				qrCodeUtil:  test.fields.qrCodeUtil,
-				qrisUsecase: test.fields.qrisUsecase,
-				qrCodeSize:  test.fields.qrCodeSize,
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
 			}
 
-			err, _ := c.IsValid(test.args.qrString)
+			err, _ := c.IsValid(context.Background(), test.args.qrString)
 			if err != nil && err.Error() != test.wantError.Error() {
 				t.Errorf(expectedErrorButGotMessage, "IsValid()", test.wantError, err)
 			}
 		})
 	}
 }
+
+func TestQRISVerifyCRC(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		isValid   bool
+		want      bool
+		wantCode  string
+		wantCalls int
+	}{
+		{
+			name:      "Success: CRC Is Valid",
+			ctx:       context.Background(),
+			isValid:   true,
+			want:      true,
+			wantCalls: 1,
+		},
+		{
+			name:      "Success: CRC Is Invalid",
+			ctx:       context.Background(),
+			isValid:   false,
+			want:      false,
+			wantCalls: 1,
+		},
+		{
+			name:     "Error: Canceled",
+			ctx:      canceled,
+			isValid:  true,
+			want:     false,
+			wantCode: ErrorCodeCanceled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			c := &QRIS{
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						t.Errorf(expectedButGotMessage, "Parse() calls", 0, 1)
+						return nil, nil, nil
+					},
+					IsValidFunc: func(qris *entities.QRIS) bool {
+						calls++
+						return test.isValid
+					},
+				},
+			}
+
+			got, err := c.VerifyCRC(test.ctx, &entities.QRIS{})
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "VerifyCRC()", test.want, got)
+			}
+			if calls != test.wantCalls {
+				t.Errorf(expectedButGotMessage, "IsValid() calls", test.wantCalls, calls)
+			}
+			var controllerErr *Error
+			if test.wantCode == "" && err != nil {
+				t.Errorf(expectedErrorButGotMessage, "VerifyCRC()", nil, err)
+			}
+			if test.wantCode != "" && (!errors.As(err, &controllerErr) || controllerErr.Kind != ErrorKindCanceled || controllerErr.Code != test.wantCode) {
+				t.Errorf(expectedErrorButGotMessage, "VerifyCRC()", test.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestQRISSticker(t *testing.T) {
+	type args struct {
+		qrString       string
+		stickerOptions *utils.StickerOptions
+	}
+
+	tests := []struct {
+		name      string
+		fields    QRIS
+		args      args
+		want      string
+		wantError error
+	}{
+		{
+			name: testNameErrorParse,
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return testQRISString
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						return nil, fmt.Errorf(testErrMessageInvalidFormatCode), nil
+					},
+				},
+			},
+			args: args{
+				qrString: testQRISString,
+			},
+			want:      "",
+			wantError: fmt.Errorf(testErrMessageInvalidFormatCode),
+		},
+		{
+			name: "Error: c.stickerUtil.RenderBase64()",
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				stickerUtil: &mockStickerUtil{
+					RenderBase64Func: func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+						return "", fmt.Errorf("unsupported sticker format %s", stickerOptions.Format)
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						return &entities.QRIS{}, nil, nil
+					},
+				},
+			},
+			args: args{
+				qrString: testQRISString,
+				stickerOptions: &utils.StickerOptions{
+					Format: "SVG",
+				},
+			},
+			want:      "",
+			wantError: fmt.Errorf("unsupported sticker format svg"),
+		},
+		{
+			name: "Success",
+			fields: QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				stickerUtil: &mockStickerUtil{
+					RenderBase64Func: func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+						if stickerContent.QRString != testQRISString || stickerContent.MerchantName != "Sintas Store" || stickerContent.NMID != "ID2020034073193" {
+							return "", fmt.Errorf("unexpected sticker content %v", stickerContent)
+						}
+						if !reflect.DeepEqual(stickerOptions, &utils.StickerOptions{Format: utils.QRCodeFormatPNG, DPI: 150, ErrorCorrectionLevel: "M"}) {
+							return "", fmt.Errorf("unexpected sticker options %v", stickerOptions)
+						}
+						return "data:image/png;base64,sticker", nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						qris := &entities.QRIS{}
+						qris.MerchantName.Content = "Sintas Store"
+						qris.Switching.Detail.NMID.Content = "ID2020034073193"
+						return qris, nil, nil
+					},
+					ToStringFunc: func(qris *entities.QRIS) string {
+						return testQRISString
+					},
+				},
+			},
+			args: args{
+				qrString: testQRISString,
+				stickerOptions: &utils.StickerOptions{
+					Format: utils.QRCodeFormatPNG,
+					DPI:    150,
+				},
+			},
+			want:      "data:image/png;base64,sticker",
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &QRIS{
+				inputUtil:     test.fields.inputUtil,
+				qrCodeUtil:    test.fields.qrCodeUtil,
+				stickerUtil:   test.fields.stickerUtil,
+				qrisUsecase:   test.fields.qrisUsecase,
+				qrCodeOptions: test.fields.qrCodeOptions,
+			}
+
+			got, err, _ := c.Sticker(context.Background(), test.args.qrString, test.args.stickerOptions)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "Sticker()", test.wantError, err)
+			}
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "Sticker()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQRISPatch(t *testing.T) {
+	text := func(value string) *string {
+		return &value
+	}
+	identity := &mockInputUtil{
+		SanitizeFunc: func(input string) string {
+			return strings.TrimSpace(input)
+		},
+	}
+	parsed := &mockQRISUsecase{
+		ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+			return &entities.QRIS{}, nil, nil
+		},
+		PatchFunc: func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+			if patch.AdditionalInformation != nil && patch.AdditionalInformation.BillNumber != nil {
+				qris.AdditionalInformation.Content = strings.Repeat(*patch.AdditionalInformation.BillNumber, 5)
+			}
+			return qris
+		},
+		ToStringFunc: func(qris *entities.QRIS) string {
+			return testQRISModifiedString
+		},
+	}
+
+	tests := []struct {
+		name         string
+		qrisUsecase  usecases.QRISInterface
+		patch        *entities.QRISPatch
+		want         string
+		wantCode     string
+		wantErrorsOf int
+	}{
+		{
+			name: "Error: Amount Is Not Decimal",
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337,50",
+			},
+			wantCode: ErrorCodeInvalidAmount,
+		},
+		{
+			name: "Error: Amount Is Zero",
+			patch: &entities.QRISPatch{
+				PaymentAmount: "0.00",
+			},
+			wantCode: ErrorCodeInvalidAmount,
+		},
+		{
+			name: "Error: Amount Too Long",
+			patch: &entities.QRISPatch{
+				PaymentAmount: "12345678901.50",
+			},
+			wantCode: ErrorCodeInvalidAmount,
+		},
+		{
+			name: "Error: Percent Fee Above 100",
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337",
+				PaymentFee: &entities.PaymentFeePatch{
+					Category: "percent",
+					Value:    "100.5",
+				},
+			},
+			wantCode: ErrorCodeInvalidPaymentFee,
+		},
+		{
+			name: "Error: Unknown Fee Category",
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337",
+				PaymentFee: &entities.PaymentFeePatch{
+					Category: "TIP",
+					Value:    "1",
+				},
+			},
+			wantCode: ErrorCodeInvalidPaymentFee,
+		},
+		{
+			name: "Error: Input Length",
+			patch: &entities.QRISPatch{
+				PaymentAmount:      "1337",
+				MerchantCity:       text(" "),
+				MerchantPostalCode: text("12345678901"),
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					TerminalLabel:   text(strings.Repeat("a", 26)),
+					MerchantChannel: text("1234"),
+				},
+			},
+			wantCode:     ErrorCodeInputTooLong,
+			wantErrorsOf: 4,
+		},
+		{
+			name: testNameErrorParse,
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return nil, fmt.Errorf(testErrMessageInvalidFormatCode), nil
+				},
+			},
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337",
+			},
+			wantCode: ErrorCodeInvalidQRIS,
+		},
+		{
+			name:        "Error: Additional Information Too Long",
+			qrisUsecase: parsed,
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337",
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					BillNumber: text(strings.Repeat("a", 25)),
+				},
+			},
+			wantCode:     ErrorCodeInputTooLong,
+			wantErrorsOf: 1,
+		},
+		{
+			name:        "Success",
+			qrisUsecase: parsed,
+			patch: &entities.QRISPatch{
+				PaymentAmount: " 1337.50 ",
+				PaymentFee: &entities.PaymentFeePatch{
+					Category: "percent",
+					Value:    "0.7",
+				},
+				MerchantCity:       text("Sleman"),
+				MerchantPostalCode: text(""),
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					TerminalLabel: text(""),
+				},
+			},
+			want: testQRISModifiedString,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &QRIS{
+				inputUtil:   identity,
+				qrisUsecase: test.qrisUsecase,
+			}
+
+			got, _, err, errs := c.Patch(context.Background(), testQRISString, test.patch)
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "Patch()", test.want, got)
+			}
+			if test.wantCode == "" {
+				if err != nil {
+					t.Errorf(expectedErrorButGotMessage, "Patch()", nil, err)
+				}
+				return
+			}
+
+			var controllerErr *Error
+			if !errors.As(err, &controllerErr) || controllerErr.Code != test.wantCode {
+				t.Fatalf(expectedErrorButGotMessage, "Patch()", test.wantCode, err)
+			}
+			if test.wantErrorsOf > 0 && (errs == nil || len(*errs) != test.wantErrorsOf) {
+				t.Errorf(expectedButGotMessage, "Patch() errors", test.wantErrorsOf, errs)
+			}
+		})
+	}
+}
+
+func TestQRISPatchSanitizesCopy(t *testing.T) {
+	city := " Sleman "
+	patch := &entities.QRISPatch{
+		PaymentAmount: " 1337 ",
+		MerchantCity:  &city,
+		PaymentFee: &entities.PaymentFeePatch{
+			Category: "fixed",
+			Value:    "666",
+		},
+	}
+
+	var got *entities.QRISPatch
+	c := &QRIS{
+		inputUtil: utils.NewInput(),
+		qrisUsecase: &mockQRISUsecase{
+			ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+				return &entities.QRIS{}, nil, nil
+			},
+			PatchFunc: func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+				got = patch
+				return qris
+			},
+		},
+	}
+
+	if _, _, err, _ := c.Patch(context.Background(), testQRISString, patch); err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "Patch()", nil, err)
+	}
+	want := &entities.QRISPatch{
+		PaymentAmount: "1337",
+		MerchantCity:  got.MerchantCity,
+		PaymentFee: &entities.PaymentFeePatch{
+			Category: "FIXED",
+			Value:    "666",
+		},
+	}
+	if !reflect.DeepEqual(got, want) || *got.MerchantCity != "Sleman" {
+		t.Errorf(expectedButGotMessage, "Patch() sanitized patch", want, got)
+	}
+	if city != " Sleman " || patch.PaymentFee.Category != "fixed" {
+		t.Errorf(expectedButGotMessage, "Patch() caller patch", "untouched", patch)
+	}
+}
+
+func TestQRISGenerate(t *testing.T) {
+	identity := &mockInputUtil{
+		SanitizeFunc: func(input string) string {
+			return input
+		},
+	}
+	parsed := func(isValid bool) *mockQRISUsecase {
+		return &mockQRISUsecase{
+			ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+				return &entities.QRIS{}, nil, nil
+			},
+			IsValidFunc: func(qris *entities.QRIS) bool {
+				return isValid
+			},
+			ToStringFunc: func(qris *entities.QRIS) string {
+				return testQRISString
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		qrisUsecase   usecases.QRISInterface
+		qrCodeUtil    utils.QRCodeInterface
+		qrCodeOptions *utils.QRCodeOptions
+		want          string
+		wantCode      string
+	}{
+		{
+			name: testNameErrorParse,
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return nil, fmt.Errorf(testErrMessageInvalidFormatCode), nil
+				},
+			},
+			wantCode: ErrorCodeInvalidQRIS,
+		},
+		{
+			name:        "Error: Invalid CRC",
+			qrisUsecase: parsed(false),
+			wantCode:    ErrorCodeInvalidCRC,
+		},
+		{
+			name:        "Error: c.qrCodeUtil.ValidateOptions()",
+			qrisUsecase: parsed(true),
+			qrCodeUtil: &mockQRCodeUtil{
+				ValidateOptionsFunc: func(qrCodeOptions *utils.QRCodeOptions) error {
+					return fmt.Errorf("unsupported QR code format %s", qrCodeOptions.Format)
+				},
+			},
+			qrCodeOptions: &utils.QRCodeOptions{
+				Format: "GIF",
+			},
+			wantCode: ErrorCodeInvalidQRCodeOptions,
+		},
+		{
+			name:        "Error: c.qrCodeUtil.StringToFormatBase64()",
+			qrisUsecase: parsed(true),
+			qrCodeUtil: &mockQRCodeUtil{
+				StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+					return "", fmt.Errorf("QR code is too small for a logo")
+				},
+			},
+			wantCode: ErrorCodeQRCodeNotRenderable,
+		},
+		{
+			name:        "Success",
+			qrisUsecase: parsed(true),
+			qrCodeUtil: &mockQRCodeUtil{
+				StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+					if qrString != testQRISString || qrCodeOptions.Format != utils.QRCodeFormatSVG || qrCodeOptions.Size != testQRCodeOptions.Size {
+						return "", fmt.Errorf("unexpected QR code options %v", qrCodeOptions)
+					}
+					return "data:image/svg+xml;base64,QRIS Code SVG Base64", nil
+				},
+			},
+			qrCodeOptions: &utils.QRCodeOptions{
+				Format: "SVG",
+			},
+			want: "data:image/svg+xml;base64,QRIS Code SVG Base64",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &QRIS{
+				inputUtil:     identity,
+				qrCodeUtil:    test.qrCodeUtil,
+				qrisUsecase:   test.qrisUsecase,
+				qrCodeOptions: testQRCodeOptions,
+			}
+
+			got, err, _ := c.Generate(context.Background(), testQRISString, test.qrCodeOptions)
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "Generate()", test.want, got)
+			}
+			if test.wantCode == "" {
+				if err != nil {
+					t.Errorf(expectedErrorButGotMessage, "Generate()", nil, err)
+				}
+				return
+			}
+
+			var controllerErr *Error
+			if !errors.As(err, &controllerErr) || controllerErr.Code != test.wantCode {
+				t.Errorf(expectedErrorButGotMessage, "Generate()", test.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestQRISMetrics(t *testing.T) {
+	type observation struct {
+		operation string
+		code      string
+		issues    []string
+	}
+
+	identity := &mockInputUtil{
+		SanitizeFunc: func(input string) string {
+			return input
+		},
+	}
+
+	tests := []struct {
+		name        string
+		call        func(c *QRIS)
+		qrisUsecase usecases.QRISInterface
+		qrCodeUtil  utils.QRCodeInterface
+		want        []observation
+		wantRenders []string
+	}{
+		{
+			name: "Invalid QRIS With Issues",
+			call: func(c *QRIS) {
+				c.Parse(context.Background(), testQRISString)
+			},
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return nil, fmt.Errorf("invalid QRIS format"), &[]string{"CRC code tag is missing"}
+				},
+			},
+			want: []observation{
+				{operation: "parse", code: ErrorCodeInvalidQRIS, issues: []string{"CRC code tag is missing"}},
+			},
+		},
+		{
+			name: "CRC Failure Is Observed Once",
+			call: func(c *QRIS) {
+				c.IsValid(context.Background(), testQRISString)
+			},
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+			},
+			want: []observation{
+				{operation: "is_valid", code: ErrorCodeInvalidCRC},
+			},
+		},
+		{
+			name: "Generate Observes The Render",
+			call: func(c *QRIS) {
+				c.Generate(context.Background(), testQRISString, nil)
+			},
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+				IsValidFunc: func(qris *entities.QRIS) bool {
+					return true
+				},
+			},
+			qrCodeUtil: &mockQRCodeUtil{},
+			want: []observation{
+				{operation: "generate"},
+			},
+			wantRenders: []string{"qr_code " + utils.QRCodeFormatPNG},
+		},
+		{
+			name: "Patch Success",
+			call: func(c *QRIS) {
+				c.Patch(context.Background(), testQRISString, &entities.QRISPatch{PaymentAmount: "1337"})
+			},
+			qrisUsecase: &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					return &entities.QRIS{}, nil, nil
+				},
+				PatchFunc: func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+					return qris
+				},
+			},
+			want: []observation{
+				{operation: "patch"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []observation
+			var gotRenders []string
+			c := &QRIS{
+				inputUtil:     identity,
+				qrCodeUtil:    test.qrCodeUtil,
+				qrisUsecase:   test.qrisUsecase,
+				qrCodeOptions: testQRCodeOptions,
+				metrics: &mockMetrics{
+					ObserveOperationFunc: func(operation string, code string, issues []string, duration time.Duration) {
+						got = append(got, observation{operation: operation, code: code, issues: issues})
+					},
+					ObserveRenderFunc: func(kind string, format string, qrString string, duration time.Duration) {
+						gotRenders = append(gotRenders, kind+" "+format)
+					},
+				},
+			}
+
+			test.call(c)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "ObserveOperation()", test.want, got)
+			}
+			if !reflect.DeepEqual(gotRenders, test.wantRenders) {
+				t.Errorf(expectedButGotMessage, "ObserveRender()", test.wantRenders, gotRenders)
+			}
+		})
+	}
+}
+
+func TestQRISObserveInternalError(t *testing.T) {
+	var got string
+	c := &QRIS{
+		metrics: &mockMetrics{
+			ObserveOperationFunc: func(operation string, code string, issues []string, duration time.Duration) {
+				got = code
+			},
+		},
+	}
+
+	c.observe(context.Background(), "convert", time.Now(), fmt.Errorf("unexpected"), nil)
+	if got != ErrorCodeInternal {
+		t.Errorf(expectedButGotMessage, "observe()", ErrorCodeInternal, got)
+	}
+}
+
+func TestQRISWithLogger(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{
+		ReplaceAttr: utils.RedactAttr,
+	})).With("request_id", "req-1337")
+
+	c := &QRIS{
+		inputUtil: &mockInputUtil{
+			SanitizeFunc: func(input string) string {
+				return input
+			},
+		},
+		qrisUsecase: &mockQRISUsecase{
+			ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+				return nil, fmt.Errorf("unknown merchant ID1020017611473"), nil
+			},
+		},
+		logger: testLogger,
+	}
+
+	got := c.WithLogger(logger)
+	if c.logger != testLogger {
+		t.Errorf(expectedButGotMessage, "c.logger", testLogger, c.logger)
+	}
+
+	got.Parse(context.Background(), testQRISString)
+	logs := output.String()
+	for _, want := range []string{`"request_id":"req-1337"`, `"operation":"parse"`, `"code":"invalid_qris"`, `"error":"unknown merchant ***********1473"`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf(expectedButGotMessage, "log output", want, logs)
+		}
+	}
+}
+
+func TestQRISWithTenant(t *testing.T) {
+	type want struct {
+		code            string
+		foregroundColor string
+	}
+
+	tests := []struct {
+		name   string
+		tenant *entities.Tenant
+		call   func(c QRISInterface) error
+		want   want
+	}{
+		{
+			name:   "Error: Convert Foreign Merchant",
+			tenant: &entities.Tenant{AllowedNMIDs: []string{"ID1020017611474"}},
+			call: func(c QRISInterface) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			want: want{
+				code: ErrorCodeMerchantNotAllowed,
+			},
+		},
+		{
+			name:   "Error: Patch Foreign Merchant",
+			tenant: &entities.Tenant{},
+			call: func(c QRISInterface) error {
+				_, _, err, _ := c.Patch(context.Background(), testQRISString, &entities.QRISPatch{PaymentAmount: "1337"})
+				return err
+			},
+			want: want{
+				code: ErrorCodeMerchantNotAllowed,
+			},
+		},
+		{
+			name: "Success: Convert With Tenant Defaults",
+			tenant: &entities.Tenant{
+				AllowedNMIDs:  []string{"ID1020017611473"},
+				QRCodeOptions: &entities.QRCodeOptions{ForegroundColor: "#1337AA"},
+			},
+			call: func(c QRISInterface) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			want: want{
+				foregroundColor: "#1337AA",
+			},
+		},
+		{
+			name:   "Success: Convert Any Merchant",
+			tenant: &entities.Tenant{AllowedNMIDs: []string{entities.TenantWildcard}},
+			call: func(c QRISInterface) error {
+				_, _, err, _ := c.Convert(context.Background(), testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			want: want{
+				foregroundColor: testQRCodeOptions.ForegroundColor,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotForegroundColor string
+			c := &QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+						gotForegroundColor = qrCodeOptions.ForegroundColor
+						return "QR Code", nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						qris := &entities.QRIS{}
+						qris.Switching.Detail.NMID.Content = "ID1020017611473"
+						return qris, nil, nil
+					},
+					ModifyFunc: func(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS {
+						return qris
+					},
+				},
+				qrCodeOptions: testQRCodeOptions,
+			}
+
+			err := test.call(c.WithTenant(test.tenant))
+			code := ""
+			var controllerErr *Error
+			if errors.As(err, &controllerErr) {
+				code = controllerErr.Code
+			}
+			if code != test.want.code {
+				t.Errorf(expectedErrorButGotMessage, "WithTenant()", test.want.code, err)
+			}
+			if gotForegroundColor != test.want.foregroundColor {
+				t.Errorf(expectedButGotMessage, "ForegroundColor", test.want.foregroundColor, gotForegroundColor)
+			}
+			if c.tenant != nil || c.qrCodeOptions != testQRCodeOptions {
+				t.Errorf(expectedButGotMessage, "*QRIS", "unchanged", c)
+			}
+		})
+	}
+}
+
+func TestTenantQRCodeOptions(t *testing.T) {
+	margin := 0
+
+	tests := []struct {
+		name    string
+		options *entities.QRCodeOptions
+		want    *utils.QRCodeOptions
+	}{
+		{
+			name: "Success: Nil",
+		},
+		{
+			name:    "Success: Without Margin",
+			options: &entities.QRCodeOptions{Format: "svg", ForegroundColor: "#1337AA"},
+			want:    &utils.QRCodeOptions{Format: "svg", ForegroundColor: "#1337AA"},
+		},
+		{
+			name:    "Success: Zero Margin",
+			options: &entities.QRCodeOptions{Margin: &margin},
+			want:    &utils.QRCodeOptions{Margin: 0, MarginSet: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := TenantQRCodeOptions(test.options); !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "TenantQRCodeOptions()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQRISContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		call      func(ctx context.Context, c QRISInterface) error
+		wantKind  ErrorKind
+		wantCode  string
+		wantCalls int
+	}{
+		{
+			name: "Success: Parse",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, c QRISInterface) error {
+				_, err, _ := c.Parse(ctx, testQRISString)
+				return err
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Error: Parse Canceled",
+			ctx:  canceled,
+			call: func(ctx context.Context, c QRISInterface) error {
+				_, err, _ := c.Parse(ctx, testQRISString)
+				return err
+			},
+			wantKind: ErrorKindCanceled,
+			wantCode: ErrorCodeCanceled,
+		},
+		{
+			name: "Error: Convert Deadline Exceeded",
+			ctx:  expired,
+			call: func(ctx context.Context, c QRISInterface) error {
+				_, _, err, _ := c.Convert(ctx, testQRISString, "", "", 1337, "", 0, "", nil)
+				return err
+			},
+			wantKind: ErrorKindTimeout,
+			wantCode: ErrorCodeDeadlineExceeded,
+		},
+		{
+			name: "Error: Parse Image Canceled",
+			ctx:  canceled,
+			call: func(ctx context.Context, c QRISInterface) error {
+				_, err, _ := c.ParseImage(ctx, []byte("image"))
+				return err
+			},
+			wantKind: ErrorKindCanceled,
+			wantCode: ErrorCodeCanceled,
+		},
+		{
+			name: "Error: Generate Canceled While Rendering",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, c QRISInterface) error {
+				ctx, cancel := context.WithCancel(ctx)
+				c.(*QRIS).qrCodeUtil = &mockQRCodeUtil{
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+						cancel()
+						return "", context.Canceled
+					},
+				}
+				_, err, _ := c.Generate(ctx, testQRISString, nil)
+				return err
+			},
+			wantKind:  ErrorKindCanceled,
+			wantCode:  ErrorCodeCanceled,
+			wantCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			c := &QRIS{
+				inputUtil: &mockInputUtil{
+					SanitizeFunc: func(input string) string {
+						return input
+					},
+				},
+				qrCodeUtil: &mockQRCodeUtil{
+					StringToFormatBase64Func: func(qrString string, qrCodeOptions *utils.QRCodeOptions) (string, error) {
+						return "QR Code", nil
+					},
+				},
+				qrisUsecase: &mockQRISUsecase{
+					ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+						calls++
+						return &entities.QRIS{}, nil, nil
+					},
+					IsValidFunc: func(qris *entities.QRIS) bool {
+						return true
+					},
+				},
+				qrCodeOptions: testQRCodeOptions,
+				logger:        testLogger,
+			}
+
+			err := test.call(test.ctx, c)
+			var controllerErr *Error
+			if test.wantCode == "" {
+				if err != nil {
+					t.Errorf(expectedErrorButGotMessage, "QRIS", nil, err)
+				}
+			} else if !errors.As(err, &controllerErr) || controllerErr.Kind != test.wantKind || controllerErr.Code != test.wantCode {
+				t.Errorf(expectedErrorButGotMessage, "QRIS", test.wantCode, err)
+			}
+			if calls != test.wantCalls {
+				t.Errorf(expectedButGotMessage, "Parse calls", test.wantCalls, calls)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ type AdditionalInformationDetailTags struct {
 type AdditionalInformationInterface interface {
 	Parse(content string) (*entities.AdditionalInformationDetail, error)
 	ToString(additionalInformationDetail *entities.AdditionalInformationDetail) string
+	Patch(additionalInformationDetail *entities.AdditionalInformationDetail, patch *entities.AdditionalInformationPatch) *entities.AdditionalInformationDetail
 }
 
 func NewAdditionalInformation(dataUsecase DataInterface, additionalInformationDetailTags *AdditionalInformationDetailTags) AdditionalInformationInterface {
@@ -97,3 +98,33 @@ func (uc *AdditionalInformation) ToString(additionalInformationDetail *entities.
 		additionalInformationDetail.RFU.Data +
 		additionalInformationDetail.PaymentSystemSpecific.Data
 }
+
+// Patch returns a copy of the detail with every non-nil field of the patch
+// applied, adding the subtag when missing and removing it when empty.
+func (uc *AdditionalInformation) Patch(additionalInformationDetail *entities.AdditionalInformationDetail, patch *entities.AdditionalInformationPatch) *entities.AdditionalInformationDetail {
+	detail := *additionalInformationDetail
+	fields := []struct {
+		data  *entities.Data
+		tag   string
+		value *string
+	}{
+		{&detail.BillNumber, uc.additionalInformationDetailTags.BillNumber, patch.BillNumber},
+		{&detail.MobileNumber, uc.additionalInformationDetailTags.MobileNumber, patch.MobileNumber},
+		{&detail.StoreLabel, uc.additionalInformationDetailTags.StoreLabel, patch.StoreLabel},
+		{&detail.LoyaltyNumber, uc.additionalInformationDetailTags.LoyaltyNumber, patch.LoyaltyNumber},
+		{&detail.ReferenceLabel, uc.additionalInformationDetailTags.ReferenceLabel, patch.ReferenceLabel},
+		{&detail.CustomerLabel, uc.additionalInformationDetailTags.CustomerLabel, patch.CustomerLabel},
+		{&detail.TerminalLabel, uc.additionalInformationDetailTags.TerminalLabel, patch.TerminalLabel},
+		{&detail.PurposeOfTransaction, uc.additionalInformationDetailTags.PurposeOfTransaction, patch.PurposeOfTransaction},
+		{&detail.AdditionalConsumerDataRequest, uc.additionalInformationDetailTags.AdditionalConsumerDataRequest, patch.AdditionalConsumerDataRequest},
+		{&detail.MerchantTaxID, uc.additionalInformationDetailTags.MerchantTaxID, patch.MerchantTaxID},
+		{&detail.MerchantChannel, uc.additionalInformationDetailTags.MerchantChannel, patch.MerchantChannel},
+	}
+	for _, field := range fields {
+		if field.value != nil {
+			*field.data = *uc.dataUsecase.ModifyContent(&entities.Data{Tag: field.tag}, *field.value)
+		}
+	}
+
+	return &detail
+}
@@ -426,3 +426,66 @@ func TestAdditionalInformationToString(t *testing.T) {
 		})
 	}
 }
+
+func TestAdditionalInformationPatch(t *testing.T) {
+	billNumber, terminalLabel, storeLabel := "INV-1337", "", "Sintas"
+	detail := &entities.AdditionalInformationDetail{
+		StoreLabel: entities.Data{
+			Tag:     testAdditionalInformationDetailStoreLabelTag,
+			Content: "Old",
+			Data:    testAdditionalInformationDetailStoreLabelTag + "03Old",
+		},
+		TerminalLabel: entities.Data{
+			Tag:     testAdditionalInformationDetailTerminalLabelTag,
+			Content: "A01",
+			Data:    testAdditionalInformationDetailTerminalLabelTag + "03A01",
+		},
+		MerchantChannel: entities.Data{
+			Tag:     testAdditionalInformationDetailMerchantChannelTag,
+			Content: "POS",
+			Data:    testAdditionalInformationDetailMerchantChannelTag + "03POS",
+		},
+	}
+	want := &entities.AdditionalInformationDetail{
+		BillNumber: entities.Data{
+			Tag:     testAdditionalInformationDetailBillNumberTag,
+			Content: billNumber,
+			Data:    testAdditionalInformationDetailBillNumberTag + "08" + billNumber,
+		},
+		StoreLabel: entities.Data{
+			Tag:     testAdditionalInformationDetailStoreLabelTag,
+			Content: storeLabel,
+			Data:    testAdditionalInformationDetailStoreLabelTag + "06" + storeLabel,
+		},
+		MerchantChannel: detail.MerchantChannel,
+	}
+
+	uc := &AdditionalInformation{
+		dataUsecase: NewData(),
+		additionalInformationDetailTags: &AdditionalInformationDetailTags{
+			BillNumber:                    testAdditionalInformationDetailBillNumberTag,
+			MobileNumber:                  testAdditionalInformationDetailMobileNumberTag,
+			StoreLabel:                    testAdditionalInformationDetailStoreLabelTag,
+			LoyaltyNumber:                 testAdditionalInformationDetailLoyaltyNumberTag,
+			ReferenceLabel:                testAdditionalInformationDetailReferenceLabelTag,
+			CustomerLabel:                 testAdditionalInformationDetailCustomerLabelTag,
+			TerminalLabel:                 testAdditionalInformationDetailTerminalLabelTag,
+			PurposeOfTransaction:          testAdditionalInformationDetailPurposeOfTransactionTag,
+			AdditionalConsumerDataRequest: testAdditionalInformationDetailAdditionalConsumerDataRequestTag,
+			MerchantTaxID:                 testAdditionalInformationDetailMerchantTaxIDTag,
+			MerchantChannel:               testAdditionalInformationDetailMerchantChannelTag,
+		},
+	}
+
+	got := uc.Patch(detail, &entities.AdditionalInformationPatch{
+		BillNumber:    &billNumber,
+		StoreLabel:    &storeLabel,
+		TerminalLabel: &terminalLabel,
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(expectedButGotMessage, "Patch()", want, got)
+	}
+	if detail.TerminalLabel.Content != "A01" {
+		t.Errorf(expectedButGotMessage, "Patch() original terminal label", "A01", detail.TerminalLabel.Content)
+	}
+}
@@ -13,6 +13,7 @@ type PaymentFee struct {
 
 type PaymentFeeInterface interface {
 	Modify(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeValue uint32) *entities.QRIS
+	ModifyContent(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeContent string) *entities.QRIS
 }
 
 func NewPaymentFee(qrisTags *QRISTags, qrisPaymentFeeCategoryContents *QRISPaymentFeeCategoryContents) PaymentFeeInterface {
@@ -23,6 +24,11 @@ func NewPaymentFee(qrisTags *QRISTags, qrisPaymentFeeCategoryContents *QRISPayme
 }
 
 func (uc *PaymentFee) Modify(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeValue uint32) *entities.QRIS {
+	return uc.ModifyContent(qris, paymentFeeCategoryValue, fmt.Sprintf("%d", paymentFeeValue))
+}
+
+// ModifyContent sets the fee from its content as is, e.g. "666.50" or "0.7".
+func (uc *PaymentFee) ModifyContent(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeContent string) *entities.QRIS {
 	paymentFeeCategoryTag := ""
 	paymentFeeCategoryContent := ""
 	paymentFeeCategoryContentLength := ""
@@ -45,11 +51,10 @@ func (uc *PaymentFee) Modify(qris *entities.QRIS, paymentFeeCategoryValue string
 		Data:    paymentFeeCategoryTag + paymentFeeCategoryContentLength + paymentFeeCategoryContent,
 	}
 	if qris.PaymentFeeCategory.Tag != "" {
-		content := fmt.Sprintf("%d", paymentFeeValue)
 		qris.PaymentFee = entities.Data{
 			Tag:     paymentFeeTag,
-			Content: content,
-			Data:    paymentFeeTag + fmt.Sprintf("%02d", len(content)) + content,
+			Content: paymentFeeContent,
+			Data:    paymentFeeTag + fmt.Sprintf("%02d", len(paymentFeeContent)) + paymentFeeContent,
 		}
 	}
 
@@ -260,3 +260,86 @@ func TestPaymentFeeModify(t *testing.T) {
 		})
 	}
 }
+
+func TestPaymentFeeModifyContent(t *testing.T) {
+	type args struct {
+		paymentFeeCategory string
+		paymentFeeContent  string
+	}
+
+	tests := []struct {
+		name                   string
+		args                   args
+		wantPaymentFeeCategory entities.Data
+		wantPaymentFee         entities.Data
+	}{
+		{
+			name: "Success: Fixed Decimal Payment Fee",
+			args: args{
+				paymentFeeCategory: "FIXED",
+				paymentFeeContent:  "666.50",
+			},
+			wantPaymentFeeCategory: entities.Data{
+				Tag:     testPaymentFeeCategoryTag,
+				Content: testPaymentFeeCategoryFixedContent,
+				Data:    testPaymentFeeCategoryTag + "02" + testPaymentFeeCategoryFixedContent,
+			},
+			wantPaymentFee: entities.Data{
+				Tag:     testPaymentFeeFixedTag,
+				Content: "666.50",
+				Data:    testPaymentFeeFixedTag + "06666.50",
+			},
+		},
+		{
+			name: "Success: Percent Decimal Payment Fee",
+			args: args{
+				paymentFeeCategory: "PERCENT",
+				paymentFeeContent:  "0.7",
+			},
+			wantPaymentFeeCategory: entities.Data{
+				Tag:     testPaymentFeeCategoryTag,
+				Content: testPaymentFeeCategoryPercentContent,
+				Data:    testPaymentFeeCategoryTag + "02" + testPaymentFeeCategoryPercentContent,
+			},
+			wantPaymentFee: entities.Data{
+				Tag:     testPaymentFeePercentTag,
+				Content: "0.7",
+				Data:    testPaymentFeePercentTag + "030.7",
+			},
+		},
+		{
+			name: "Success: Unknown Category",
+			args: args{
+				paymentFeeCategory: "FREE",
+				paymentFeeContent:  "1",
+			},
+			wantPaymentFeeCategory: entities.Data{},
+			wantPaymentFee:         testQRIS.PaymentFee,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uc := &PaymentFee{
+				qrisTags: &QRISTags{
+					PaymentFeeCategory: testPaymentFeeCategoryTag,
+					PaymentFeeFixed:    testPaymentFeeFixedTag,
+					PaymentFeePercent:  testPaymentFeePercentTag,
+				},
+				qrisPaymentFeeCategoryContents: &QRISPaymentFeeCategoryContents{
+					Fixed:   testPaymentFeeCategoryFixedContent,
+					Percent: testPaymentFeeCategoryPercentContent,
+				},
+			}
+
+			qris := testQRIS
+			got := uc.ModifyContent(&qris, test.args.paymentFeeCategory, test.args.paymentFeeContent)
+			if !reflect.DeepEqual(got.PaymentFeeCategory, test.wantPaymentFeeCategory) {
+				t.Errorf(expectedButGotMessage, "ModifyContent() payment fee category", test.wantPaymentFeeCategory, got.PaymentFeeCategory)
+			}
+			if !reflect.DeepEqual(got.PaymentFee, test.wantPaymentFee) {
+				t.Errorf(expectedButGotMessage, "ModifyContent() payment fee", test.wantPaymentFee, got.PaymentFee)
+			}
+		})
+	}
+}
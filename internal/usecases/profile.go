@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"github.com/fyvri/go-qris/internal/config"
+)
+
+// Profile holds the tag numbers and contents of one EMVCo national scheme.
+// It only holds strings, so changing a copy never reaches a built usecase.
+type Profile struct {
+	Tags                            QRISTags
+	AcquirerDetailTags              AcquirerDetailTags
+	SwitchingDetailTags             SwitchingDetailTags
+	AdditionalInformationDetailTags AdditionalInformationDetailTags
+	CategoryContents                QRISCategoryContents
+	PaymentFeeCategoryContents      QRISPaymentFeeCategoryContents
+}
+
+// DefaultProfile returns the QRIS profile of Bank Indonesia.
+func DefaultProfile() Profile {
+	return Profile{
+		Tags: QRISTags{
+			Version:               config.VersionTag,
+			Category:              config.CategoryTag,
+			Acquirer:              config.AcquirerTag,
+			AcquirerBankTransfer:  config.AcquirerBankTransferTag,
+			Switching:             config.SwitchingTag,
+			MerchantCategoryCode:  config.MerchantCategoryCodeTag,
+			CurrencyCode:          config.CurrencyCodeTag,
+			PaymentAmount:         config.PaymentAmountTag,
+			PaymentFeeCategory:    config.PaymentFeeCategoryTag,
+			PaymentFeeFixed:       config.PaymentFeeFixedTag,
+			PaymentFeePercent:     config.PaymentFeePercentTag,
+			CountryCode:           config.CountryCodeTag,
+			MerchantName:          config.MerchantNameTag,
+			MerchantCity:          config.MerchantCityTag,
+			MerchantPostalCode:    config.MerchantPostalCodeTag,
+			AdditionalInformation: config.AdditionalInformationTag,
+			CRCCode:               config.CRCCodeTag,
+		},
+		AcquirerDetailTags: AcquirerDetailTags{
+			Site:       config.AcquirerDetailSiteTag,
+			MPAN:       config.AcquirerDetailMPANTag,
+			TerminalID: config.AcquirerDetailTerminalIDTag,
+			Category:   config.AcquirerDetailCategoryTag,
+		},
+		SwitchingDetailTags: SwitchingDetailTags{
+			Site:     config.SwitchingDetailSiteTag,
+			NMID:     config.SwitchingDetailNMIDTag,
+			Category: config.SwitchingDetailCategoryTag,
+		},
+		AdditionalInformationDetailTags: AdditionalInformationDetailTags{
+			BillNumber:                    config.AdditionalInformationDetailBillNumberTag,
+			MobileNumber:                  config.AdditionalInformationDetailMobileNumberTag,
+			StoreLabel:                    config.AdditionalInformationDetailStoreLabelTag,
+			LoyaltyNumber:                 config.AdditionalInformationDetailLoyaltyNumberTag,
+			ReferenceLabel:                config.AdditionalInformationDetailReferenceLabelTag,
+			CustomerLabel:                 config.AdditionalInformationDetailCustomerLabelTag,
+			TerminalLabel:                 config.AdditionalInformationDetailTerminalLabelTag,
+			PurposeOfTransaction:          config.AdditionalInformationDetailPurposeOfTransactionTag,
+			AdditionalConsumerDataRequest: config.AdditionalInformationDetailAdditionalConsumerDataRequestTag,
+			MerchantTaxID:                 config.AdditionalInformationDetailMerchantTaxIDTag,
+			MerchantChannel:               config.AdditionalInformationDetailMerchantChannelTag,
+			RFUStart:                      config.AdditionalInformationDetailRFUTagStart,
+			RFUEnd:                        config.AdditionalInformationDetailRFUTagEnd,
+			PaymentSystemSpecificStart:    config.AdditionalInformationDetailPaymentSystemSpecificTagStart,
+			PaymentSystemSpecificEnd:      config.AdditionalInformationDetailPaymentSystemSpecificTagEnd,
+		},
+		CategoryContents: QRISCategoryContents{
+			Static:  config.CategoryStaticContent,
+			Dynamic: config.CategoryDynamicContent,
+		},
+		PaymentFeeCategoryContents: QRISPaymentFeeCategoryContents{
+			Fixed:   config.PaymentFeeCategoryFixedContent,
+			Percent: config.PaymentFeeCategoryPercentContent,
+		},
+	}
+}
+
+// NewQRISFromProfile builds the QRIS usecase and everything it depends on.
+// The usecases point into this call's copy of profile.
+func NewQRISFromProfile(profile Profile) QRISInterface {
+	dataUsecase := NewData()
+	acquirerUsecase := NewAcquirer(dataUsecase, &profile.AcquirerDetailTags)
+	switchingUsecase := NewSwitching(dataUsecase, &profile.SwitchingDetailTags)
+	additionalInformationUsecase := NewAdditionalInformation(dataUsecase, &profile.AdditionalInformationDetailTags)
+	fieldUsecase := NewField(acquirerUsecase, switchingUsecase, additionalInformationUsecase, &profile.Tags, &profile.CategoryContents)
+	paymentFeeUsecase := NewPaymentFee(&profile.Tags, &profile.PaymentFeeCategoryContents)
+	crc16CCITTUsecase := NewCRC16CCITT()
+
+	qrisUsecases := &QRISUsecases{
+		Data:                  dataUsecase,
+		Field:                 fieldUsecase,
+		PaymentFee:            paymentFeeUsecase,
+		AdditionalInformation: additionalInformationUsecase,
+		CRC16CCITT:            crc16CCITTUsecase,
+	}
+
+	return NewQRIS(qrisUsecases, &profile.Tags, &profile.CategoryContents, &profile.PaymentFeeCategoryContents)
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewQRISFromProfile(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+	// The same QRIS with its additional data field under tag 64.
+	payload := strings.Replace(qrString[:len(qrString)-4], "62070703A01", "64070703A01", 1)
+	customQRString := payload + NewCRC16CCITT().GenerateCode(payload)
+
+	customProfile := DefaultProfile()
+	customProfile.Tags.AdditionalInformation = "64"
+
+	tests := []struct {
+		name              string
+		profile           Profile
+		qrString          string
+		wantTerminalLabel string
+		wantQRString      string
+	}{
+		{
+			name:              "Success: Default Profile",
+			profile:           DefaultProfile(),
+			qrString:          qrString,
+			wantTerminalLabel: "A01",
+			wantQRString:      qrString,
+		},
+		{
+			name:              "Success: Custom Profile",
+			profile:           customProfile,
+			qrString:          customQRString,
+			wantTerminalLabel: "A01",
+			wantQRString:      customQRString,
+		},
+		{
+			name:              "Success: Default Profile Ignores Custom Tag",
+			profile:           DefaultProfile(),
+			qrString:          customQRString,
+			wantTerminalLabel: "",
+			wantQRString:      "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta610555000630494D0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := test.profile
+			uc := NewQRISFromProfile(profile)
+			profile.Tags.AdditionalInformation = "99"
+
+			qris, err, errs := uc.Parse(test.qrString)
+			if err != nil {
+				t.Fatalf(expectedErrorButGotMessage, "Parse()", nil, errs)
+			}
+			if got := qris.AdditionalInformation.Detail.TerminalLabel.Content; got != test.wantTerminalLabel {
+				t.Errorf(expectedButGotMessage, "TerminalLabel", test.wantTerminalLabel, got)
+			}
+			if got := uc.ToString(qris); got != test.wantQRString {
+				t.Errorf(expectedButGotMessage, "ToString()", test.wantQRString, got)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ type QRISInterface interface {
 	Parse(qrString string) (*entities.QRIS, error, *[]string)
 	IsValid(qris *entities.QRIS) bool
 	Modify(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS
+	Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS
 	ToString(qris *entities.QRIS) string
 }
 
@@ -61,26 +62,13 @@ func (uc *QRIS) Parse(qrString string) (*entities.QRIS, error, *[]string) {
 }
 
 func (uc *QRIS) IsValid(qris *entities.QRIS) bool {
-	qrStringConverted := qris.Version.Data +
-		qris.Category.Data +
-		qris.Acquirer.Data +
-		qris.Switching.Data +
-		qris.MerchantCategoryCode.Data +
-		qris.CurrencyCode.Data +
-		qris.PaymentAmount.Data +
-		qris.PaymentFeeCategory.Data +
-		qris.PaymentFee.Data +
-		qris.CountryCode.Data +
-		qris.MerchantName.Data +
-		qris.MerchantCity.Data +
-		qris.MerchantPostalCode.Data +
-		qris.AdditionalInformation.Data +
-		qris.CRCCode.Tag + "04"
-
-	return qris.CRCCode.Content == uc.qrisUsecases.CRC16CCITT.GenerateCode(qrStringConverted)
+	return qris.CRCCode.Content == uc.qrisUsecases.CRC16CCITT.GenerateCode(uc.crcPayload(qris))
 }
 
+// Modify returns a dynamic copy of qris and leaves qris untouched, so one
+// parsed template may be modified from several goroutines.
 func (uc *QRIS) Modify(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS {
+	qris = qris.Clone()
 	qris.Category = entities.Data{
 		Tag:     qris.Category.Tag,
 		Content: uc.qrisCategoryContents.Dynamic,
@@ -120,7 +108,65 @@ func (uc *QRIS) Modify(qris *entities.QRIS, merchantCityValue string, merchantPo
 		}
 	}
 
-	qrStringConverted := qris.Version.Data +
+	content = uc.qrisUsecases.CRC16CCITT.GenerateCode(uc.crcPayload(qris))
+	qris.CRCCode = *uc.qrisUsecases.Data.ModifyContent(&qris.CRCCode, content)
+
+	return qris
+}
+
+// Patch returns a dynamic copy of qris with patch applied and, like Modify,
+// leaves qris untouched.
+func (uc *QRIS) Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+	qris = qris.Clone()
+	qris.Category = entities.Data{
+		Tag:     qris.Category.Tag,
+		Content: uc.qrisCategoryContents.Dynamic,
+		Data:    qris.Category.Tag + fmt.Sprintf("%02d", len(uc.qrisCategoryContents.Dynamic)) + uc.qrisCategoryContents.Dynamic,
+	}
+
+	if patch.PaymentAmount != "" {
+		qris.PaymentAmount = *uc.qrisUsecases.Data.ModifyContent(&entities.Data{Tag: uc.qrisTags.PaymentAmount}, patch.PaymentAmount)
+	}
+
+	if qris.Acquirer.Tag == uc.qrisTags.Acquirer {
+		if patch.MerchantCity != nil {
+			qris.MerchantCity = *uc.qrisUsecases.Data.ModifyContent(&entities.Data{Tag: uc.qrisTags.MerchantCity}, *patch.MerchantCity)
+		}
+		if patch.MerchantPostalCode != nil {
+			qris.MerchantPostalCode = *uc.qrisUsecases.Data.ModifyContent(&entities.Data{Tag: uc.qrisTags.MerchantPostalCode}, *patch.MerchantPostalCode)
+		}
+
+		if patch.PaymentFee != nil {
+			qris.PaymentFeeCategory = entities.Data{}
+			qris.PaymentFee = entities.Data{}
+			if patch.PaymentFee.Category != "" {
+				qris = uc.qrisUsecases.PaymentFee.ModifyContent(qris, patch.PaymentFee.Category, patch.PaymentFee.Value)
+			}
+		}
+	}
+
+	if patch.AdditionalInformation != nil {
+		detail := uc.qrisUsecases.AdditionalInformation.Patch(&qris.AdditionalInformation.Detail, patch.AdditionalInformation)
+		qrisAdditionalInformationContent := uc.qrisUsecases.AdditionalInformation.ToString(detail)
+		qris.AdditionalInformation = entities.AdditionalInformation{}
+		if qrisAdditionalInformationContent != "" {
+			qris.AdditionalInformation = entities.AdditionalInformation{
+				Tag:     uc.qrisTags.AdditionalInformation,
+				Content: qrisAdditionalInformationContent,
+				Data:    uc.qrisTags.AdditionalInformation + fmt.Sprintf("%02d", len(qrisAdditionalInformationContent)) + qrisAdditionalInformationContent,
+				Detail:  *detail,
+			}
+		}
+	}
+
+	content := uc.qrisUsecases.CRC16CCITT.GenerateCode(uc.crcPayload(qris))
+	qris.CRCCode = *uc.qrisUsecases.Data.ModifyContent(&qris.CRCCode, content)
+
+	return qris
+}
+
+func (uc *QRIS) ToString(qris *entities.QRIS) string {
+	return qris.Version.Data +
 		qris.Category.Data +
 		qris.Acquirer.Data +
 		qris.Switching.Data +
@@ -134,14 +180,12 @@ func (uc *QRIS) Modify(qris *entities.QRIS, merchantCityValue string, merchantPo
 		qris.MerchantCity.Data +
 		qris.MerchantPostalCode.Data +
 		qris.AdditionalInformation.Data +
-		qris.CRCCode.Tag + "04"
-	content = uc.qrisUsecases.CRC16CCITT.GenerateCode(qrStringConverted)
-	qris.CRCCode = *uc.qrisUsecases.Data.ModifyContent(&qris.CRCCode, content)
-
-	return qris
+		qris.CRCCode.Data
 }
 
-func (uc *QRIS) ToString(qris *entities.QRIS) string {
+// crcPayload is everything the CRC16-CCITT code is computed over, which ends
+// with the tag and length of the CRC field itself.
+func (uc *QRIS) crcPayload(qris *entities.QRIS) string {
 	return qris.Version.Data +
 		qris.Category.Data +
 		qris.Acquirer.Data +
@@ -156,5 +200,5 @@ func (uc *QRIS) ToString(qris *entities.QRIS) string {
 		qris.MerchantCity.Data +
 		qris.MerchantPostalCode.Data +
 		qris.AdditionalInformation.Data +
-		qris.CRCCode.Data
+		qris.CRCCode.Tag + "04"
 }
@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+	"github.com/fyvri/go-qris/pkg/utils"
+)
+
+const qrisCacheName = "qris_template"
+
+type QRISCache struct {
+	qrisUsecase          QRISInterface
+	qrisCategoryContents *QRISCategoryContents
+	size                 int
+	ttl                  time.Duration
+	metrics              utils.MetricsInterface
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+type qrisCacheEntry struct {
+	qrString string
+	qris     entities.QRIS
+	expires  time.Time
+}
+
+// NewQRISCache keeps up to size parsed static templates for ttl, 0 keeping
+// them until evicted, so repeated QR strings skip parsing and validation.
+// Callers still get their own copy, so nothing they do to the entity can
+// reach the cached template.
+func NewQRISCache(qrisUsecase QRISInterface, qrisCategoryContents *QRISCategoryContents, size int, ttl time.Duration, metrics utils.MetricsInterface) QRISInterface {
+	return &QRISCache{
+		qrisUsecase:          qrisUsecase,
+		qrisCategoryContents: qrisCategoryContents,
+		size:                 size,
+		ttl:                  ttl,
+		metrics:              metrics,
+		entries:              map[string]*list.Element{},
+		order:                list.New(),
+		now:                  time.Now,
+	}
+}
+
+func (uc *QRISCache) Parse(qrString string) (*entities.QRIS, error, *[]string) {
+	if qris, hit := uc.get(qrString); hit {
+		uc.observe(true)
+		return qris, nil, nil
+	}
+	uc.observe(false)
+
+	qris, err, errs := uc.qrisUsecase.Parse(qrString)
+	if err != nil {
+		return nil, err, errs
+	}
+	// Dynamic QRIS carry a different amount on every call and would only
+	// push the templates out.
+	if qris.Category.Content == uc.qrisCategoryContents.Static {
+		uc.put(qrString, qris)
+	}
+
+	return qris, nil, nil
+}
+
+func (uc *QRISCache) IsValid(qris *entities.QRIS) bool {
+	return uc.qrisUsecase.IsValid(qris)
+}
+
+func (uc *QRISCache) Modify(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS {
+	return uc.qrisUsecase.Modify(qris, merchantCityValue, merchantPostalCodeValue, paymentAmountValue, paymentFeeCategoryValue, paymentFeeValue, terminalLabelValue)
+}
+
+func (uc *QRISCache) Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+	return uc.qrisUsecase.Patch(qris, patch)
+}
+
+func (uc *QRISCache) ToString(qris *entities.QRIS) string {
+	return uc.qrisUsecase.ToString(qris)
+}
+
+// get returns a copy of the cached template, entities.QRIS only holds strings
+// so copying the value copies it deeply.
+func (uc *QRISCache) get(qrString string) (*entities.QRIS, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	element, exists := uc.entries[qrString]
+	if !exists {
+		return nil, false
+	}
+	entry := element.Value.(*qrisCacheEntry)
+	if uc.ttl > 0 && !uc.now().Before(entry.expires) {
+		uc.order.Remove(element)
+		delete(uc.entries, qrString)
+		return nil, false
+	}
+	uc.order.MoveToFront(element)
+	qris := entry.qris
+
+	return &qris, true
+}
+
+func (uc *QRISCache) put(qrString string, qris *entities.QRIS) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	entry := &qrisCacheEntry{
+		qrString: qrString,
+		qris:     *qris,
+		expires:  uc.now().Add(uc.ttl),
+	}
+	if element, exists := uc.entries[qrString]; exists {
+		element.Value = entry
+		uc.order.MoveToFront(element)
+		return
+	}
+	uc.entries[qrString] = uc.order.PushFront(entry)
+
+	for uc.order.Len() > uc.size {
+		oldest := uc.order.Back()
+		uc.order.Remove(oldest)
+		delete(uc.entries, oldest.Value.(*qrisCacheEntry).qrString)
+	}
+}
+
+func (uc *QRISCache) observe(hit bool) {
+	if uc.metrics != nil {
+		uc.metrics.ObserveCache(qrisCacheName, hit)
+	}
+}
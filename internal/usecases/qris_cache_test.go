@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fyvri/go-qris/internal/domain/entities"
+)
+
+func TestQRISCacheParse(t *testing.T) {
+	dynamicQRIS := testQRIS
+	dynamicQRIS.Category.Content = testCategoryDynamicContent
+
+	tests := []struct {
+		name       string
+		size       int
+		ttl        time.Duration
+		parsed     entities.QRIS
+		parseErr   error
+		qrStrings  []string
+		advance    time.Duration
+		wantParses int
+		wantHits   int
+	}{
+		{
+			name:       "Success: Hit Repeated Static QRIS",
+			size:       2,
+			parsed:     testQRIS,
+			qrStrings:  []string{"a", "a", "a"},
+			wantParses: 1,
+			wantHits:   2,
+		},
+		{
+			name:       "Success: Skip Dynamic QRIS",
+			size:       2,
+			parsed:     dynamicQRIS,
+			qrStrings:  []string{"a", "a"},
+			wantParses: 2,
+		},
+		{
+			name:       "Success: Evict Least Recently Used",
+			size:       2,
+			parsed:     testQRIS,
+			qrStrings:  []string{"a", "b", "a", "c", "a", "b"},
+			wantParses: 4,
+			wantHits:   2,
+		},
+		{
+			name:       "Success: Expire After TTL",
+			size:       2,
+			ttl:        time.Minute,
+			parsed:     testQRIS,
+			qrStrings:  []string{"a", "a"},
+			advance:    time.Minute,
+			wantParses: 2,
+		},
+		{
+			name:       "Error: Parse Not Cached",
+			size:       2,
+			parseErr:   fmt.Errorf("invalid QRIS format"),
+			qrStrings:  []string{"a", "a"},
+			wantParses: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parses, hits := 0, 0
+			qrisUsecase := &mockQRISUsecase{
+				ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+					parses++
+					if test.parseErr != nil {
+						return nil, test.parseErr, nil
+					}
+					qris := test.parsed
+					return &qris, nil, nil
+				},
+			}
+			metrics := &mockMetrics{
+				ObserveCacheFunc: func(cache string, hit bool) {
+					if hit {
+						hits++
+					}
+				},
+			}
+			uc := NewQRISCache(qrisUsecase, &QRISCategoryContents{Static: testCategoryStaticContent, Dynamic: testCategoryDynamicContent}, test.size, test.ttl, metrics).(*QRISCache)
+			now := time.Now()
+			uc.now = func() time.Time { return now }
+
+			for _, qrString := range test.qrStrings {
+				got, err, _ := uc.Parse(qrString)
+				if (err != nil) != (test.parseErr != nil) {
+					t.Fatalf(expectedErrorButGotMessage, "Parse()", test.parseErr, err)
+				}
+				if err == nil && !reflect.DeepEqual(*got, test.parsed) {
+					t.Errorf(expectedButGotMessage, "Parse()", test.parsed, *got)
+				}
+				now = now.Add(test.advance)
+			}
+
+			if parses != test.wantParses {
+				t.Errorf(expectedButGotMessage, "parses", test.wantParses, parses)
+			}
+			if hits != test.wantHits {
+				t.Errorf(expectedButGotMessage, "hits", test.wantHits, hits)
+			}
+		})
+	}
+}
+
+func TestQRISCacheParseReturnsCopy(t *testing.T) {
+	uc := NewQRISCache(&mockQRISUsecase{
+		ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+			qris := testQRIS
+			return &qris, nil, nil
+		},
+	}, &QRISCategoryContents{Static: testCategoryStaticContent, Dynamic: testCategoryDynamicContent}, 1, 0, nil)
+
+	first, _, _ := uc.Parse("a")
+	first.PaymentAmount.Content = "1337"
+	first.AdditionalInformation.Detail.TerminalLabel.Content = "A01"
+
+	second, _, _ := uc.Parse("a")
+	if !reflect.DeepEqual(*second, testQRIS) {
+		t.Errorf(expectedButGotMessage, "cached template", testQRIS, *second)
+	}
+	second.MerchantCity.Content = "Jakarta"
+
+	third, _, _ := uc.Parse("a")
+	if !reflect.DeepEqual(*third, testQRIS) {
+		t.Errorf(expectedButGotMessage, "cached template", testQRIS, *third)
+	}
+}
+
+func TestQRISCacheParseConcurrent(t *testing.T) {
+	uc := NewQRISCache(&mockQRISUsecase{
+		ParseFunc: func(qrString string) (*entities.QRIS, error, *[]string) {
+			qris := testQRIS
+			return &qris, nil, nil
+		},
+	}, &QRISCategoryContents{Static: testCategoryStaticContent, Dynamic: testCategoryDynamicContent}, 4, time.Minute, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				qris, _, _ := uc.Parse(fmt.Sprintf("%d", j%8))
+				qris.PaymentAmount.Content = fmt.Sprintf("%d", j)
+			}
+		}()
+	}
+	wg.Wait()
+}
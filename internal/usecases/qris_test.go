@@ -3,6 +3,7 @@ package usecases
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
@@ -473,10 +474,14 @@ func TestQRISModify(t *testing.T) {
 				},
 			}
 
+			input := test.args.qris
 			got := uc.Modify(&test.args.qris, testMerchantCityContent, testMerchantPostalCodeContent, testPaymentAmountValue, test.args.paymentFeeCategory, test.args.paymentFee, test.args.terminalLabel)
 			if !reflect.DeepEqual(got, test.want) {
 				t.Errorf(expectedButGotMessage, "Modify()", test.want, got)
 			}
+			if !reflect.DeepEqual(test.args.qris, input) {
+				t.Errorf(expectedButGotMessage, "Modify() input", input, test.args.qris)
+			}
 		})
 	}
 }
@@ -694,3 +699,293 @@ func TestQRISIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestQRISPatch(t *testing.T) {
+	text := func(value string) *string {
+		return &value
+	}
+	qrisTags := &QRISTags{
+		Version:               testVersionTag,
+		Category:              testCategoryTag,
+		Acquirer:              testAcquirerTag,
+		AcquirerBankTransfer:  testAcquirerBankTransferTag,
+		Switching:             testSwitchingTag,
+		MerchantCategoryCode:  testMerchantCategoryCodeTag,
+		CurrencyCode:          testCurrencyCodeTag,
+		PaymentAmount:         testPaymentAmountTag,
+		PaymentFeeCategory:    testPaymentFeeCategoryTag,
+		PaymentFeeFixed:       testPaymentFeeFixedTag,
+		PaymentFeePercent:     testPaymentFeePercentTag,
+		CountryCode:           testCountryCodeTag,
+		MerchantName:          testMerchantNameTag,
+		MerchantCity:          testMerchantCityTag,
+		MerchantPostalCode:    testMerchantPostalCodeTag,
+		AdditionalInformation: testAdditionalInformationTag,
+		CRCCode:               testCRCCodeTag,
+	}
+	qrisPaymentFeeCategoryContents := &QRISPaymentFeeCategoryContents{
+		Fixed:   testPaymentFeeCategoryFixedContent,
+		Percent: testPaymentFeeCategoryPercentContent,
+	}
+	dataUsecase := NewData()
+	uc := &QRIS{
+		qrisUsecases: &QRISUsecases{
+			Data:       dataUsecase,
+			PaymentFee: NewPaymentFee(qrisTags, qrisPaymentFeeCategoryContents),
+			AdditionalInformation: NewAdditionalInformation(dataUsecase, &AdditionalInformationDetailTags{
+				BillNumber:    testAdditionalInformationDetailBillNumberTag,
+				TerminalLabel: testAdditionalInformationDetailTerminalLabelTag,
+			}),
+			CRC16CCITT: NewCRC16CCITT(),
+		},
+		qrisTags: qrisTags,
+		qrisCategoryContents: &QRISCategoryContents{
+			Static:  testCategoryStaticContent,
+			Dynamic: testCategoryDynamicContent,
+		},
+		qrisPaymentFeeCategoryContents: qrisPaymentFeeCategoryContents,
+	}
+
+	type want struct {
+		paymentAmount         string
+		paymentFee            string
+		merchantCity          string
+		merchantPostalCode    string
+		additionalInformation string
+	}
+
+	tests := []struct {
+		name     string
+		acquirer string
+		patch    *entities.QRISPatch
+		want     want
+	}{
+		{
+			name:     "Success: Full Patch",
+			acquirer: testAcquirerTag,
+			patch: &entities.QRISPatch{
+				PaymentAmount: "1337.50",
+				PaymentFee: &entities.PaymentFeePatch{
+					Category: "FIXED",
+					Value:    "666.50",
+				},
+				MerchantCity:       text("Sleman"),
+				MerchantPostalCode: text(""),
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					BillNumber:    text("INV-1"),
+					TerminalLabel: text(""),
+				},
+			},
+			want: want{
+				paymentAmount:         testPaymentAmountTag + "071337.50",
+				paymentFee:            testPaymentFeeFixedTag + "06666.50",
+				merchantCity:          testMerchantCityTag + "06Sleman",
+				merchantPostalCode:    "",
+				additionalInformation: testAdditionalInformationTag + "09" + testAdditionalInformationDetailBillNumberTag + "05INV-1",
+			},
+		},
+		{
+			name:     "Success: Nil Fields Are Kept",
+			acquirer: testAcquirerTag,
+			patch: &entities.QRISPatch{
+				PaymentAmount: "10000",
+			},
+			want: want{
+				paymentAmount:         testPaymentAmountTag + "0510000",
+				paymentFee:            testQRIS.PaymentFee.Data,
+				merchantCity:          testQRIS.MerchantCity.Data,
+				merchantPostalCode:    testQRIS.MerchantPostalCode.Data,
+				additionalInformation: testQRIS.AdditionalInformation.Data,
+			},
+		},
+		{
+			name:     "Success: Removing Every Subtag Removes Additional Information",
+			acquirer: testAcquirerTag,
+			patch: &entities.QRISPatch{
+				PaymentAmount: "10000",
+				PaymentFee:    &entities.PaymentFeePatch{},
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					TerminalLabel: text(""),
+				},
+			},
+			want: want{
+				paymentAmount:         testPaymentAmountTag + "0510000",
+				paymentFee:            "",
+				merchantCity:          testQRIS.MerchantCity.Data,
+				merchantPostalCode:    testQRIS.MerchantPostalCode.Data,
+				additionalInformation: "",
+			},
+		},
+		{
+			name:     "Success: Bank Transfer Acquirer Keeps Merchant Fields",
+			acquirer: testAcquirerBankTransferTag,
+			patch: &entities.QRISPatch{
+				PaymentAmount: "10000",
+				PaymentFee:    &entities.PaymentFeePatch{},
+				MerchantCity:  text("Sleman"),
+			},
+			want: want{
+				paymentAmount:         testPaymentAmountTag + "0510000",
+				paymentFee:            testQRIS.PaymentFee.Data,
+				merchantCity:          testQRIS.MerchantCity.Data,
+				merchantPostalCode:    testQRIS.MerchantPostalCode.Data,
+				additionalInformation: testQRIS.AdditionalInformation.Data,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qris := testQRIS
+			qris.Acquirer.Tag = test.acquirer
+
+			got := uc.Patch(&qris, test.patch)
+			if got.Category.Content != testCategoryDynamicContent {
+				t.Errorf(expectedButGotMessage, "Patch() category", testCategoryDynamicContent, got.Category.Content)
+			}
+			gotWant := want{
+				paymentAmount:         got.PaymentAmount.Data,
+				paymentFee:            got.PaymentFee.Data,
+				merchantCity:          got.MerchantCity.Data,
+				merchantPostalCode:    got.MerchantPostalCode.Data,
+				additionalInformation: got.AdditionalInformation.Data,
+			}
+			if gotWant != test.want {
+				t.Errorf(expectedButGotMessage, "Patch()", test.want, gotWant)
+			}
+			if !uc.IsValid(got) {
+				t.Errorf(expectedButGotMessage, "IsValid()", true, false)
+			}
+		})
+	}
+}
+
+func TestQRISModifyConcurrent(t *testing.T) {
+	qrisTags := &QRISTags{
+		Version:               testVersionTag,
+		Category:              testCategoryTag,
+		Acquirer:              testAcquirerTag,
+		AcquirerBankTransfer:  testAcquirerBankTransferTag,
+		Switching:             testSwitchingTag,
+		MerchantCategoryCode:  testMerchantCategoryCodeTag,
+		CurrencyCode:          testCurrencyCodeTag,
+		PaymentAmount:         testPaymentAmountTag,
+		PaymentFeeCategory:    testPaymentFeeCategoryTag,
+		PaymentFeeFixed:       testPaymentFeeFixedTag,
+		PaymentFeePercent:     testPaymentFeePercentTag,
+		CountryCode:           testCountryCodeTag,
+		MerchantName:          testMerchantNameTag,
+		MerchantCity:          testMerchantCityTag,
+		MerchantPostalCode:    testMerchantPostalCodeTag,
+		AdditionalInformation: testAdditionalInformationTag,
+		CRCCode:               testCRCCodeTag,
+	}
+	qrisPaymentFeeCategoryContents := &QRISPaymentFeeCategoryContents{
+		Fixed:   testPaymentFeeCategoryFixedContent,
+		Percent: testPaymentFeeCategoryPercentContent,
+	}
+	dataUsecase := NewData()
+	uc := NewQRIS(&QRISUsecases{
+		Data:       dataUsecase,
+		PaymentFee: NewPaymentFee(qrisTags, qrisPaymentFeeCategoryContents),
+		AdditionalInformation: NewAdditionalInformation(dataUsecase, &AdditionalInformationDetailTags{
+			TerminalLabel: testAdditionalInformationDetailTerminalLabelTag,
+		}),
+		CRC16CCITT: NewCRC16CCITT(),
+	}, qrisTags, &QRISCategoryContents{
+		Static:  testCategoryStaticContent,
+		Dynamic: testCategoryDynamicContent,
+	}, qrisPaymentFeeCategoryContents)
+
+	shared := testQRIS
+	var wg sync.WaitGroup
+	for i := 1; i <= 32; i++ {
+		wg.Add(1)
+		go func(amount uint32) {
+			defer wg.Done()
+			got := uc.Modify(&shared, "Kota Yogyakarta", "55000", amount, "PERCENT", 1, fmt.Sprintf("T%d", amount))
+			if want := fmt.Sprintf("%d", amount); got.PaymentAmount.Content != want {
+				t.Errorf(expectedButGotMessage, "Modify() payment amount", want, got.PaymentAmount.Content)
+			}
+			if !uc.IsValid(got) {
+				t.Errorf(expectedButGotMessage, "Modify() CRC valid", true, false)
+			}
+		}(uint32(i * 1000))
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(shared, testQRIS) {
+		t.Errorf(expectedButGotMessage, "shared QRIS", testQRIS, shared)
+	}
+}
+
+func TestQRISPatchConcurrent(t *testing.T) {
+	qrisTags := &QRISTags{
+		Version:               testVersionTag,
+		Category:              testCategoryTag,
+		Acquirer:              testAcquirerTag,
+		AcquirerBankTransfer:  testAcquirerBankTransferTag,
+		Switching:             testSwitchingTag,
+		MerchantCategoryCode:  testMerchantCategoryCodeTag,
+		CurrencyCode:          testCurrencyCodeTag,
+		PaymentAmount:         testPaymentAmountTag,
+		PaymentFeeCategory:    testPaymentFeeCategoryTag,
+		PaymentFeeFixed:       testPaymentFeeFixedTag,
+		PaymentFeePercent:     testPaymentFeePercentTag,
+		CountryCode:           testCountryCodeTag,
+		MerchantName:          testMerchantNameTag,
+		MerchantCity:          testMerchantCityTag,
+		MerchantPostalCode:    testMerchantPostalCodeTag,
+		AdditionalInformation: testAdditionalInformationTag,
+		CRCCode:               testCRCCodeTag,
+	}
+	qrisPaymentFeeCategoryContents := &QRISPaymentFeeCategoryContents{
+		Fixed:   testPaymentFeeCategoryFixedContent,
+		Percent: testPaymentFeeCategoryPercentContent,
+	}
+	dataUsecase := NewData()
+	uc := NewQRIS(&QRISUsecases{
+		Data:       dataUsecase,
+		PaymentFee: NewPaymentFee(qrisTags, qrisPaymentFeeCategoryContents),
+		AdditionalInformation: NewAdditionalInformation(dataUsecase, &AdditionalInformationDetailTags{
+			BillNumber:    testAdditionalInformationDetailBillNumberTag,
+			TerminalLabel: testAdditionalInformationDetailTerminalLabelTag,
+		}),
+		CRC16CCITT: NewCRC16CCITT(),
+	}, qrisTags, &QRISCategoryContents{
+		Static:  testCategoryStaticContent,
+		Dynamic: testCategoryDynamicContent,
+	}, qrisPaymentFeeCategoryContents)
+
+	shared := testQRIS
+	var wg sync.WaitGroup
+	for i := 1; i <= 32; i++ {
+		wg.Add(1)
+		go func(amount int) {
+			defer wg.Done()
+			city, bill := "Sleman", fmt.Sprintf("INV-%d", amount)
+			got := uc.Patch(&shared, &entities.QRISPatch{
+				PaymentAmount: fmt.Sprintf("%d", amount),
+				PaymentFee:    &entities.PaymentFeePatch{Category: "PERCENT", Value: "1"},
+				MerchantCity:  &city,
+				AdditionalInformation: &entities.AdditionalInformationPatch{
+					BillNumber: &bill,
+				},
+			})
+			if want := fmt.Sprintf("%d", amount); got.PaymentAmount.Content != want {
+				t.Errorf(expectedButGotMessage, "Patch() payment amount", want, got.PaymentAmount.Content)
+			}
+			if got.AdditionalInformation.Detail.BillNumber.Content != bill {
+				t.Errorf(expectedButGotMessage, "Patch() bill number", bill, got.AdditionalInformation.Detail.BillNumber.Content)
+			}
+			if !uc.IsValid(got) {
+				t.Errorf(expectedButGotMessage, "Patch() CRC valid", true, false)
+			}
+		}(i * 1000)
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(shared, testQRIS) {
+		t.Errorf(expectedButGotMessage, "shared QRIS", testQRIS, shared)
+	}
+}
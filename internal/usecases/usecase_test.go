@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"io"
+	"time"
+
 	"github.com/fyvri/go-qris/internal/domain/entities"
 )
 
@@ -219,7 +222,8 @@ func (m *mockSwitchingUsecase) Parse(content string) (*entities.SwitchingDetail,
 }
 
 type mockPaymentFeeUsecase struct {
-	ModifyFunc func(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeValue uint32) *entities.QRIS
+	ModifyFunc        func(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeValue uint32) *entities.QRIS
+	ModifyContentFunc func(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeContent string) *entities.QRIS
 }
 
 func (m *mockPaymentFeeUsecase) Modify(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeValue uint32) *entities.QRIS {
@@ -229,9 +233,17 @@ func (m *mockPaymentFeeUsecase) Modify(qris *entities.QRIS, paymentFeeCategoryVa
 	return nil
 }
 
+func (m *mockPaymentFeeUsecase) ModifyContent(qris *entities.QRIS, paymentFeeCategoryValue string, paymentFeeContent string) *entities.QRIS {
+	if m.ModifyContentFunc != nil {
+		return m.ModifyContentFunc(qris, paymentFeeCategoryValue, paymentFeeContent)
+	}
+	return nil
+}
+
 type mockAdditionalInformationUsecase struct {
 	ParseFunc    func(content string) (*entities.AdditionalInformationDetail, error)
 	ToStringFunc func(additionalInformationDetail *entities.AdditionalInformationDetail) string
+	PatchFunc    func(additionalInformationDetail *entities.AdditionalInformationDetail, patch *entities.AdditionalInformationPatch) *entities.AdditionalInformationDetail
 }
 
 func (m *mockAdditionalInformationUsecase) Parse(content string) (*entities.AdditionalInformationDetail, error) {
@@ -248,6 +260,13 @@ func (m *mockAdditionalInformationUsecase) ToString(additionalInformationDetail
 	return ""
 }
 
+func (m *mockAdditionalInformationUsecase) Patch(additionalInformationDetail *entities.AdditionalInformationDetail, patch *entities.AdditionalInformationPatch) *entities.AdditionalInformationDetail {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(additionalInformationDetail, patch)
+	}
+	return nil
+}
+
 type mockCRC16CCITTUsecase struct {
 	GenerateCodeFunc func(code string) string
 }
@@ -297,3 +316,85 @@ func (m *mockFieldUsecase) IsValid(qris *entities.QRIS, errs *[]string) {
 	}
 	return
 }
+
+type mockQRISUsecase struct {
+	ParseFunc    func(qrString string) (*entities.QRIS, error, *[]string)
+	IsValidFunc  func(qris *entities.QRIS) bool
+	ModifyFunc   func(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS
+	PatchFunc    func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS
+	ToStringFunc func(qris *entities.QRIS) string
+}
+
+func (m *mockQRISUsecase) Parse(qrString string) (*entities.QRIS, error, *[]string) {
+	if m.ParseFunc != nil {
+		return m.ParseFunc(qrString)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockQRISUsecase) IsValid(qris *entities.QRIS) bool {
+	if m.IsValidFunc != nil {
+		return m.IsValidFunc(qris)
+	}
+	return false
+}
+
+func (m *mockQRISUsecase) Modify(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS {
+	if m.ModifyFunc != nil {
+		return m.ModifyFunc(qris, merchantCityValue, merchantPostalCodeValue, paymentAmountValue, paymentFeeCategoryValue, paymentFeeValue, terminalLabelValue)
+	}
+	return nil
+}
+
+func (m *mockQRISUsecase) Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(qris, patch)
+	}
+	return nil
+}
+
+func (m *mockQRISUsecase) ToString(qris *entities.QRIS) string {
+	if m.ToStringFunc != nil {
+		return m.ToStringFunc(qris)
+	}
+	return ""
+}
+
+type mockMetrics struct {
+	ObserveRequestFunc   func(method string, route string, status int, duration time.Duration)
+	ObserveOperationFunc func(operation string, code string, issues []string, duration time.Duration)
+	ObserveRenderFunc    func(kind string, format string, qrString string, duration time.Duration)
+	ObserveCacheFunc     func(cache string, hit bool)
+	WriteTextFunc        func(w io.Writer) error
+}
+
+func (m *mockMetrics) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	if m.ObserveRequestFunc != nil {
+		m.ObserveRequestFunc(method, route, status, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveOperation(operation string, code string, issues []string, duration time.Duration) {
+	if m.ObserveOperationFunc != nil {
+		m.ObserveOperationFunc(operation, code, issues, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveRender(kind string, format string, qrString string, duration time.Duration) {
+	if m.ObserveRenderFunc != nil {
+		m.ObserveRenderFunc(kind, format, qrString, duration)
+	}
+}
+
+func (m *mockMetrics) ObserveCache(cache string, hit bool) {
+	if m.ObserveCacheFunc != nil {
+		m.ObserveCacheFunc(cache, hit)
+	}
+}
+
+func (m *mockMetrics) WriteText(w io.Writer) error {
+	if m.WriteTextFunc != nil {
+		return m.WriteTextFunc(w)
+	}
+	return nil
+}
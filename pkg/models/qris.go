@@ -17,3 +17,10 @@ type QRIS struct {
 	AdditionalInformation AdditionalInformation
 	CRCCode               Data
 }
+
+// Clone returns a deep copy of qris. Every field is a value, so copying the
+// struct is enough; Clone has to follow along if that ever changes.
+func (qris *QRIS) Clone() *QRIS {
+	clone := *qris
+	return &clone
+}
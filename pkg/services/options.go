@@ -0,0 +1,138 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fyvri/go-qris/internal/config"
+	"github.com/fyvri/go-qris/internal/domain/entities"
+)
+
+// Option sets one field of a dynamic QRIS for ConvertWithOptions and
+// ModifyWithOptions. Fields without an option are kept as they are.
+type Option func(*options)
+
+type options struct {
+	patch entities.QRISPatch
+}
+
+// WithAmount sets the payment amount, a decimal such as "1337" or "1337.50".
+func WithAmount(amount string) Option {
+	return func(o *options) {
+		o.patch.PaymentAmount = amount
+	}
+}
+
+// WithFixedFee adds a fixed convenience fee, a decimal like the amount.
+func WithFixedFee(fee string) Option {
+	return func(o *options) {
+		o.patch.PaymentFee = &entities.PaymentFeePatch{Category: "FIXED", Value: fee}
+	}
+}
+
+// WithPercentFee adds a convenience fee in percent of the amount, at most 100.
+func WithPercentFee(percent string) Option {
+	return func(o *options) {
+		o.patch.PaymentFee = &entities.PaymentFeePatch{Category: "PERCENT", Value: percent}
+	}
+}
+
+// WithoutFee removes the convenience fee of the QRIS.
+func WithoutFee() Option {
+	return func(o *options) {
+		o.patch.PaymentFee = &entities.PaymentFeePatch{}
+	}
+}
+
+func WithMerchantCity(city string) Option {
+	return func(o *options) {
+		o.patch.MerchantCity = &city
+	}
+}
+
+func WithMerchantPostalCode(postalCode string) Option {
+	return func(o *options) {
+		o.patch.MerchantPostalCode = &postalCode
+	}
+}
+
+func WithBillNumber(billNumber string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.BillNumber = &billNumber })
+}
+
+func WithMobileNumber(mobileNumber string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.MobileNumber = &mobileNumber })
+}
+
+func WithStoreLabel(storeLabel string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.StoreLabel = &storeLabel })
+}
+
+func WithLoyaltyNumber(loyaltyNumber string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.LoyaltyNumber = &loyaltyNumber })
+}
+
+func WithReferenceLabel(referenceLabel string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.ReferenceLabel = &referenceLabel })
+}
+
+func WithCustomerLabel(customerLabel string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.CustomerLabel = &customerLabel })
+}
+
+func WithTerminalLabel(terminalLabel string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.TerminalLabel = &terminalLabel })
+}
+
+func WithPurposeOfTransaction(purpose string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.PurposeOfTransaction = &purpose })
+}
+
+// WithAdditionalConsumerDataRequest asks the payer's app for data, e.g. "AME"
+// for address, mobile number and email.
+func WithAdditionalConsumerDataRequest(request string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.AdditionalConsumerDataRequest = &request })
+}
+
+func WithMerchantTaxID(taxID string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.MerchantTaxID = &taxID })
+}
+
+func WithMerchantChannel(channel string) Option {
+	return additionalInformation(func(patch *entities.AdditionalInformationPatch) { patch.MerchantChannel = &channel })
+}
+
+func additionalInformation(set func(patch *entities.AdditionalInformationPatch)) Option {
+	return func(o *options) {
+		if o.patch.AdditionalInformation == nil {
+			o.patch.AdditionalInformation = &entities.AdditionalInformationPatch{}
+		}
+		set(o.patch.AdditionalInformation)
+	}
+}
+
+// sanitizePatch applies opts and checks every field fits its tag. It returns
+// the metrics code of the first problem found. Sanitized values get their own
+// pointers, so options may be reused across goroutines.
+func (s *QRIS) sanitizePatch(opts []Option) (*entities.QRISPatch, string, error, *[]string) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	patch := o.patch.Sanitized(s.inputUtil.Sanitize)
+	if code, err, errs := patch.Validate(); err != nil {
+		return nil, code, err, errs
+	}
+
+	return patch, "", nil, nil
+}
+
+// applyPatch patches a copy of qris, the caller's entity is left untouched.
+func (s *QRIS) applyPatch(qris *entities.QRIS, patch *entities.QRISPatch) (*entities.QRIS, string, error, *[]string) {
+	qris = s.qrisUsecase.Patch(qris, patch)
+	if len(qris.AdditionalInformation.Content) > 99 {
+		return nil, config.ErrorCodeInputTooLong, fmt.Errorf("input length exceeds the maximum permitted characters"), &[]string{"additional information exceeds 99 characters"}
+	}
+
+	return qris, "", nil, nil
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fyvri/go-qris/internal/config"
 	"github.com/fyvri/go-qris/internal/usecases"
@@ -14,114 +17,119 @@ type QRIS struct {
 	crc16CCITTUsecase usecases.CRC16CCITTInterface
 	qrisUsecase       usecases.QRISInterface
 	inputUtil         utils.InputInterface
+	qrCodeUtil        utils.QRCodeInterface
+	stickerUtil       utils.StickerInterface
+	metrics           utils.MetricsInterface
 }
 
 type QRISInterface interface {
 	Parse(qrisString string) (*models.QRIS, error, *[]string)
+	ParseContext(ctx context.Context, qrisString string) (*models.QRIS, error, *[]string)
 	IsValid(qris *models.QRIS) bool
+	IsValidContext(ctx context.Context, qris *models.QRIS) (bool, error)
+	// Deprecated: use ModifyWithOptions.
 	Modify(qris *models.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue int, paymentFeeCategoryValue string, paymentFeeValue int, terminalLabelValue string) (*models.QRIS, error, *[]string)
+	ModifyWithOptions(qris *models.QRIS, opts ...Option) (*models.QRIS, error, *[]string)
 	ToString(qris *models.QRIS) string
+	// Deprecated: use ConvertWithOptions.
 	Convert(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue int, paymentFeeCategoryValue string, paymentFeeValue int, terminalLabelValue string) (string, error, *[]string)
+	ConvertWithOptions(qrisString string, opts ...Option) (string, error, *[]string)
+	ConvertContext(ctx context.Context, qrisString string, opts ...Option) (string, error, *[]string)
+	QRCode(qris *models.QRIS, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	QRCodeContext(ctx context.Context, qris *models.QRIS, qrCodeOptions *utils.QRCodeOptions) ([]byte, error)
+	Sticker(qris *models.QRIS, stickerOptions *utils.StickerOptions) ([]byte, error)
+	StickerContext(ctx context.Context, qris *models.QRIS, stickerOptions *utils.StickerOptions) ([]byte, error)
+}
+
+// Profile holds the tag numbers and contents of an EMVCo national scheme.
+// Start from DefaultProfile and change what differs, e.g.
+//
+//	profile := services.DefaultProfile()
+//	profile.Tags.AdditionalInformation = "64"
+//	qrisService := services.NewQRISWithProfile(profile)
+type Profile = usecases.Profile
+
+// DefaultProfile returns the QRIS profile of Bank Indonesia.
+func DefaultProfile() Profile {
+	return usecases.DefaultProfile()
 }
 
 func NewQRIS() QRISInterface {
-	qrisTags := &usecases.QRISTags{
-		Version:               config.VersionTag,
-		Category:              config.CategoryTag,
-		Acquirer:              config.AcquirerTag,
-		AcquirerBankTransfer:  config.AcquirerBankTransferTag,
-		Switching:             config.SwitchingTag,
-		MerchantCategoryCode:  config.MerchantCategoryCodeTag,
-		CurrencyCode:          config.CurrencyCodeTag,
-		PaymentAmount:         config.PaymentAmountTag,
-		PaymentFeeCategory:    config.PaymentFeeCategoryTag,
-		PaymentFeeFixed:       config.PaymentFeeFixedTag,
-		PaymentFeePercent:     config.PaymentFeePercentTag,
-		CountryCode:           config.CountryCodeTag,
-		MerchantName:          config.MerchantNameTag,
-		MerchantCity:          config.MerchantCityTag,
-		MerchantPostalCode:    config.MerchantPostalCodeTag,
-		AdditionalInformation: config.AdditionalInformationTag,
-		CRCCode:               config.CRCCodeTag,
-	}
-	qrisCategoryContents := &usecases.QRISCategoryContents{
-		Static:  config.CategoryStaticContent,
-		Dynamic: config.CategoryDynamicContent,
-	}
-	qrisPaymentFeeCategoryContents := &usecases.QRISPaymentFeeCategoryContents{
-		Fixed:   config.PaymentFeeCategoryFixedContent,
-		Percent: config.PaymentFeeCategoryPercentContent,
-	}
-	acquirerDetailTags := &usecases.AcquirerDetailTags{
-		Site:       config.AcquirerDetailSiteTag,
-		MPAN:       config.AcquirerDetailMPANTag,
-		TerminalID: config.AcquirerDetailTerminalIDTag,
-		Category:   config.AcquirerDetailCategoryTag,
-	}
-	switchingDetailTags := &usecases.SwitchingDetailTags{
-		Site:     config.SwitchingDetailSiteTag,
-		NMID:     config.SwitchingDetailNMIDTag,
-		Category: config.SwitchingDetailCategoryTag,
-	}
-	qrisAdditionalInformationDetailTags := &usecases.AdditionalInformationDetailTags{
-		BillNumber:                    config.AdditionalInformationDetailBillNumberTag,
-		MobileNumber:                  config.AdditionalInformationDetailMobileNumberTag,
-		StoreLabel:                    config.AdditionalInformationDetailStoreLabelTag,
-		LoyaltyNumber:                 config.AdditionalInformationDetailLoyaltyNumberTag,
-		ReferenceLabel:                config.AdditionalInformationDetailReferenceLabelTag,
-		CustomerLabel:                 config.AdditionalInformationDetailCustomerLabelTag,
-		TerminalLabel:                 config.AdditionalInformationDetailTerminalLabelTag,
-		PurposeOfTransaction:          config.AdditionalInformationDetailPurposeOfTransactionTag,
-		AdditionalConsumerDataRequest: config.AdditionalInformationDetailAdditionalConsumerDataRequestTag,
-		MerchantTaxID:                 config.AdditionalInformationDetailMerchantTaxIDTag,
-		MerchantChannel:               config.AdditionalInformationDetailMerchantChannelTag,
-		RFUStart:                      config.AdditionalInformationDetailRFUTagStart,
-		RFUEnd:                        config.AdditionalInformationDetailRFUTagEnd,
-		PaymentSystemSpecificStart:    config.AdditionalInformationDetailPaymentSystemSpecificTagStart,
-		PaymentSystemSpecificEnd:      config.AdditionalInformationDetailPaymentSystemSpecificTagEnd,
-	}
-
-	dataUsecase := usecases.NewData()
-	acquirerUsecase := usecases.NewAcquirer(dataUsecase, acquirerDetailTags)
-	switchingUsecase := usecases.NewSwitching(dataUsecase, switchingDetailTags)
-	additionalInformationUsecase := usecases.NewAdditionalInformation(dataUsecase, qrisAdditionalInformationDetailTags)
-	fieldUsecase := usecases.NewField(acquirerUsecase, switchingUsecase, additionalInformationUsecase, qrisTags, qrisCategoryContents)
-	paymentFeeUsecase := usecases.NewPaymentFee(qrisTags, qrisPaymentFeeCategoryContents)
-	crc16CCITTUsecase := usecases.NewCRC16CCITT()
-
-	qrisUsecases := &usecases.QRISUsecases{
-		Data:                  dataUsecase,
-		Field:                 fieldUsecase,
-		PaymentFee:            paymentFeeUsecase,
-		AdditionalInformation: additionalInformationUsecase,
-		CRC16CCITT:            crc16CCITTUsecase,
-	}
-	qrisUsecase := usecases.NewQRIS(qrisUsecases, qrisTags, qrisCategoryContents, qrisPaymentFeeCategoryContents)
-	inputUtil := utils.NewInput()
+	return NewQRISWithProfile(DefaultProfile())
+}
 
+// NewQRISWithProfile parses and builds QR strings of the scheme described by
+// profile. Changing profile afterwards does not affect the service.
+func NewQRISWithProfile(profile Profile) QRISInterface {
 	return &QRIS{
-		crc16CCITTUsecase: crc16CCITTUsecase,
-		qrisUsecase:       qrisUsecase,
-		inputUtil:         inputUtil,
+		crc16CCITTUsecase: usecases.NewCRC16CCITT(),
+		qrisUsecase:       usecases.NewQRISFromProfile(profile),
+		inputUtil:         utils.NewInput(),
+		qrCodeUtil:        utils.NewQRCode(),
+		stickerUtil:       utils.NewSticker(),
 	}
 }
 
+// NewQRISWithMetrics is NewQRIS reporting parse, validation, conversion and
+// sticker outcomes into metrics, e.g. a registry served at /metrics.
+func NewQRISWithMetrics(metrics utils.MetricsInterface) QRISInterface {
+	qris := NewQRIS().(*QRIS)
+	qris.metrics = metrics
+
+	return qris
+}
+
 func (s *QRIS) Parse(qrisString string) (*models.QRIS, error, *[]string) {
+	return s.ParseContext(context.Background(), qrisString)
+}
+
+// ParseContext is Parse returning ctx.Err() once ctx is done.
+func (s *QRIS) ParseContext(ctx context.Context, qrisString string) (*models.QRIS, error, *[]string) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		s.observe("parse", start, contextCode(err), nil)
+		return nil, err, nil
+	}
 	qrisString = s.inputUtil.Sanitize(qrisString)
 	qris, err, errs := s.qrisUsecase.Parse(qrisString)
 	if err != nil {
+		s.observe("parse", start, config.ErrorCodeInvalidQRIS, errs)
 		return nil, err, errs
 	}
 
+	s.observe("parse", start, "", nil)
 	return mapQRISEntityToModel(qris), nil, nil
 }
 
 func (s *QRIS) IsValid(qris *models.QRIS) bool {
+	start := time.Now()
 	qrisEntity := mapQRISModelToEntity(qris)
 
-	return s.qrisUsecase.IsValid(qrisEntity)
+	isValid := s.qrisUsecase.IsValid(qrisEntity)
+	if !isValid {
+		s.observe("is_valid", start, config.ErrorCodeInvalidCRC, nil)
+	} else {
+		s.observe("is_valid", start, "", nil)
+	}
+
+	return isValid
 }
 
+// IsValidContext is IsValid returning ctx.Err() once ctx is done.
+func (s *QRIS) IsValidContext(ctx context.Context, qris *models.QRIS) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		s.observe("is_valid", time.Now(), contextCode(err), nil)
+		return false, err
+	}
+
+	return s.IsValid(qris), nil
+}
+
+// Modify returns a new dynamic QRIS and leaves qris untouched, so one parsed
+// QRIS may be shared by concurrent conversions.
+//
+// Deprecated: use ModifyWithOptions, which takes new fields without breaking
+// callers.
 func (s *QRIS) Modify(qris *models.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue int, paymentFeeCategoryValue string, paymentFeeValue int, terminalLabelValue string) (*models.QRIS, error, *[]string) {
 	errs := &[]string{}
 	merchantCityValue = s.inputUtil.Sanitize(merchantCityValue)
@@ -147,6 +155,22 @@ func (s *QRIS) Modify(qris *models.QRIS, merchantCityValue string, merchantPosta
 	return mapQRISEntityToModel(qrisModel), nil, nil
 }
 
+// ModifyWithOptions returns a new dynamic QRIS with the fields set by opts,
+// WithAmount being required, and leaves qris untouched.
+func (s *QRIS) ModifyWithOptions(qris *models.QRIS, opts ...Option) (*models.QRIS, error, *[]string) {
+	patch, _, err, errs := s.sanitizePatch(opts)
+	if err != nil {
+		return nil, err, errs
+	}
+
+	qrisEntity, _, err, errs := s.applyPatch(mapQRISModelToEntity(qris), patch)
+	if err != nil {
+		return nil, err, errs
+	}
+
+	return mapQRISEntityToModel(qrisEntity), nil, nil
+}
+
 func (s *QRIS) ToString(qris *models.QRIS) string {
 	qrisString := qris.Version.Data +
 		qris.Category.Data +
@@ -167,7 +191,10 @@ func (s *QRIS) ToString(qris *models.QRIS) string {
 	return qrisString + s.crc16CCITTUsecase.GenerateCode(qrisString)
 }
 
+// Deprecated: use ConvertWithOptions, which takes new fields without breaking
+// callers.
 func (s *QRIS) Convert(qrisString string, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue int, paymentFeeCategoryValue string, paymentFeeValue int, terminalLabelValue string) (string, error, *[]string) {
+	start := time.Now()
 	errs := &[]string{}
 	merchantCityValue = s.inputUtil.Sanitize(merchantCityValue)
 	if len(merchantCityValue) > 15 {
@@ -182,17 +209,157 @@ func (s *QRIS) Convert(qrisString string, merchantCityValue string, merchantPost
 		*errs = append(*errs, "terminal label exceeds 99 characters")
 	}
 	if len(*errs) > 0 {
+		s.observe("convert", start, config.ErrorCodeInputTooLong, errs)
 		return "", fmt.Errorf("input length exceeds the maximum permitted characters"), errs
 	}
 
 	qrisString = s.inputUtil.Sanitize(qrisString)
 	qrisEntity, err, errs := s.qrisUsecase.Parse(qrisString)
 	if err != nil {
+		s.observe("convert", start, config.ErrorCodeInvalidQRIS, errs)
 		return "", err, errs
 	}
 
 	paymentFeeCategoryValue = strings.ToUpper(s.inputUtil.Sanitize(paymentFeeCategoryValue))
 	qrisEntity = s.qrisUsecase.Modify(qrisEntity, merchantCityValue, merchantPostalCodeValue, uint32(paymentAmountValue), paymentFeeCategoryValue, uint32(paymentFeeValue), terminalLabelValue)
 
+	s.observe("convert", start, "", nil)
+	return s.qrisUsecase.ToString(qrisEntity), nil, nil
+}
+
+// ConvertWithOptions turns a QRIS string into a dynamic one with the fields
+// set by opts, e.g.
+//
+//	qrisService.ConvertWithOptions(qrisString, services.WithAmount("1337"), services.WithFixedFee("666"), services.WithBillNumber("INV-1337"))
+func (s *QRIS) ConvertWithOptions(qrisString string, opts ...Option) (string, error, *[]string) {
+	return s.ConvertContext(context.Background(), qrisString, opts...)
+}
+
+// ConvertContext is ConvertWithOptions returning ctx.Err() once ctx is done.
+func (s *QRIS) ConvertContext(ctx context.Context, qrisString string, opts ...Option) (string, error, *[]string) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		s.observe("convert", start, contextCode(err), nil)
+		return "", err, nil
+	}
+	patch, code, err, errs := s.sanitizePatch(opts)
+	if err != nil {
+		s.observe("convert", start, code, errs)
+		return "", err, errs
+	}
+
+	qrisString = s.inputUtil.Sanitize(qrisString)
+	qrisEntity, err, errs := s.qrisUsecase.Parse(qrisString)
+	if err != nil {
+		s.observe("convert", start, config.ErrorCodeInvalidQRIS, errs)
+		return "", err, errs
+	}
+	if err := ctx.Err(); err != nil {
+		s.observe("convert", start, contextCode(err), nil)
+		return "", err, nil
+	}
+
+	qrisEntity, code, err, errs = s.applyPatch(qrisEntity, patch)
+	if err != nil {
+		s.observe("convert", start, code, errs)
+		return "", err, errs
+	}
+
+	s.observe("convert", start, "", nil)
 	return s.qrisUsecase.ToString(qrisEntity), nil, nil
 }
+
+// QRCode renders qris as a QR code image. Nil or zero options fall back to
+// utils.DefaultQRCodeOptions.
+func (s *QRIS) QRCode(qris *models.QRIS, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	return s.QRCodeContext(context.Background(), qris, qrCodeOptions)
+}
+
+// QRCodeContext is QRCode returning ctx.Err() once ctx is done, checked
+// between the rendering steps.
+func (s *QRIS) QRCodeContext(ctx context.Context, qris *models.QRIS, qrCodeOptions *utils.QRCodeOptions) ([]byte, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		s.observe("qr_code", start, contextCode(err), nil)
+		return nil, err
+	}
+	qrCodeOptions = utils.MergeQRCodeOptions(utils.DefaultQRCodeOptions(), qrCodeOptions)
+	if err := s.qrCodeUtil.ValidateOptions(qrCodeOptions); err != nil {
+		s.observe("qr_code", start, config.ErrorCodeInvalidQRCodeOptions, nil)
+		return nil, err
+	}
+
+	qrisString := s.ToString(qris)
+	qrCode, err := s.qrCodeUtil.StringToFormatContext(ctx, qrisString, qrCodeOptions)
+	if s.metrics != nil {
+		s.metrics.ObserveRender("qr_code", qrCodeOptions.Format, qrisString, time.Since(start))
+	}
+	if err != nil {
+		code := config.ErrorCodeQRCodeNotRenderable
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			code = contextCode(ctxErr)
+		}
+		s.observe("qr_code", start, code, nil)
+		return nil, err
+	}
+
+	s.observe("qr_code", start, "", nil)
+	return qrCode, nil
+}
+
+func (s *QRIS) Sticker(qris *models.QRIS, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	return s.StickerContext(context.Background(), qris, stickerOptions)
+}
+
+// StickerContext is Sticker returning ctx.Err() once ctx is done, checked
+// between the rendering steps.
+func (s *QRIS) StickerContext(ctx context.Context, qris *models.QRIS, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		s.observe("sticker", start, contextCode(err), nil)
+		return nil, err
+	}
+	stickerOptions = utils.MergeStickerOptions(utils.DefaultStickerOptions(), stickerOptions)
+	qrisString := s.ToString(qris)
+	sticker, err := s.stickerUtil.RenderContext(ctx, &utils.StickerContent{
+		QRString:     qrisString,
+		MerchantName: qris.MerchantName.Content,
+		NMID:         qris.Switching.Detail.NMID.Content,
+		TerminalID:   qris.Acquirer.Detail.TerminalID.Content,
+	}, stickerOptions)
+	if s.metrics != nil {
+		s.metrics.ObserveRender("sticker", stickerOptions.Format, qrisString, time.Since(start))
+	}
+	if err != nil {
+		code := config.ErrorCodeStickerNotRenderable
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			code = contextCode(ctxErr)
+		}
+		s.observe("sticker", start, code, nil)
+		return nil, err
+	}
+
+	s.observe("sticker", start, "", nil)
+	return sticker, nil
+}
+
+// contextCode names a done context in metrics with the REST error codes.
+func contextCode(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return config.ErrorCodeDeadlineExceeded
+	}
+
+	return config.ErrorCodeCanceled
+}
+
+func (s *QRIS) observe(operation string, start time.Time, code string, errs *[]string) {
+	if s.metrics == nil {
+		return
+	}
+
+	var issues []string
+	if errs != nil {
+		issues = *errs
+	}
+	s.metrics.ObserveOperation(operation, code, issues, time.Since(start))
+}
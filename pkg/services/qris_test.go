@@ -1,9 +1,15 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
 	"github.com/fyvri/go-qris/internal/usecases"
@@ -96,6 +102,8 @@ func TestNewQRIS(t *testing.T) {
 				crc16CCITTUsecase: crc16CCITTUsecase,
 				qrisUsecase:       qrisUsecase,
 				inputUtil:         inputUtil,
+				qrCodeUtil:        utils.NewQRCode(),
+				stickerUtil:       utils.NewSticker(),
 			},
 		},
 	}
@@ -515,3 +523,455 @@ func TestQRISConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestQRISSticker(t *testing.T) {
+	type args struct {
+		qris           *models.QRIS
+		stickerOptions *utils.StickerOptions
+	}
+
+	tests := []struct {
+		name      string
+		fields    QRIS
+		args      args
+		want      []byte
+		wantError error
+	}{
+		{
+			name: "Error: s.stickerUtil.Render()",
+			fields: QRIS{
+				crc16CCITTUsecase: &mockCRC16CCITTUsecase{},
+				stickerUtil: &mockStickerUtil{
+					RenderFunc: func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+						return nil, fmt.Errorf("unsupported sticker format %s", stickerOptions.Format)
+					},
+				},
+			},
+			args: args{
+				qris: &testQRISModel,
+				stickerOptions: &utils.StickerOptions{
+					Format: "svg",
+				},
+			},
+			want:      nil,
+			wantError: fmt.Errorf("unsupported sticker format svg"),
+		},
+		{
+			name: "Success",
+			fields: QRIS{
+				crc16CCITTUsecase: &mockCRC16CCITTUsecase{
+					GenerateCodeFunc: func(code string) string {
+						return "AZ15"
+					},
+				},
+				stickerUtil: &mockStickerUtil{
+					RenderFunc: func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+						if stickerContent.MerchantName != testQRISModel.MerchantName.Content || stickerContent.NMID != testQRISModel.Switching.Detail.NMID.Content || !strings.HasSuffix(stickerContent.QRString, "AZ15") {
+							return nil, fmt.Errorf("unexpected sticker content %v", stickerContent)
+						}
+						return []byte("sticker"), nil
+					},
+				},
+			},
+			args: args{
+				qris:           &testQRISModel,
+				stickerOptions: utils.DefaultStickerOptions(),
+			},
+			want:      []byte("sticker"),
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uc := &QRIS{
+				crc16CCITTUsecase: test.fields.crc16CCITTUsecase,
+				qrisUsecase:       test.fields.qrisUsecase,
+				inputUtil:         test.fields.inputUtil,
+				stickerUtil:       test.fields.stickerUtil,
+			}
+
+			got, err := uc.Sticker(test.args.qris, test.args.stickerOptions)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "Sticker()", test.wantError, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "Sticker()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQRISQRCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		qrCodeOptions *utils.QRCodeOptions
+		wantPrefix    string
+		wantError     error
+	}{
+		{
+			name:          "Success: Default Options",
+			qrCodeOptions: nil,
+			wantPrefix:    "\x89PNG",
+		},
+		{
+			name:          "Success: SVG",
+			qrCodeOptions: &utils.QRCodeOptions{Format: utils.QRCodeFormatSVG},
+			wantPrefix:    "<?xml",
+		},
+		{
+			name:          "Error: Invalid Options",
+			qrCodeOptions: &utils.QRCodeOptions{Format: "gif"},
+			wantError:     fmt.Errorf("unsupported QR code format gif"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewQRIS().QRCode(&testQRISModel, test.qrCodeOptions)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "QRCode()", test.wantError, err)
+			}
+			if !bytes.HasPrefix(got, []byte(test.wantPrefix)) {
+				t.Errorf(expectedButGotMessage, "QRCode() prefix", test.wantPrefix, string(got[:min(len(got), 8)]))
+			}
+		})
+	}
+}
+
+func TestQRISStickerDefaultOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		stickerOptions *utils.StickerOptions
+		wantPrefix     string
+	}{
+		{
+			name:           "Success: Nil Options",
+			stickerOptions: nil,
+			wantPrefix:     "%PDF-",
+		},
+		{
+			name:           "Success: Zero Options",
+			stickerOptions: &utils.StickerOptions{},
+			wantPrefix:     "%PDF-",
+		},
+		{
+			name:           "Success: Format Only",
+			stickerOptions: &utils.StickerOptions{Format: utils.QRCodeFormatPNG},
+			wantPrefix:     "\x89PNG",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewQRIS().Sticker(&testQRISModel, test.stickerOptions)
+			if err != nil {
+				t.Fatalf(expectedErrorButGotMessage, "Sticker()", nil, err)
+			}
+			if !bytes.HasPrefix(got, []byte(test.wantPrefix)) {
+				t.Errorf(expectedButGotMessage, "Sticker() prefix", test.wantPrefix, string(got[:min(len(got), 8)]))
+			}
+		})
+	}
+}
+
+func TestNewQRISWithMetrics(t *testing.T) {
+	metrics := utils.NewMetrics()
+	qrisService := NewQRISWithMetrics(metrics)
+
+	qrisService.Parse("invalid")
+	qris, err, _ := qrisService.Parse("00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7")
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "Parse()", nil, err)
+	}
+	qrisService.IsValid(qris)
+
+	var b strings.Builder
+	metrics.WriteText(&b)
+	for _, want := range []string{
+		`goqris_operations_total{operation="parse",code="invalid_qris"} 1`,
+		`goqris_operations_total{operation="parse",code="ok"} 1`,
+		`goqris_operations_total{operation="is_valid",code="ok"} 1`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf(expectedButGotMessage, "WriteText() to contain", want, b.String())
+		}
+	}
+}
+
+func TestQRISModifyConcurrent(t *testing.T) {
+	s := NewQRIS()
+	qris, err, _ := s.Parse("00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7")
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "Parse()", nil, err)
+	}
+	template := qris.Clone()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 32; i++ {
+		wg.Add(1)
+		go func(amount int) {
+			defer wg.Done()
+			modified, err, _ := s.Modify(qris, "", "", amount, "FIXED", 666, fmt.Sprintf("T%d", amount))
+			if err != nil {
+				t.Errorf(expectedErrorButGotMessage, "Modify()", nil, err)
+				return
+			}
+			if want := fmt.Sprintf("%d", amount); modified.PaymentAmount.Content != want {
+				t.Errorf(expectedButGotMessage, "Modify() payment amount", want, modified.PaymentAmount.Content)
+			}
+			if qrisString := s.ToString(modified); !s.IsValid(modified) || !strings.HasSuffix(qrisString, modified.CRCCode.Content) {
+				t.Errorf(expectedButGotMessage, "Modify() CRC", modified.CRCCode.Content, qrisString)
+			}
+		}(i * 1000)
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(qris, template) {
+		t.Errorf(expectedButGotMessage, "shared QRIS", template, qris)
+	}
+}
+
+func TestQRISConvertWithOptions(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+
+	tests := []struct {
+		name         string
+		qrString     string
+		options      []Option
+		wantContains []string
+		wantError    error
+		wantErrs     *[]string
+	}{
+		{
+			name:     "Success: Amount, Fee And Additional Information",
+			qrString: qrString,
+			options: []Option{
+				WithAmount("1337.50"),
+				WithFixedFee("666"),
+				WithMerchantCity("Jakarta"),
+				WithBillNumber("INV-1337"),
+				WithTerminalLabel("A01"),
+			},
+			wantContains: []string{"010212", "54071337.50", "550202", "5603666", "6007Jakarta", "0108INV-1337", "0703A01"},
+		},
+		{
+			name:         "Success: Percent Fee",
+			qrString:     qrString,
+			options:      []Option{WithAmount("1337"), WithPercentFee("0.7")},
+			wantContains: []string{"54041337", "550203", "57030.7"},
+		},
+		{
+			name:      "Error: Missing Amount",
+			qrString:  qrString,
+			options:   []Option{WithFixedFee("666")},
+			wantError: fmt.Errorf("payment amount must be a positive decimal of at most 13 characters"),
+		},
+		{
+			name:      "Error: Percent Fee Above 100",
+			qrString:  qrString,
+			options:   []Option{WithAmount("1337"), WithPercentFee("101")},
+			wantError: fmt.Errorf("payment fee must be a positive FIXED amount or a PERCENT of at most 100"),
+		},
+		{
+			name:      "Error: Input Length",
+			qrString:  qrString,
+			options:   []Option{WithAmount("1337"), WithMerchantCity("Kota Yogyakarta Istimewa"), WithBillNumber(strings.Repeat("1", 26))},
+			wantError: fmt.Errorf("input length exceeds the maximum permitted characters"),
+			wantErrs:  &[]string{"merchant city must be between 1 and 15 characters", "bill number exceeds 25 characters"},
+		},
+		{
+			name:      "Error: Invalid QRIS",
+			qrString:  "000201",
+			options:   []Option{WithAmount("1337")},
+			wantError: fmt.Errorf("invalid QRIS format"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewQRIS()
+
+			got, err, errs := s.ConvertWithOptions(test.qrString, test.options...)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || !strings.Contains(err.Error(), test.wantError.Error())) {
+				t.Fatalf(expectedErrorButGotMessage, "ConvertWithOptions()", test.wantError, err)
+			}
+			if test.wantErrs != nil && !reflect.DeepEqual(errs, test.wantErrs) {
+				t.Errorf(expectedButGotMessage, "ConvertWithOptions() errs", *test.wantErrs, errs)
+			}
+			for _, want := range test.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf(expectedButGotMessage, "ConvertWithOptions() to contain", want, got)
+				}
+			}
+			if err == nil {
+				qris, err, _ := s.Parse(got)
+				if err != nil || !s.IsValid(qris) {
+					t.Errorf(expectedButGotMessage, "ConvertWithOptions() valid", true, err)
+				}
+			}
+		})
+	}
+}
+
+func TestQRISModifyWithOptions(t *testing.T) {
+	s := NewQRIS()
+	qris, err, _ := s.Parse("00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7")
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "Parse()", nil, err)
+	}
+	template := qris.Clone()
+
+	got, err, _ := s.ModifyWithOptions(qris, WithAmount("1337"), WithReferenceLabel("REF-1337"))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "ModifyWithOptions()", nil, err)
+	}
+	if got.PaymentAmount.Content != "1337" || got.AdditionalInformation.Detail.ReferenceLabel.Content != "REF-1337" {
+		t.Errorf(expectedButGotMessage, "ModifyWithOptions()", "1337 REF-1337", got)
+	}
+	if !s.IsValid(got) {
+		t.Errorf(expectedButGotMessage, "ModifyWithOptions() CRC valid", true, false)
+	}
+	if !reflect.DeepEqual(qris, template) {
+		t.Errorf(expectedButGotMessage, "ModifyWithOptions() input", template, qris)
+	}
+}
+
+func TestQRISContext(t *testing.T) {
+	qrString := "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7"
+	metrics := utils.NewMetrics()
+	s := NewQRISWithMetrics(metrics)
+	qris, err, _ := s.Parse(qrString)
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "Parse()", nil, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name      string
+		call      func(ctx context.Context) error
+		ctx       context.Context
+		wantError error
+	}{
+		{
+			name: "Success: Parse",
+			call: func(ctx context.Context) error {
+				_, err, _ := s.ParseContext(ctx, qrString)
+				return err
+			},
+			ctx: context.Background(),
+		},
+		{
+			name: "Success: Convert",
+			call: func(ctx context.Context) error {
+				_, err, _ := s.ConvertContext(ctx, qrString, WithAmount("1337"))
+				return err
+			},
+			ctx: context.Background(),
+		},
+		{
+			name: "Error: Parse Canceled",
+			call: func(ctx context.Context) error {
+				_, err, _ := s.ParseContext(ctx, qrString)
+				return err
+			},
+			ctx:       canceled,
+			wantError: context.Canceled,
+		},
+		{
+			name: "Error: IsValid Canceled",
+			call: func(ctx context.Context) error {
+				_, err := s.IsValidContext(ctx, qris)
+				return err
+			},
+			ctx:       canceled,
+			wantError: context.Canceled,
+		},
+		{
+			name: "Error: Convert Deadline Exceeded",
+			call: func(ctx context.Context) error {
+				_, err, _ := s.ConvertContext(ctx, qrString, WithAmount("1337"))
+				return err
+			},
+			ctx:       expired,
+			wantError: context.DeadlineExceeded,
+		},
+		{
+			name: "Success: QR Code",
+			call: func(ctx context.Context) error {
+				_, err := s.QRCodeContext(ctx, qris, nil)
+				return err
+			},
+			ctx: context.Background(),
+		},
+		{
+			name: "Error: QR Code Canceled",
+			call: func(ctx context.Context) error {
+				_, err := s.QRCodeContext(ctx, qris, &utils.QRCodeOptions{Format: utils.QRCodeFormatSVG})
+				return err
+			},
+			ctx:       canceled,
+			wantError: context.Canceled,
+		},
+		{
+			name: "Error: QR Code Deadline Exceeded",
+			call: func(ctx context.Context) error {
+				_, err := s.QRCodeContext(ctx, qris, nil)
+				return err
+			},
+			ctx:       expired,
+			wantError: context.DeadlineExceeded,
+		},
+		{
+			name: "Error: Sticker Canceled",
+			call: func(ctx context.Context) error {
+				_, err := s.StickerContext(ctx, qris, &utils.StickerOptions{Format: utils.QRCodeFormatPNG})
+				return err
+			},
+			ctx:       canceled,
+			wantError: context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.call(test.ctx); !errors.Is(err, test.wantError) {
+				t.Errorf(expectedErrorButGotMessage, test.name, test.wantError, err)
+			}
+		})
+	}
+
+	var b strings.Builder
+	metrics.WriteText(&b)
+	for _, want := range []string{
+		`goqris_operations_total{operation="parse",code="canceled"} 1`,
+		`goqris_operations_total{operation="convert",code="deadline_exceeded"} 1`,
+		`goqris_operations_total{operation="qr_code",code="canceled"} 1`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf(expectedButGotMessage, "WriteText() to contain", want, b.String())
+		}
+	}
+}
+
+func TestNewQRISWithProfile(t *testing.T) {
+	profile := DefaultProfile()
+	profile.Tags.AdditionalInformation = "64"
+	s := NewQRISWithProfile(profile)
+	profile.Tags.AdditionalInformation = "62"
+
+	qrString, err, errs := s.ConvertWithOptions("00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7", WithAmount("1337"), WithBillNumber("INV-1337"))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "ConvertWithOptions()", nil, errs)
+	}
+	if want := "64120108INV-1337"; !strings.Contains(qrString, want) {
+		t.Errorf(expectedButGotMessage, "ConvertWithOptions() to contain", want, qrString)
+	}
+	if DefaultProfile().Tags.AdditionalInformation != "62" {
+		t.Errorf(expectedButGotMessage, "DefaultProfile().Tags.AdditionalInformation", "62", DefaultProfile().Tags.AdditionalInformation)
+	}
+}
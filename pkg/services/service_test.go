@@ -1,11 +1,13 @@
 package services
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/fyvri/go-qris/internal/domain/entities"
 	"github.com/fyvri/go-qris/internal/usecases"
 	"github.com/fyvri/go-qris/pkg/models"
+	"github.com/fyvri/go-qris/pkg/utils"
 )
 
 var (
@@ -749,6 +751,7 @@ type mockQRISUsecase struct {
 	ParseFunc    func(qrString string) (*entities.QRIS, error, *[]string)
 	IsValidFunc  func(qris *entities.QRIS) bool
 	ModifyFunc   func(qris *entities.QRIS, merchantCityValue string, merchantPostalCodeValue string, paymentAmountValue uint32, paymentFeeCategoryValue string, paymentFeeValue uint32, terminalLabelValue string) *entities.QRIS
+	PatchFunc    func(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS
 	ToStringFunc func(qris *entities.QRIS) string
 }
 
@@ -773,6 +776,13 @@ func (m *mockQRISUsecase) Modify(qris *entities.QRIS, merchantCityValue string,
 	return nil
 }
 
+func (m *mockQRISUsecase) Patch(qris *entities.QRIS, patch *entities.QRISPatch) *entities.QRIS {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(qris, patch)
+	}
+	return nil
+}
+
 func (m *mockQRISUsecase) ToString(qris *entities.QRIS) string {
 	if m.ToStringFunc != nil {
 		return m.ToStringFunc(qris)
@@ -780,6 +790,34 @@ func (m *mockQRISUsecase) ToString(qris *entities.QRIS) string {
 	return ""
 }
 
+type mockStickerUtil struct {
+	RenderFunc       func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error)
+	RenderBase64Func func(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error)
+}
+
+func (m *mockStickerUtil) Render(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	if m.RenderFunc != nil {
+		return m.RenderFunc(stickerContent, stickerOptions)
+	}
+	return nil, nil
+}
+
+func (m *mockStickerUtil) RenderBase64(stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+	if m.RenderBase64Func != nil {
+		return m.RenderBase64Func(stickerContent, stickerOptions)
+	}
+	return "", nil
+}
+
+// The Context methods share the mocks of the methods they extend.
+func (m *mockStickerUtil) RenderContext(ctx context.Context, stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) ([]byte, error) {
+	return m.Render(stickerContent, stickerOptions)
+}
+
+func (m *mockStickerUtil) RenderBase64Context(ctx context.Context, stickerContent *utils.StickerContent, stickerOptions *utils.StickerOptions) (string, error) {
+	return m.RenderBase64(stickerContent, stickerOptions)
+}
+
 type mockInputUtil struct {
 	SanitizeFunc func(input string) string
 }
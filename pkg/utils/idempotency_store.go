@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// idempotencyStoreSweepInterval is how often expired entries are dropped.
+const idempotencyStoreSweepInterval = time.Minute
+
+// IdempotentResponse is a request claimed by an idempotency key and, once
+// Completed, the response to replay for it.
+type IdempotentResponse struct {
+	Fingerprint string
+	Completed   bool
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStoreInterface keeps idempotent responses, implementations may
+// be shared by several servers.
+type IdempotencyStoreInterface interface {
+	// Reserve claims key for a request with fingerprint for ttl. When key is
+	// already taken it returns the existing entry and false.
+	Reserve(key string, fingerprint string, ttl time.Duration) (*IdempotentResponse, bool)
+	// Complete stores the response of a reserved key for ttl.
+	Complete(key string, response *IdempotentResponse, ttl time.Duration)
+	// Release drops a reservation whose request failed, so it can be retried.
+	Release(key string)
+}
+
+type IdempotencyStore struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	order     *list.List
+	bytes     int64
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type idempotencyEntry struct {
+	key      string
+	response IdempotentResponse
+	expires  time.Time
+	size     int64
+}
+
+// NewIdempotencyStore keeps responses in memory, they are lost on restart and
+// not shared between replicas. Past maxEntries entries or maxBytes of keys and
+// bodies, 0 for no limit, the oldest completed entries are evicted first.
+func NewIdempotencyStore(maxEntries int, maxBytes int64) IdempotencyStoreInterface {
+	return &IdempotencyStore{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (u *IdempotencyStore) Reserve(key string, fingerprint string, ttl time.Duration) (*IdempotentResponse, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.now()
+	u.sweep(now)
+
+	if element, exists := u.entries[key]; exists {
+		if entry := element.Value.(*idempotencyEntry); now.Before(entry.expires) {
+			response := entry.response
+			response.Body = bytes.Clone(entry.response.Body)
+			return &response, false
+		}
+	}
+	u.set(&idempotencyEntry{
+		key:      key,
+		response: IdempotentResponse{Fingerprint: fingerprint},
+		expires:  now.Add(ttl),
+	})
+
+	return nil, true
+}
+
+func (u *IdempotencyStore) Complete(key string, response *IdempotentResponse, ttl time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	entry := &idempotencyEntry{
+		key:      key,
+		response: *response,
+		expires:  u.now().Add(ttl),
+	}
+	entry.response.Completed = true
+	entry.response.Body = bytes.Clone(response.Body)
+	u.set(entry)
+}
+
+func (u *IdempotencyStore) Release(key string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.remove(key)
+}
+
+// set replaces the entry of entry.key and evicts the oldest completed entries
+// until the store fits its limits again, a response larger than maxBytes is
+// not kept. Reservations still in progress are never evicted, or a retry
+// could run the request a second time, so they may briefly exceed the limits.
+func (u *IdempotencyStore) set(entry *idempotencyEntry) {
+	u.remove(entry.key)
+	entry.size = int64(len(entry.key) + len(entry.response.Fingerprint) + len(entry.response.ContentType) + len(entry.response.Body))
+	u.entries[entry.key] = u.order.PushFront(entry)
+	u.bytes += entry.size
+
+	for element := u.order.Back(); element != nil && u.full(); {
+		previous := element.Prev()
+		if evicted := element.Value.(*idempotencyEntry); evicted.response.Completed {
+			u.remove(evicted.key)
+		}
+		element = previous
+	}
+}
+
+func (u *IdempotencyStore) full() bool {
+	return (u.maxEntries > 0 && u.order.Len() > u.maxEntries) || (u.maxBytes > 0 && u.bytes > u.maxBytes)
+}
+
+func (u *IdempotencyStore) remove(key string) {
+	element, exists := u.entries[key]
+	if !exists {
+		return
+	}
+	u.order.Remove(element)
+	delete(u.entries, key)
+	u.bytes -= element.Value.(*idempotencyEntry).size
+}
+
+func (u *IdempotencyStore) sweep(now time.Time) {
+	if now.Sub(u.lastSweep) < idempotencyStoreSweepInterval {
+		return
+	}
+	u.lastSweep = now
+
+	for key, element := range u.entries {
+		if !now.Before(element.Value.(*idempotencyEntry).expires) {
+			u.remove(key)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	store := NewIdempotencyStore(0, 0).(*IdempotencyStore)
+	store.now = func() time.Time {
+		return now
+	}
+	ttl := time.Hour
+
+	if _, reserved := store.Reserve("kiosk", "request-a", ttl); !reserved {
+		t.Fatalf(expectedButGotMessage, "Reserve() reserved", true, reserved)
+	}
+	existing, reserved := store.Reserve("kiosk", "request-a", ttl)
+	if reserved || existing.Completed || existing.Fingerprint != "request-a" {
+		t.Errorf(expectedButGotMessage, "Reserve() in progress", "pending request-a", existing)
+	}
+
+	body := []byte(`{"success":true}`)
+	store.Complete("kiosk", &IdempotentResponse{Fingerprint: "request-a", Status: 200, ContentType: "application/json", Body: body}, ttl)
+	body[0] = 'X'
+	existing, reserved = store.Reserve("kiosk", "request-b", ttl)
+	if reserved || !existing.Completed || existing.Status != 200 || string(existing.Body) != `{"success":true}` {
+		t.Errorf(expectedButGotMessage, "Reserve() completed", `{"success":true}`, existing)
+	}
+
+	store.Release("kiosk")
+	if _, reserved := store.Reserve("kiosk", "request-b", ttl); !reserved {
+		t.Errorf(expectedButGotMessage, "Reserve() after Release()", true, reserved)
+	}
+
+	now = now.Add(ttl)
+	if _, reserved := store.Reserve("kiosk", "request-c", ttl); !reserved {
+		t.Errorf(expectedButGotMessage, "Reserve() after expiry", true, reserved)
+	}
+}
+
+func TestIdempotencyStoreSweep(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	store := NewIdempotencyStore(0, 0).(*IdempotencyStore)
+	store.now = func() time.Time {
+		return now
+	}
+
+	store.Reserve("kiosk", "request-a", time.Second)
+	now = now.Add(idempotencyStoreSweepInterval)
+	store.Reserve("cashier", "request-b", time.Hour)
+
+	if _, exists := store.entries["kiosk"]; exists || len(store.entries) != 1 || store.order.Len() != 1 {
+		t.Errorf(expectedButGotMessage, "entries", "cashier only", store.entries)
+	}
+}
+
+func TestIdempotencyStoreLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		maxBytes   int64
+		body       string
+		wantKeys   []string
+	}{
+		{
+			name:     "Success: Unlimited",
+			body:     "response",
+			wantKeys: []string{"kiosk", "cashier", "counter"},
+		},
+		{
+			name:       "Success: Maximum Entries",
+			maxEntries: 2,
+			body:       "response",
+			wantKeys:   []string{"cashier", "counter"},
+		},
+		{
+			name:     "Success: Maximum Bytes",
+			maxBytes: 64,
+			body:     strings.Repeat("r", 40),
+			wantKeys: []string{"counter"},
+		},
+		{
+			name:     "Success: Response Larger Than Maximum Bytes",
+			maxBytes: 16,
+			body:     strings.Repeat("r", 40),
+			wantKeys: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewIdempotencyStore(test.maxEntries, test.maxBytes).(*IdempotencyStore)
+			for _, key := range []string{"kiosk", "cashier", "counter"} {
+				store.Reserve(key, "request", time.Hour)
+				store.Complete(key, &IdempotentResponse{Fingerprint: "request", Status: 200, Body: []byte(test.body)}, time.Hour)
+			}
+
+			if len(store.entries) != len(test.wantKeys) || store.order.Len() != len(test.wantKeys) {
+				t.Errorf(expectedButGotMessage, "entries", test.wantKeys, store.entries)
+			}
+			for _, key := range test.wantKeys {
+				if _, exists := store.entries[key]; !exists {
+					t.Errorf(expectedButGotMessage, "entry "+key, true, exists)
+				}
+			}
+			if test.maxBytes > 0 && store.bytes > test.maxBytes {
+				t.Errorf(expectedButGotMessage, "bytes", test.maxBytes, store.bytes)
+			}
+		})
+	}
+}
+
+func TestIdempotencyStoreKeepsReservations(t *testing.T) {
+	store := NewIdempotencyStore(2, 0).(*IdempotencyStore)
+	store.Reserve("kiosk", "request-a", time.Hour)
+	store.Reserve("cashier", "request-b", time.Hour)
+	store.Reserve("counter", "request-c", time.Hour)
+
+	for _, key := range []string{"kiosk", "cashier", "counter"} {
+		if existing, reserved := store.Reserve(key, "request-retry", time.Hour); reserved || existing.Completed {
+			t.Errorf(expectedButGotMessage, "Reserve() of in progress "+key, "pending", existing)
+		}
+	}
+
+	store.Complete("kiosk", &IdempotentResponse{Fingerprint: "request-a", Status: 200}, time.Hour)
+	store.Complete("cashier", &IdempotentResponse{Fingerprint: "request-b", Status: 200}, time.Hour)
+	if _, exists := store.entries["counter"]; !exists || len(store.entries) != 2 {
+		t.Errorf(expectedButGotMessage, "entries", "cashier and counter", store.entries)
+	}
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var (
+	metricsDurationBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+	metricsLengthBuckets   = []float64{64, 128, 192, 256, 384, 512}
+)
+
+// Metrics keeps counters and histograms in memory and writes them in the
+// Prometheus text exposition format.
+type Metrics struct {
+	mu       sync.Mutex
+	families []*metricFamily
+	byName   map[string]*metricFamily
+}
+
+type MetricsInterface interface {
+	ObserveRequest(method string, route string, status int, duration time.Duration)
+	ObserveOperation(operation string, code string, issues []string, duration time.Duration)
+	ObserveRender(kind string, format string, qrString string, duration time.Duration)
+	ObserveCache(cache string, hit bool)
+	WriteText(w io.Writer) error
+}
+
+type metricFamily struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	buckets    []float64
+	series     map[string]*metricSeries
+}
+
+type metricSeries struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+func NewMetrics() MetricsInterface {
+	m := &Metrics{
+		byName: map[string]*metricFamily{},
+	}
+	m.register("goqris_http_requests_total", "HTTP requests by route and status.", "counter", nil, "method", "route", "status")
+	m.register("goqris_http_request_duration_seconds", "HTTP request latency by route.", "histogram", metricsDurationBuckets, "method", "route")
+	m.register("goqris_operations_total", "QRIS operations by result code, ok on success.", "counter", nil, "operation", "code")
+	m.register("goqris_operation_duration_seconds", "QRIS operation latency.", "histogram", metricsDurationBuckets, "operation")
+	m.register("goqris_validation_issues_total", "QRIS validation issues by error code and offending tag.", "counter", nil, "code", "tag")
+	m.register("goqris_crc_failures_total", "QRIS strings whose CRC16-CCITT code does not match.", "counter", nil, "operation")
+	m.register("goqris_render_duration_seconds", "QR code and sticker render latency.", "histogram", metricsDurationBuckets, "kind", "format")
+	m.register("goqris_render_qr_string_bytes", "Length of the rendered QR strings.", "histogram", metricsLengthBuckets, "kind")
+	m.register("goqris_cache_requests_total", "Cache lookups by result, hit or miss.", "counter", nil, "cache", "result")
+
+	return m
+}
+
+func (m *Metrics) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	m.add("goqris_http_requests_total", 1, method, route, strconv.Itoa(status))
+	m.add("goqris_http_request_duration_seconds", duration.Seconds(), method, route)
+}
+
+// ObserveOperation records an operation, code is empty on success. Every
+// issue is counted under the tag it names, e.g. "Acquirer MPAN tag is
+// missing" under acquirer_mpan.
+func (m *Metrics) ObserveOperation(operation string, code string, issues []string, duration time.Duration) {
+	if code == "" {
+		code = "ok"
+	}
+	m.add("goqris_operations_total", 1, operation, code)
+	m.add("goqris_operation_duration_seconds", duration.Seconds(), operation)
+	for _, issue := range issues {
+		m.add("goqris_validation_issues_total", 1, code, metricsIssueTag(issue))
+	}
+	if code == "invalid_crc" {
+		m.add("goqris_crc_failures_total", 1, operation)
+	}
+}
+
+func (m *Metrics) ObserveRender(kind string, format string, qrString string, duration time.Duration) {
+	m.add("goqris_render_duration_seconds", duration.Seconds(), kind, format)
+	m.add("goqris_render_qr_string_bytes", float64(len(qrString)), kind)
+}
+
+func (m *Metrics) ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.add("goqris_cache_requests_total", 1, cache, result)
+}
+
+func (m *Metrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	for _, family := range m.families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.kind)
+
+		keys := make([]string, 0, len(family.series))
+		for key := range family.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			series := family.series[key]
+			labels := metricsLabels(family.labelNames, series.labelValues)
+			if family.kind == "counter" {
+				fmt.Fprintf(&b, "%s%s %s\n", family.name, metricsJoinLabels(labels), metricsFloat(series.value))
+				continue
+			}
+
+			cumulative := uint64(0)
+			for i, upperBound := range family.buckets {
+				cumulative += series.counts[i]
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", family.name, metricsJoinLabels(append(labels, `le="`+metricsFloat(upperBound)+`"`)), cumulative)
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", family.name, metricsJoinLabels(append(labels, `le="+Inf"`)), series.count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", family.name, metricsJoinLabels(labels), metricsFloat(series.sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", family.name, metricsJoinLabels(labels), series.count)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (m *Metrics) register(name string, help string, kind string, buckets []float64, labelNames ...string) {
+	family := &metricFamily{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     map[string]*metricSeries{},
+	}
+	m.families = append(m.families, family)
+	m.byName[name] = family
+}
+
+// add increments a counter or observes a histogram value.
+func (m *Metrics) add(name string, value float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	family := m.byName[name]
+	key := strings.Join(labelValues, "\xff")
+	series, exists := family.series[key]
+	if !exists {
+		series = &metricSeries{
+			labelValues: labelValues,
+			counts:      make([]uint64, len(family.buckets)),
+		}
+		family.series[key] = series
+	}
+
+	if family.kind == "counter" {
+		series.value += value
+		return
+	}
+	for i, upperBound := range family.buckets {
+		if value <= upperBound {
+			series.counts[i]++
+			break
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+// metricsIssueTag turns an issue such as "Merchant city exceeds 15
+// characters" into a bounded label value such as merchant_city.
+func metricsIssueTag(issue string) string {
+	for _, suffix := range []string{" tag is missing", " content undefined", " exceeds ", " must be "} {
+		if index := strings.Index(issue, suffix); index > 0 {
+			issue = issue[:index]
+			break
+		}
+	}
+
+	return strings.ReplaceAll(strings.ToLower(issue), " ", "_")
+}
+
+func metricsLabels(names []string, values []string) []string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(values[i])
+		labels[i] = name + `="` + value + `"`
+	}
+
+	return labels
+}
+
+func metricsJoinLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func metricsFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsWriteText(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ObserveRequest("POST", "/v2/qris:parse", 200, 3*time.Millisecond)
+	metrics.ObserveRequest("POST", "/v2/qris:parse", 200, 30*time.Millisecond)
+	metrics.ObserveOperation("parse", "invalid_qris", []string{"Acquirer MPAN tag is missing", "CRC code tag is missing"}, time.Millisecond)
+	metrics.ObserveOperation("is_valid", "invalid_crc", nil, time.Millisecond)
+	metrics.ObserveOperation("is_valid", "", nil, time.Millisecond)
+	metrics.ObserveRender("qr_code", "svg", strings.Repeat("0", 200), 20*time.Millisecond)
+	metrics.ObserveCache("qris_template", true)
+	metrics.ObserveCache("qris_template", true)
+	metrics.ObserveCache("qris_template", false)
+
+	var b strings.Builder
+	if err := metrics.WriteText(&b); err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "WriteText()", nil, err)
+	}
+	got := b.String()
+
+	for _, want := range []string{
+		"# TYPE goqris_http_requests_total counter\n",
+		`goqris_http_requests_total{method="POST",route="/v2/qris:parse",status="200"} 2` + "\n",
+		`goqris_http_request_duration_seconds_bucket{method="POST",route="/v2/qris:parse",le="0.0025"} 0` + "\n",
+		`goqris_http_request_duration_seconds_bucket{method="POST",route="/v2/qris:parse",le="0.005"} 1` + "\n",
+		`goqris_http_request_duration_seconds_bucket{method="POST",route="/v2/qris:parse",le="+Inf"} 2` + "\n",
+		`goqris_http_request_duration_seconds_sum{method="POST",route="/v2/qris:parse"} 0.033` + "\n",
+		`goqris_http_request_duration_seconds_count{method="POST",route="/v2/qris:parse"} 2` + "\n",
+		`goqris_operations_total{operation="is_valid",code="invalid_crc"} 1` + "\n",
+		`goqris_operations_total{operation="is_valid",code="ok"} 1` + "\n",
+		`goqris_validation_issues_total{code="invalid_qris",tag="acquirer_mpan"} 1` + "\n",
+		`goqris_validation_issues_total{code="invalid_qris",tag="crc_code"} 1` + "\n",
+		`goqris_crc_failures_total{operation="is_valid"} 1` + "\n",
+		`goqris_render_duration_seconds_count{kind="qr_code",format="svg"} 1` + "\n",
+		`goqris_render_qr_string_bytes_bucket{kind="qr_code",le="192"} 0` + "\n",
+		`goqris_render_qr_string_bytes_bucket{kind="qr_code",le="256"} 1` + "\n",
+		`goqris_cache_requests_total{cache="qris_template",result="hit"} 2` + "\n",
+		`goqris_cache_requests_total{cache="qris_template",result="miss"} 1` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf(expectedButGotMessage, "WriteText() to contain", want, got)
+		}
+	}
+}
+
+func TestMetricsIssueTag(t *testing.T) {
+	tests := []struct {
+		issue string
+		want  string
+	}{
+		{issue: "Acquirer terminal id tag is missing", want: "acquirer_terminal_id"},
+		{issue: "Category content undefined", want: "category"},
+		{issue: "merchant city exceeds 15 characters", want: "merchant_city"},
+		{issue: "merchant city must be between 1 and 15 characters", want: "merchant_city"},
+		{issue: "additional information exceeds 99 characters", want: "additional_information"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.issue, func(t *testing.T) {
+			if got := metricsIssueTag(test.issue); got != test.want {
+				t.Errorf(expectedButGotMessage, "metricsIssueTag()", test.want, got)
+			}
+		})
+	}
+}
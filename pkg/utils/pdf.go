@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// pdfDocument is a minimal PDF 1.4 writer. Object 1 is always the catalog and
+// object 2 the page tree, so pages can be appended in any order.
+type pdfDocument struct {
+	objects [][]byte
+	pages   []int
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{
+		objects: make([][]byte, 2),
+	}
+}
+
+func (d *pdfDocument) addObject(body []byte) int {
+	d.objects = append(d.objects, body)
+	return len(d.objects)
+}
+
+func (d *pdfDocument) addStream(dictionary string, data []byte) int {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<< %s/Length %d >>\nstream\n", dictionary, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+
+	return d.addObject(buf.Bytes())
+}
+
+func (d *pdfDocument) addPage(width float64, height float64, content []byte, resources string) int {
+	contentID := d.addStream("", content)
+	page := fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s>> /Contents %d 0 R >>", pdfNumber(width), pdfNumber(height), resources, contentID)
+	pageID := d.addObject([]byte(page))
+	d.pages = append(d.pages, pageID)
+
+	return pageID
+}
+
+// addTrueTypeFont embeds a TrueType font with WinAnsi encoding for the
+// printable ASCII range. Widths are in thousandths of an em, starting at 32.
+func (d *pdfDocument) addTrueTypeFont(name string, data []byte, parsed *sfnt.Font, widths []int) (int, error) {
+	compressed, err := pdfDeflate(data)
+	if err != nil {
+		return 0, err
+	}
+	fontFileID := d.addStream(fmt.Sprintf("/Filter /FlateDecode /Length1 %d ", len(data)), compressed)
+
+	var buf sfnt.Buffer
+	metrics, err := parsed.Metrics(&buf, fixed.I(1000), font.HintingNone)
+	if err != nil {
+		return 0, err
+	}
+	bounds, err := parsed.Bounds(&buf, fixed.I(1000), font.HintingNone)
+	if err != nil {
+		return 0, err
+	}
+	descriptor := fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, bounds.Min.X.Round(), -bounds.Max.Y.Round(), bounds.Max.X.Round(), -bounds.Min.Y.Round(), metrics.Ascent.Round(), -metrics.Descent.Round(), metrics.CapHeight.Round(), fontFileID)
+	descriptorID := d.addObject([]byte(descriptor))
+
+	widthValues := make([]string, len(widths))
+	for i, width := range widths {
+		widthValues[i] = fmt.Sprintf("%d", width)
+	}
+	fontDictionary := fmt.Sprintf("<< /Type /Font /Subtype /TrueType /BaseFont /%s /FirstChar 32 /LastChar %d /Widths [%s] /FontDescriptor %d 0 R /Encoding /WinAnsiEncoding >>",
+		name, 32+len(widths)-1, strings.Join(widthValues, " "), descriptorID)
+
+	return d.addObject([]byte(fontDictionary)), nil
+}
+
+// addImage embeds an image as an RGB XObject, with a soft mask when any pixel
+// is not fully opaque.
+func (d *pdfDocument) addImage(img image.Image) (int, error) {
+	bounds := img.Bounds()
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+	opaque := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, pixel.R, pixel.G, pixel.B)
+			alpha = append(alpha, pixel.A)
+			if pixel.A != 0xff {
+				opaque = false
+			}
+		}
+	}
+
+	dictionary := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /BitsPerComponent 8 /Filter /FlateDecode ", bounds.Dx(), bounds.Dy())
+	softMask := ""
+	if !opaque {
+		compressed, err := pdfDeflate(alpha)
+		if err != nil {
+			return 0, err
+		}
+		softMask = fmt.Sprintf("/SMask %d 0 R ", d.addStream(dictionary+"/ColorSpace /DeviceGray ", compressed))
+	}
+
+	compressed, err := pdfDeflate(rgb)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.addStream(dictionary+"/ColorSpace /DeviceRGB "+softMask, compressed), nil
+}
+
+func (d *pdfDocument) bytes() []byte {
+	var kids bytes.Buffer
+	for i, pageID := range d.pages {
+		if i > 0 {
+			kids.WriteString(" ")
+		}
+		fmt.Fprintf(&kids, "%d 0 R", pageID)
+	}
+	d.objects[0] = []byte("<< /Type /Catalog /Pages 2 0 R >>")
+	d.objects[1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(d.pages)))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(d.objects))
+	for i, object := range d.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(object)
+		buf.WriteString("\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func pdfDeflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func pdfString(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	return replacer.Replace(text)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPDFDocumentBytes(t *testing.T) {
+	document := newPDFDocument()
+	document.addPage(100, 50, []byte("0 0 10 10 re f"), "")
+	document.addPage(50, 100, []byte("0 0 20 20 re f"), "")
+
+	got := document.bytes()
+	if !bytes.Contains(got, []byte("/Kids [4 0 R 6 0 R] /Count 2")) {
+		t.Errorf(expectedResponseToContain, "/Kids [4 0 R 6 0 R] /Count 2", string(got))
+	}
+
+	xref := got[bytes.Index(got, []byte("xref\n")):]
+	lines := strings.Split(string(xref), "\n")
+	for i := 1; i <= len(document.objects); i++ {
+		offset, err := strconv.Atoi(lines[2+i][:10])
+		if err != nil {
+			t.Fatalf(expectedErrorButGotMessage, "xref offset", nil, err)
+		}
+		want := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(got[offset:], []byte(want)) {
+			t.Errorf(expectedButGotMessage, "xref offset", want, string(got[offset:offset+len(want)]))
+		}
+	}
+}
+
+func TestPDFDocumentAddImage(t *testing.T) {
+	opaque := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	opaque.Set(0, 0, color.NRGBA{R: 0xff, A: 0xff})
+	opaque.Set(1, 0, color.NRGBA{G: 0xff, A: 0xff})
+	opaque.Set(0, 1, color.NRGBA{B: 0xff, A: 0xff})
+	opaque.Set(1, 1, color.NRGBA{A: 0xff})
+	transparent := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	document := newPDFDocument()
+	opaqueID, err := document.addImage(opaque)
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "addImage()", nil, err)
+	}
+	if got := document.objects[opaqueID-1]; !bytes.Contains(got, []byte("/Width 2 /Height 2")) || bytes.Contains(got, []byte("/SMask")) {
+		t.Errorf(expectedButGotMessage, "addImage() opaque", "an RGB image without soft mask", string(got))
+	}
+
+	transparentID, err := document.addImage(transparent)
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "addImage()", nil, err)
+	}
+	want := fmt.Sprintf("/SMask %d 0 R", transparentID-1)
+	if got := document.objects[transparentID-1]; !bytes.Contains(got, []byte(want)) {
+		t.Errorf(expectedResponseToContain, want, string(got))
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	got := pdfString(`Toko (Baru) \ 1`)
+	want := `Toko \(Baru\) \\ 1`
+	if got != want {
+		t.Errorf(expectedButGotMessage, "pdfString()", want, got)
+	}
+}
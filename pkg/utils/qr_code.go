@@ -2,24 +2,184 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
 	"image/png"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/boombuler/barcode"
 	"github.com/boombuler/barcode/qr"
+	"golang.org/x/image/draw"
 )
 
+const (
+	QRCodeFormatPNG = "png"
+	QRCodeFormatSVG = "svg"
+	QRCodeFormatPDF = "pdf"
+
+	QRCodeMinimumContrastRatio = 3.0
+
+	// The maximums keep a single request from allocating an image large
+	// enough to exhaust memory.
+	QRCodeMaximumSize       = 4096
+	QRCodeMaximumModuleSize = 64
+	QRCodeMaximumMargin     = 16
+
+	// QRCodeMaximumLogoAreaPercent caps the share of the symbol hidden behind
+	// a logo, well below the 30% that error correction level H can recover.
+	QRCodeMaximumLogoAreaPercent = 10
+
+	qrCodeLogoErrorCorrectionLevel = "H"
+	qrCodeVerificationModulePixels = 8
+)
+
+var QRCodeFormatMediaTypes = map[string]string{
+	QRCodeFormatPNG: "image/png",
+	QRCodeFormatSVG: "image/svg+xml",
+	QRCodeFormatPDF: "application/pdf",
+}
+
 type QRCode struct {
 }
 
+type QRCodeOptions struct {
+	Format               string `json:"format"`
+	Size                 int    `json:"size"`
+	ModuleSize           int    `json:"module_size"`
+	Margin               int    `json:"margin"`
+	ErrorCorrectionLevel string `json:"error_correction_level"`
+	ForegroundColor      string `json:"foreground_color"`
+	BackgroundColor      string `json:"background_color"`
+	Logo                 []byte `json:"logo,omitempty"`
+
+	// MarginSet makes MergeQRCodeOptions apply Margin even when it is zero.
+	// Decoding JSON sets it whenever the margin key is present.
+	MarginSet bool `json:"-"`
+}
+
 type QRCodeInterface interface {
 	StringToImageBase64(qrString string, qrCodeSize int) (string, error)
+	StringToPNG(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error)
+	StringToSVG(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error)
+	StringToPDF(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error)
+	StringToFormat(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error)
+	StringToFormatContext(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error)
+	StringToFormatBase64(qrString string, qrCodeOptions *QRCodeOptions) (string, error)
+	StringToFormatBase64Context(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) (string, error)
+	ValidateOptions(qrCodeOptions *QRCodeOptions) error
+	ImageToString(imageData []byte) (string, error)
+	ImageToStringContext(ctx context.Context, imageData []byte) (string, error)
+}
+
+type qrCodeRect struct {
+	x      int
+	y      int
+	width  int
+	height int
+}
+
+type qrCodeLayout struct {
+	modules    [][]bool
+	dimension  int
+	size       int
+	foreground color.RGBA
+	background color.RGBA
+	logo       *qrCodeLogo
+}
+
+// qrCodeLogo positions a logo in module units, inside the area of modules
+// cleared to the background color.
+type qrCodeLogo struct {
+	image     image.Image
+	data      []byte
+	mediaType string
+	x         float64
+	y         float64
+	width     float64
+	height    float64
 }
 
 func NewQRCode() QRCodeInterface {
 	return &QRCode{}
 }
 
+func DefaultQRCodeOptions() *QRCodeOptions {
+	return &QRCodeOptions{
+		Format:               QRCodeFormatPNG,
+		Size:                 256,
+		Margin:               4,
+		ErrorCorrectionLevel: "L",
+		ForegroundColor:      "#000000",
+		BackgroundColor:      "#FFFFFF",
+	}
+}
+
+func (o *QRCodeOptions) UnmarshalJSON(data []byte) error {
+	type qrCodeOptions QRCodeOptions
+	decoded := struct {
+		qrCodeOptions
+		Margin *int `json:"margin"`
+	}{qrCodeOptions: qrCodeOptions(*o)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*o = QRCodeOptions(decoded.qrCodeOptions)
+	if decoded.Margin != nil {
+		o.Margin = *decoded.Margin
+		o.MarginSet = true
+	}
+
+	return nil
+}
+
+func MergeQRCodeOptions(qrCodeOptions *QRCodeOptions, overrides *QRCodeOptions) *QRCodeOptions {
+	merged := QRCodeOptions{}
+	if qrCodeOptions != nil {
+		merged = *qrCodeOptions
+	}
+	if overrides == nil {
+		return &merged
+	}
+
+	if overrides.Format != "" {
+		merged.Format = overrides.Format
+	}
+	if overrides.ModuleSize > 0 {
+		merged.ModuleSize = overrides.ModuleSize
+		merged.Size = 0
+	} else if overrides.Size > 0 {
+		merged.Size = overrides.Size
+		merged.ModuleSize = 0
+	}
+	if overrides.MarginSet || overrides.Margin > 0 {
+		merged.Margin = overrides.Margin
+	}
+	if overrides.ErrorCorrectionLevel != "" {
+		merged.ErrorCorrectionLevel = overrides.ErrorCorrectionLevel
+	}
+	if overrides.ForegroundColor != "" {
+		merged.ForegroundColor = overrides.ForegroundColor
+	}
+	if overrides.BackgroundColor != "" {
+		merged.BackgroundColor = overrides.BackgroundColor
+	}
+	if len(overrides.Logo) > 0 {
+		merged.Logo = overrides.Logo
+	}
+
+	return &merged
+}
+
 func (u *QRCode) StringToImageBase64(qrString string, qrCodeSize int) (string, error) {
 	qrCode, err := qr.Encode(qrString, qr.L, qr.Auto)
 	if err != nil {
@@ -41,3 +201,491 @@ func (u *QRCode) StringToImageBase64(qrString string, qrCodeSize int) (string, e
 
 	return base64String, nil
 }
+
+func (u *QRCode) StringToPNG(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	return u.stringToPNG(context.Background(), qrString, qrCodeOptions)
+}
+
+func (u *QRCode) stringToPNG(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	layout, err := u.layout(ctx, qrString, qrCodeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePixels := layout.size / layout.dimension
+	offset := (layout.size - layout.dimension*modulePixels) / 2
+	img := layout.rasterize(layout.size, modulePixels, offset)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *QRCode) StringToSVG(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	return u.stringToSVG(context.Background(), qrString, qrCodeOptions)
+}
+
+func (u *QRCode) stringToSVG(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	layout, err := u.layout(ctx, qrString, qrCodeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, layout.size, layout.size, layout.dimension, layout.dimension)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, layout.dimension, layout.dimension, hexColor(layout.background))
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hexColor(layout.foreground))
+	for _, rect := range mergeModules(layout.modules) {
+		fmt.Fprintf(&buf, "M%d %dh%dv%dh-%dz", rect.x, rect.y, rect.width, rect.height, rect.width)
+	}
+	buf.WriteString(`"/>`)
+	if logo := layout.logo; logo != nil {
+		fmt.Fprintf(&buf, `<image x="%s" y="%s" width="%s" height="%s" href="data:%s;base64,%s"/>`, pdfNumber(logo.x), pdfNumber(logo.y), pdfNumber(logo.width), pdfNumber(logo.height), logo.mediaType, base64.StdEncoding.EncodeToString(logo.data))
+	}
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes(), nil
+}
+
+func (u *QRCode) StringToPDF(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	return u.stringToPDF(context.Background(), qrString, qrCodeOptions)
+}
+
+func (u *QRCode) stringToPDF(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	layout, err := u.layout(ctx, qrString, qrCodeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	size := float64(layout.size)
+	scale := size / float64(layout.dimension)
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "%s rg\n0 0 %s %s re f\n", pdfColor(layout.background), pdfNumber(size), pdfNumber(size))
+	fmt.Fprintf(&content, "q\n%s 0 0 %s 0 %s cm\n%s rg\n", pdfNumber(scale), pdfNumber(-scale), pdfNumber(size), pdfColor(layout.foreground))
+	for _, rect := range mergeModules(layout.modules) {
+		fmt.Fprintf(&content, "%d %d %d %d re\n", rect.x, rect.y, rect.width, rect.height)
+	}
+	content.WriteString("f\n")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	document := newPDFDocument()
+	resources := ""
+	if logo := layout.logo; logo != nil {
+		imageID, err := document.addImage(logo.image)
+		if err != nil {
+			return nil, err
+		}
+		resources = fmt.Sprintf("/XObject << /Logo %d 0 R >> ", imageID)
+		fmt.Fprintf(&content, "q\n%s 0 0 %s %s %s cm\n/Logo Do\nQ\n", pdfNumber(logo.width), pdfNumber(-logo.height), pdfNumber(logo.x), pdfNumber(logo.y+logo.height))
+	}
+	content.WriteString("Q\n")
+	document.addPage(size, size, content.Bytes(), resources)
+
+	return document.bytes(), nil
+}
+
+func (u *QRCode) StringToFormat(qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	return u.StringToFormatContext(context.Background(), qrString, qrCodeOptions)
+}
+
+// StringToFormatContext is StringToFormat returning ctx.Err() once ctx is
+// done, checked between the rendering steps.
+func (u *QRCode) StringToFormatContext(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) ([]byte, error) {
+	switch qrCodeOptions.Format {
+	case QRCodeFormatPNG:
+		return u.stringToPNG(ctx, qrString, qrCodeOptions)
+	case QRCodeFormatSVG:
+		return u.stringToSVG(ctx, qrString, qrCodeOptions)
+	case QRCodeFormatPDF:
+		return u.stringToPDF(ctx, qrString, qrCodeOptions)
+	default:
+		return nil, fmt.Errorf("unsupported QR code format %s", qrCodeOptions.Format)
+	}
+}
+
+func (u *QRCode) StringToFormatBase64(qrString string, qrCodeOptions *QRCodeOptions) (string, error) {
+	return u.StringToFormatBase64Context(context.Background(), qrString, qrCodeOptions)
+}
+
+func (u *QRCode) StringToFormatBase64Context(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) (string, error) {
+	qrCode, err := u.StringToFormatContext(ctx, qrString, qrCodeOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:" + QRCodeFormatMediaTypes[qrCodeOptions.Format] + ";base64," + base64.StdEncoding.EncodeToString(qrCode), nil
+}
+
+func (u *QRCode) ValidateOptions(qrCodeOptions *QRCodeOptions) error {
+	if _, exists := QRCodeFormatMediaTypes[qrCodeOptions.Format]; !exists {
+		return fmt.Errorf("unsupported QR code format %s", qrCodeOptions.Format)
+	}
+	if _, exists := qrCodeFormatLevelBits[strings.ToUpper(qrCodeOptions.ErrorCorrectionLevel)]; !exists {
+		return fmt.Errorf("unsupported QR code error correction level %s", qrCodeOptions.ErrorCorrectionLevel)
+	}
+	if qrCodeOptions.Margin < 0 {
+		return fmt.Errorf("QR code margin must not be negative")
+	}
+	if qrCodeOptions.Margin > QRCodeMaximumMargin {
+		return fmt.Errorf("QR code margin must not exceed %d", QRCodeMaximumMargin)
+	}
+	if qrCodeOptions.Size <= 0 && qrCodeOptions.ModuleSize <= 0 {
+		return fmt.Errorf("QR code size or module size must be greater than zero")
+	}
+	if qrCodeOptions.Size > QRCodeMaximumSize {
+		return fmt.Errorf("QR code size must not exceed %d", QRCodeMaximumSize)
+	}
+	if qrCodeOptions.ModuleSize > QRCodeMaximumModuleSize {
+		return fmt.Errorf("QR code module size must not exceed %d", QRCodeMaximumModuleSize)
+	}
+
+	foreground, err := parseHexColor(qrCodeOptions.ForegroundColor)
+	if err != nil {
+		return err
+	}
+	background, err := parseHexColor(qrCodeOptions.BackgroundColor)
+	if err != nil {
+		return err
+	}
+	if relativeLuminance(foreground) >= relativeLuminance(background) {
+		return fmt.Errorf("QR code foreground color %s must be darker than background color %s", qrCodeOptions.ForegroundColor, qrCodeOptions.BackgroundColor)
+	}
+	if ratio := contrastRatio(foreground, background); ratio < QRCodeMinimumContrastRatio {
+		return fmt.Errorf("QR code contrast ratio %.2f is below the minimum of %.1f", ratio, QRCodeMinimumContrastRatio)
+	}
+
+	return nil
+}
+
+func (u *QRCode) ImageToString(imageData []byte) (string, error) {
+	return u.ImageToStringContext(context.Background(), imageData)
+}
+
+// ImageToStringContext is ImageToString returning ctx.Err() once ctx is done,
+// checked between the detection and decoding attempts.
+func (u *QRCode) ImageToStringContext(ctx context.Context, imageData []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return "", fmt.Errorf("unsupported QR code image: %v", err)
+	}
+
+	candidates, err := detectQRCodeModules(ctx, img)
+	if err != nil {
+		return "", err
+	}
+
+	for _, modules := range candidates {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if qrString, err := decodeQRCodeModules(modules); err == nil {
+			return qrString, nil
+		}
+	}
+	// A mirrored symbol, e.g. from a front camera, reads as its transpose.
+	for _, modules := range candidates {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		transposed := make([][]bool, len(modules))
+		for y := range transposed {
+			transposed[y] = make([]bool, len(modules))
+			for x := range transposed[y] {
+				transposed[y][x] = modules[x][y]
+			}
+		}
+		if qrString, err := decodeQRCodeModules(transposed); err == nil {
+			return qrString, nil
+		}
+	}
+
+	return "", fmt.Errorf("can not decode QR code in image")
+}
+
+func (u *QRCode) layout(ctx context.Context, qrString string, qrCodeOptions *QRCodeOptions) (*qrCodeLayout, error) {
+	if err := u.ValidateOptions(qrCodeOptions); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	errorCorrectionLevel := qrCodeOptions.ErrorCorrectionLevel
+	if len(qrCodeOptions.Logo) > 0 {
+		errorCorrectionLevel = qrCodeLogoErrorCorrectionLevel
+	}
+
+	modules, err := encodeModules(qrString, errorCorrectionLevel, qrCodeOptions.Margin)
+	if err != nil {
+		return nil, err
+	}
+
+	dimension := len(modules)
+	size := qrCodeOptions.Size
+	if qrCodeOptions.ModuleSize > 0 {
+		size = dimension * qrCodeOptions.ModuleSize
+	}
+	if size < dimension {
+		return nil, fmt.Errorf("can not scale QR code to a size smaller than %dx%d", dimension, dimension)
+	}
+	// The module size alone does not bound the image, the symbol and its
+	// margin grow with the QR string.
+	if size > QRCodeMaximumSize {
+		return nil, fmt.Errorf("QR code of %dx%d modules at module size %d exceeds the maximum size of %d", dimension, dimension, qrCodeOptions.ModuleSize, QRCodeMaximumSize)
+	}
+
+	foreground, _ := parseHexColor(qrCodeOptions.ForegroundColor)
+	background, _ := parseHexColor(qrCodeOptions.BackgroundColor)
+
+	layout := &qrCodeLayout{
+		modules:    modules,
+		dimension:  dimension,
+		size:       size,
+		foreground: foreground,
+		background: background,
+	}
+	if len(qrCodeOptions.Logo) > 0 {
+		if err := layout.placeLogo(qrCodeOptions.Logo, qrCodeOptions.Margin); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := layout.verify(qrString, qrCodeOptions.Margin); err != nil {
+			return nil, err
+		}
+	}
+
+	return layout, nil
+}
+
+// placeLogo centres the logo on the symbol and clears the modules behind it,
+// keeping a padding of one module between the logo and the remaining modules.
+func (l *qrCodeLayout) placeLogo(data []byte, margin int) error {
+	logo, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unsupported QR code logo: %v", err)
+	}
+	bounds := logo.Bounds()
+	if bounds.Empty() {
+		return fmt.Errorf("unsupported QR code logo: empty image")
+	}
+
+	symbolDimension := l.dimension - 2*margin
+	boxSize := int(float64(symbolDimension) * math.Sqrt(QRCodeMaximumLogoAreaPercent/100.0))
+	if boxSize%2 != symbolDimension%2 {
+		boxSize--
+	}
+	if boxSize < 3 {
+		return fmt.Errorf("QR code is too small for a logo")
+	}
+
+	box := qrCodeRect{x: margin + (symbolDimension-boxSize)/2, y: margin + (symbolDimension-boxSize)/2, width: boxSize, height: boxSize}
+	for y := box.y; y < box.y+box.height; y++ {
+		for x := box.x; x < box.x+box.width; x++ {
+			l.modules[y][x] = false
+		}
+	}
+
+	inner := float64(boxSize - 2)
+	scale := inner / float64(max(bounds.Dx(), bounds.Dy()))
+	width, height := float64(bounds.Dx())*scale, float64(bounds.Dy())*scale
+	l.logo = &qrCodeLogo{
+		image:     logo,
+		data:      data,
+		mediaType: "image/" + format,
+		x:         float64(box.x+1) + (inner-width)/2,
+		y:         float64(box.y+1) + (inner-height)/2,
+		width:     width,
+		height:    height,
+	}
+
+	return nil
+}
+
+// verify renders the layout, samples the centre of every module and decodes
+// the result, so a logo that hides too much of the symbol is rejected.
+func (l *qrCodeLayout) verify(qrString string, margin int) error {
+	modulePixels := qrCodeVerificationModulePixels
+	img := l.rasterize(l.dimension*modulePixels, modulePixels, 0)
+
+	threshold := (relativeLuminance(l.foreground) + relativeLuminance(l.background)) / 2
+	symbolDimension := l.dimension - 2*margin
+	modules := make([][]bool, symbolDimension)
+	for y := range modules {
+		modules[y] = make([]bool, symbolDimension)
+		for x := range modules[y] {
+			pixel := color.RGBAModel.Convert(img.At((x+margin)*modulePixels+modulePixels/2, (y+margin)*modulePixels+modulePixels/2)).(color.RGBA)
+			modules[y][x] = relativeLuminance(pixel) < threshold
+		}
+	}
+
+	decoded, err := decodeQRCodeModules(modules)
+	if err != nil {
+		return fmt.Errorf("QR code with logo can not be decoded: %v", err)
+	}
+	if decoded != qrString {
+		return fmt.Errorf("QR code with logo decodes to a different content")
+	}
+
+	return nil
+}
+
+func (l *qrCodeLayout) rasterize(size int, modulePixels int, offset int) draw.Image {
+	palette := color.Palette{l.background, l.foreground}
+	paletted := image.NewPaletted(image.Rect(0, 0, size, size), palette)
+	for y, row := range l.modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for py := 0; py < modulePixels; py++ {
+				start := paletted.PixOffset(offset+x*modulePixels, offset+y*modulePixels+py)
+				for px := 0; px < modulePixels; px++ {
+					paletted.Pix[start+px] = 1
+				}
+			}
+		}
+	}
+	if l.logo == nil {
+		return paletted
+	}
+
+	img := image.NewRGBA(paletted.Bounds())
+	draw.Draw(img, img.Bounds(), paletted, image.Point{}, draw.Src)
+	pixel := func(value float64) int {
+		return offset + int(math.Round(value*float64(modulePixels)))
+	}
+	target := image.Rect(pixel(l.logo.x), pixel(l.logo.y), pixel(l.logo.x+l.logo.width), pixel(l.logo.y+l.logo.height))
+	draw.CatmullRom.Scale(img, target, l.logo.image, l.logo.image.Bounds(), draw.Over, nil)
+
+	return img
+}
+
+func encodeModules(qrString string, errorCorrectionLevel string, margin int) ([][]bool, error) {
+	symbol, err := encodeQRCodeSymbol(qrString, strings.ToUpper(errorCorrectionLevel))
+	if err != nil {
+		return nil, err
+	}
+
+	dimension := len(symbol) + 2*margin
+	modules := make([][]bool, dimension)
+	for y := range modules {
+		modules[y] = make([]bool, dimension)
+	}
+	for y, row := range symbol {
+		copy(modules[y+margin][margin:], row)
+	}
+
+	return modules, nil
+}
+
+// mergeModules joins dark modules into horizontal runs and stacks identical
+// runs of consecutive rows, so each rectangle becomes a single path command.
+func mergeModules(modules [][]bool) []qrCodeRect {
+	var rects []qrCodeRect
+	open := map[[2]int]*qrCodeRect{}
+	for y, row := range modules {
+		next := map[[2]int]*qrCodeRect{}
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+
+			key := [2]int{start, x - start}
+			if rect, exists := open[key]; exists {
+				rect.height++
+				next[key] = rect
+				delete(open, key)
+			} else {
+				next[key] = &qrCodeRect{x: start, y: y, width: x - start, height: 1}
+			}
+		}
+
+		for _, rect := range open {
+			rects = append(rects, *rect)
+		}
+		open = next
+	}
+	for _, rect := range open {
+		rects = append(rects, *rect)
+	}
+
+	sort.Slice(rects, func(i, j int) bool {
+		if rects[i].y != rects[j].y {
+			return rects[i].y < rects[j].y
+		}
+		return rects[i].x < rects[j].x
+	})
+
+	return rects
+}
+
+func parseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid hex color %s", value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid hex color %s", value)
+	}
+
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// relativeLuminance follows the WCAG 2 definition, which is also what the
+// contrast ratio below is based on.
+func relativeLuminance(c color.RGBA) float64 {
+	channel := func(value uint8) float64 {
+		v := float64(value) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+func contrastRatio(foreground color.RGBA, background color.RGBA) float64 {
+	lighter := relativeLuminance(background)
+	darker := relativeLuminance(foreground)
+	if darker > lighter {
+		lighter, darker = darker, lighter
+	}
+
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+func pdfColor(c color.RGBA) string {
+	return pdfNumber(float64(c.R)/255) + " " + pdfNumber(float64(c.G)/255) + " " + pdfNumber(float64(c.B)/255)
+}
+
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10000)/10000, 'f', -1, 64)
+}
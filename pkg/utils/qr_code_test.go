@@ -1,8 +1,18 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	"image/png"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -88,3 +98,548 @@ func TestQRCODEStringToImageBase64(t *testing.T) {
 		})
 	}
 }
+
+func TestQRCodeStringToPNG(t *testing.T) {
+	u := QRCode{}
+
+	got, err := u.StringToPNG(testQRString, &QRCodeOptions{
+		Format:               QRCodeFormatPNG,
+		ModuleSize:           3,
+		Margin:               4,
+		ErrorCorrectionLevel: "L",
+		ForegroundColor:      "#1A237E",
+		BackgroundColor:      "#FFFFFF",
+	})
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "StringToPNG()", nil, err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(got))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "png.Decode()", nil, err)
+	}
+	if size := img.Bounds().Dx(); size != (21+8)*3 {
+		t.Errorf(expectedButGotMessage, "StringToPNG() size", (21+8)*3, size)
+	}
+	if r, g, b, _ := img.At(0, 0).RGBA(); r>>8 != 0xff || g>>8 != 0xff || b>>8 != 0xff {
+		t.Errorf(expectedButGotMessage, "StringToPNG() quiet zone", "#ffffff", img.At(0, 0))
+	}
+	if r, g, b, _ := img.At(4*3, 4*3).RGBA(); r>>8 != 0x1a || g>>8 != 0x23 || b>>8 != 0x7e {
+		t.Errorf(expectedButGotMessage, "StringToPNG() finder pattern", "#1a237e", img.At(4*3, 4*3))
+	}
+}
+
+func TestQRCodeStringToSVG(t *testing.T) {
+	type args struct {
+		qrString      string
+		qrCodeOptions *QRCodeOptions
+	}
+
+	tests := []struct {
+		name      string
+		fields    QRCode
+		args      args
+		want      []byte
+		wantError error
+	}{
+		{
+			name:   "Error: QR Code Size",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions(QRCodeFormatSVG, 20),
+			},
+			want:      nil,
+			wantError: fmt.Errorf("can not scale QR code to a size smaller than 21x21"),
+		},
+		{
+			name:   "Success",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions(QRCodeFormatSVG, 125),
+			},
+			want:      []byte(testQRCodeSVG),
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := test.fields
+
+			got, err := u.StringToSVG(test.args.qrString, test.args.qrCodeOptions)
+			if err != nil && err.Error() != test.wantError.Error() {
+				t.Errorf(expectedErrorButGotMessage, "StringToSVG()", test.wantError, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "StringToSVG()", string(test.want), string(got))
+			}
+		})
+	}
+}
+
+func TestQRCodeMaximumImageSize(t *testing.T) {
+	u := QRCode{}
+	qrCodeOptions := testQRCodeOptions(QRCodeFormatPNG, 0)
+	qrCodeOptions.ModuleSize = QRCodeMaximumModuleSize
+	qrCodeOptions.Margin = QRCodeMaximumMargin
+
+	for _, format := range []string{QRCodeFormatPNG, QRCodeFormatSVG, QRCodeFormatPDF} {
+		qrCodeOptions.Format = format
+		_, err := u.StringToFormat(strings.Repeat("QRIS", 100), qrCodeOptions)
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("exceeds the maximum size of %d", QRCodeMaximumSize)) {
+			t.Errorf(expectedErrorButGotMessage, "StringToFormat("+format+")", "QR code exceeds the maximum size", err)
+		}
+	}
+}
+
+func TestQRCodeStringToPDF(t *testing.T) {
+	u := QRCode{}
+
+	got, err := u.StringToPDF(testQRString, testQRCodeOptions(QRCodeFormatPDF, 125))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "StringToPDF()", nil, err)
+	}
+	if !bytes.HasPrefix(got, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(got, []byte("%%EOF\n")) {
+		t.Errorf(expectedButGotMessage, "StringToPDF()", "a PDF document", string(got))
+	}
+	if !bytes.Contains(got, []byte("/MediaBox [0 0 125 125]")) {
+		t.Errorf(expectedButGotMessage, "StringToPDF() media box", "[0 0 125 125]", string(got))
+	}
+
+	trailer := got[bytes.LastIndex(got, []byte("startxref\n"))+len("startxref\n"):]
+	offset, err := strconv.Atoi(string(trailer[:bytes.IndexByte(trailer, '\n')]))
+	if err != nil || !bytes.HasPrefix(got[offset:], []byte("xref\n")) {
+		t.Errorf(expectedButGotMessage, "StringToPDF() startxref", "offset of xref table", offset)
+	}
+
+	_, err = u.StringToPDF(testQRString, testQRCodeOptions(QRCodeFormatPDF, 20))
+	if err == nil {
+		t.Errorf(expectedErrorButGotMessage, "StringToPDF()", "can not scale QR code", err)
+	}
+}
+
+func TestQRCodeStringToFormatWithLogo(t *testing.T) {
+	type args struct {
+		qrCodeOptions *QRCodeOptions
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantError error
+	}{
+		{
+			name: "Error: Invalid Logo",
+			args: args{
+				qrCodeOptions: &QRCodeOptions{Format: QRCodeFormatPNG, Size: 400, Margin: 4, ErrorCorrectionLevel: "L", ForegroundColor: "#000000", BackgroundColor: "#FFFFFF", Logo: []byte("logo")},
+			},
+			want:      "",
+			wantError: fmt.Errorf("unsupported QR code logo: image: unknown format"),
+		},
+		{
+			name: "Success: PNG Logo",
+			args: args{
+				qrCodeOptions: &QRCodeOptions{Format: QRCodeFormatPNG, Size: 400, Margin: 4, ErrorCorrectionLevel: "L", ForegroundColor: "#000000", BackgroundColor: "#FFFFFF", Logo: testLogo("png", 120, 80)},
+			},
+			want:      "\x89PNG",
+			wantError: nil,
+		},
+		{
+			name: "Success: SVG With JPEG Logo",
+			args: args{
+				qrCodeOptions: &QRCodeOptions{Format: QRCodeFormatSVG, Size: 400, Margin: 4, ErrorCorrectionLevel: "M", ForegroundColor: "#000000", BackgroundColor: "#FFFFFF", Logo: testLogo("jpeg", 64, 64)},
+			},
+			want:      `href="data:image/jpeg;base64,`,
+			wantError: nil,
+		},
+		{
+			name: "Success: PDF Logo",
+			args: args{
+				qrCodeOptions: &QRCodeOptions{Format: QRCodeFormatPDF, Size: 400, Margin: 4, ErrorCorrectionLevel: "Q", ForegroundColor: "#000000", BackgroundColor: "#FFFFFF", Logo: testLogo("png", 80, 120)},
+			},
+			want:      "/XObject << /Logo ",
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := &QRCode{}
+
+			got, err := u.StringToFormat(testStickerContent.QRString, test.args.qrCodeOptions)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "StringToFormat()", test.wantError, err)
+			}
+			if !bytes.Contains(got, []byte(test.want)) {
+				t.Errorf(expectedResponseToContain, test.want, string(got))
+			}
+		})
+	}
+}
+
+func TestQRCodeLayoutWithLogo(t *testing.T) {
+	u := &QRCode{}
+	qrCodeOptions := &QRCodeOptions{Format: QRCodeFormatPNG, Size: 400, Margin: 4, ErrorCorrectionLevel: "L", ForegroundColor: "#000000", BackgroundColor: "#FFFFFF", Logo: testLogo("png", 120, 80)}
+
+	layout, err := u.layout(context.Background(), testStickerContent.QRString, qrCodeOptions)
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "layout()", nil, err)
+	}
+
+	symbol := make([][]bool, layout.dimension-8)
+	for y := range symbol {
+		symbol[y] = layout.modules[y+4][4 : layout.dimension-4]
+	}
+	errorCorrectionLevel, _, err := readQRCodeFormat(symbol)
+	if err != nil || errorCorrectionLevel != "H" {
+		t.Errorf(expectedButGotMessage, "layout() error correction level", "H", errorCorrectionLevel)
+	}
+
+	symbolDimension := float64(len(symbol))
+	if area := layout.logo.width * layout.logo.height; area > symbolDimension*symbolDimension*QRCodeMaximumLogoAreaPercent/100 {
+		t.Errorf(expectedButGotMessage, "layout() logo area", "at most 10% of the symbol", area)
+	}
+	center := layout.dimension / 2
+	if layout.modules[center][center] {
+		t.Errorf(expectedButGotMessage, "layout() module behind logo", false, true)
+	}
+
+	got, err := decodeQRCodeModules(symbol)
+	if err != nil || got != testStickerContent.QRString {
+		t.Errorf(expectedButGotMessage, "decodeQRCodeModules()", testStickerContent.QRString, got)
+	}
+}
+
+func TestQRCodeImageToString(t *testing.T) {
+	u := &QRCode{}
+	qrCode, err := u.StringToPNG(testStickerContent.QRString, testQRCodeOptions(QRCodeFormatPNG, 400))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "StringToPNG()", nil, err)
+	}
+	qrCodeOptions := testQRCodeOptions(QRCodeFormatPNG, 400)
+	qrCodeOptions.Logo = testLogo("png", 120, 80)
+	qrCodeWithLogo, err := u.StringToPNG(testStickerContent.QRString, qrCodeOptions)
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "StringToPNG()", nil, err)
+	}
+	var blank bytes.Buffer
+	png.Encode(&blank, image.NewGray(image.Rect(0, 0, 200, 200)))
+
+	type args struct {
+		imageData []byte
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantError error
+	}{
+		{
+			name: "Error: Invalid Image",
+			args: args{
+				imageData: []byte("image"),
+			},
+			want:      "",
+			wantError: fmt.Errorf("unsupported QR code image: image: unknown format"),
+		},
+		{
+			name: "Error: No QR Code",
+			args: args{
+				imageData: blank.Bytes(),
+			},
+			want:      "",
+			wantError: fmt.Errorf("can not find QR code in image"),
+		},
+		{
+			name: "Success: PNG",
+			args: args{
+				imageData: qrCode,
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Success: PNG With Logo",
+			args: args{
+				imageData: qrCodeWithLogo,
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Success: Rotated And Scaled Photo",
+			args: args{
+				imageData: testPhoto(qrCode, 0.6, 0.8, false),
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Success: Upside Down Photo",
+			args: args{
+				imageData: testPhoto(qrCode, math.Pi+0.1, 1.2, false),
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Success: Mirrored Photo",
+			args: args{
+				imageData: testPhoto(qrCode, -0.3, 1, true),
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := u.ImageToString(test.args.imageData)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "ImageToString()", test.wantError, err)
+			}
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "ImageToString()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestQRCodeStringToFormatBase64(t *testing.T) {
+	type args struct {
+		qrString      string
+		qrCodeOptions *QRCodeOptions
+	}
+
+	tests := []struct {
+		name       string
+		fields     QRCode
+		args       args
+		wantPrefix string
+		wantError  error
+	}{
+		{
+			name:   "Error: Unsupported Format",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions("gif", 125),
+			},
+			wantPrefix: "",
+			wantError:  fmt.Errorf("unsupported QR code format gif"),
+		},
+		{
+			name:   "Success: PNG",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions(QRCodeFormatPNG, 125),
+			},
+			wantPrefix: "data:image/png;base64,iVBORw0KGgo",
+			wantError:  nil,
+		},
+		{
+			name:   "Success: SVG",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions(QRCodeFormatSVG, 125),
+			},
+			wantPrefix: "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(testQRCodeSVG)),
+			wantError:  nil,
+		},
+		{
+			name:   "Success: PDF",
+			fields: QRCode{},
+			args: args{
+				qrString:      testQRString,
+				qrCodeOptions: testQRCodeOptions(QRCodeFormatPDF, 125),
+			},
+			wantPrefix: "data:application/pdf;base64,JVBERi0xLjQK",
+			wantError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := test.fields
+
+			got, err := u.StringToFormatBase64(test.args.qrString, test.args.qrCodeOptions)
+			if err != nil && err.Error() != test.wantError.Error() {
+				t.Errorf(expectedErrorButGotMessage, "StringToFormatBase64()", test.wantError, err)
+			}
+			if !strings.HasPrefix(got, test.wantPrefix) {
+				t.Errorf(expectedButGotMessage, "StringToFormatBase64()", test.wantPrefix, got)
+			}
+		})
+	}
+}
+
+func TestQRCodeValidateOptions(t *testing.T) {
+	withOptions := func(modify func(qrCodeOptions *QRCodeOptions)) *QRCodeOptions {
+		qrCodeOptions := DefaultQRCodeOptions()
+		modify(qrCodeOptions)
+		return qrCodeOptions
+	}
+
+	tests := []struct {
+		name          string
+		qrCodeOptions *QRCodeOptions
+		wantError     error
+	}{
+		{
+			name:          "Error: Format",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Format = "gif" }),
+			wantError:     fmt.Errorf("unsupported QR code format gif"),
+		},
+		{
+			name:          "Error: Error Correction Level",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.ErrorCorrectionLevel = "X" }),
+			wantError:     fmt.Errorf("unsupported QR code error correction level X"),
+		},
+		{
+			name:          "Error: Margin",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Margin = -1 }),
+			wantError:     fmt.Errorf("QR code margin must not be negative"),
+		},
+		{
+			name:          "Error: Size",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Size = 0 }),
+			wantError:     fmt.Errorf("QR code size or module size must be greater than zero"),
+		},
+		{
+			name:          "Error: Maximum Size",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Size = QRCodeMaximumSize + 1 }),
+			wantError:     fmt.Errorf("QR code size must not exceed 4096"),
+		},
+		{
+			name:          "Error: Maximum Module Size",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Size, o.ModuleSize = 0, QRCodeMaximumModuleSize+1 }),
+			wantError:     fmt.Errorf("QR code module size must not exceed 64"),
+		},
+		{
+			name:          "Error: Maximum Margin",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.Margin = QRCodeMaximumMargin + 1 }),
+			wantError:     fmt.Errorf("QR code margin must not exceed 16"),
+		},
+		{
+			name:          "Error: Foreground Color",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.ForegroundColor = "black" }),
+			wantError:     fmt.Errorf("invalid hex color black"),
+		},
+		{
+			name:          "Error: Inverted Colors",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.ForegroundColor, o.BackgroundColor = "#FFFFFF", "#000000" }),
+			wantError:     fmt.Errorf("QR code foreground color #FFFFFF must be darker than background color #000000"),
+		},
+		{
+			name:          "Error: Contrast Ratio",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) { o.ForegroundColor = "#AAAAAA" }),
+			wantError:     fmt.Errorf("QR code contrast ratio 2.32 is below the minimum of 3.0"),
+		},
+		{
+			name: "Success: Brand Colors",
+			qrCodeOptions: withOptions(func(o *QRCodeOptions) {
+				o.ForegroundColor, o.BackgroundColor, o.ErrorCorrectionLevel = "#C62828", "#FFF", "q"
+			}),
+			wantError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := QRCode{}
+
+			err := u.ValidateOptions(test.qrCodeOptions)
+			if !reflect.DeepEqual(err, test.wantError) {
+				t.Errorf(expectedErrorButGotMessage, "ValidateOptions()", test.wantError, err)
+			}
+		})
+	}
+}
+
+func TestMergeQRCodeOptions(t *testing.T) {
+	defaults := DefaultQRCodeOptions()
+
+	got := MergeQRCodeOptions(defaults, &QRCodeOptions{
+		Format:               QRCodeFormatSVG,
+		ModuleSize:           8,
+		ErrorCorrectionLevel: "M",
+	})
+	want := &QRCodeOptions{
+		Format:               QRCodeFormatSVG,
+		ModuleSize:           8,
+		Margin:               4,
+		ErrorCorrectionLevel: "M",
+		ForegroundColor:      "#000000",
+		BackgroundColor:      "#FFFFFF",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(expectedButGotMessage, "MergeQRCodeOptions()", want, got)
+	}
+	if !reflect.DeepEqual(defaults, DefaultQRCodeOptions()) {
+		t.Errorf(expectedButGotMessage, "MergeQRCodeOptions() defaults", DefaultQRCodeOptions(), defaults)
+	}
+	if got := MergeQRCodeOptions(defaults, nil); !reflect.DeepEqual(got, defaults) {
+		t.Errorf(expectedButGotMessage, "MergeQRCodeOptions()", defaults, got)
+	}
+
+	var overrides QRCodeOptions
+	if err := json.Unmarshal([]byte(`{"format": "svg", "margin": 0}`), &overrides); err != nil {
+		t.Fatalf(expectedButGotMessage, "json.Unmarshal() error", nil, err)
+	}
+	if got := MergeQRCodeOptions(defaults, &overrides); got.Margin != 0 || got.Format != QRCodeFormatSVG {
+		t.Errorf(expectedButGotMessage, "MergeQRCodeOptions() margin", 0, got.Margin)
+	}
+	overrides = QRCodeOptions{}
+	if err := json.Unmarshal([]byte(`{"format": "svg"}`), &overrides); err != nil {
+		t.Fatalf(expectedButGotMessage, "json.Unmarshal() error", nil, err)
+	}
+	if got := MergeQRCodeOptions(defaults, &overrides); got.Margin != defaults.Margin {
+		t.Errorf(expectedButGotMessage, "MergeQRCodeOptions() margin", defaults.Margin, got.Margin)
+	}
+}
+
+func TestMergeModules(t *testing.T) {
+	modules := [][]bool{
+		{true, true, false, true},
+		{true, true, false, false},
+		{false, true, true, true},
+	}
+	want := []qrCodeRect{
+		{x: 0, y: 0, width: 2, height: 2},
+		{x: 3, y: 0, width: 1, height: 1},
+		{x: 1, y: 2, width: 3, height: 1},
+	}
+
+	got := mergeModules(modules)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(expectedButGotMessage, "mergeModules()", want, got)
+	}
+}
+
+func TestQRCodeContext(t *testing.T) {
+	u := &QRCode{}
+	qrCode, err := u.StringToPNG(testStickerContent.QRString, testQRCodeOptions(QRCodeFormatPNG, 400))
+	if err != nil {
+		t.Fatalf(expectedErrorButGotMessage, "StringToPNG()", nil, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := u.ImageToStringContext(ctx, qrCode); !errors.Is(err, context.Canceled) {
+		t.Errorf(expectedErrorButGotMessage, "ImageToStringContext()", context.Canceled, err)
+	}
+	for _, format := range []string{QRCodeFormatPNG, QRCodeFormatSVG, QRCodeFormatPDF} {
+		if _, err := u.StringToFormatBase64Context(ctx, testStickerContent.QRString, testQRCodeOptions(format, 400)); !errors.Is(err, context.Canceled) {
+			t.Errorf(expectedErrorButGotMessage, "StringToFormatBase64Context() "+format, context.Canceled, err)
+		}
+	}
+	if got, err := u.ImageToStringContext(context.Background(), qrCode); err != nil || got != testStickerContent.QRString {
+		t.Errorf(expectedButGotMessage, "ImageToStringContext()", testStickerContent.QRString, got)
+	}
+}
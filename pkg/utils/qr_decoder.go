@@ -0,0 +1,300 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const qrCodeAlphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+type qrCodeBitReader struct {
+	data     []byte
+	position int
+}
+
+// decodeQRCodeModules decodes a symbol given as a module matrix without quiet
+// zone, where true is a dark module.
+func decodeQRCodeModules(modules [][]bool) (string, error) {
+	dimension := len(modules)
+	version := (dimension - 17) / 4
+	if (dimension-17)%4 != 0 || version < qrCodeMinimumVersion || version > qrCodeMaximumVersion {
+		return "", fmt.Errorf("invalid QR code dimension %d", dimension)
+	}
+	for _, row := range modules {
+		if len(row) != dimension {
+			return "", fmt.Errorf("QR code module matrix must be square")
+		}
+	}
+
+	errorCorrectionLevel, mask, err := readQRCodeFormat(modules)
+	if err != nil {
+		return "", err
+	}
+
+	rawCodewords := qrCodeRawDataModules(version) / 8
+	codewords := make([]byte, rawCodewords)
+	for i, position := range qrCodeDataPositions(version)[:rawCodewords*8] {
+		x, y := position[0], position[1]
+		if modules[y][x] != qrCodeMask(mask, x, y) {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	data, err := correctQRCodeCodewords(codewords, version, errorCorrectionLevel)
+	if err != nil {
+		return "", err
+	}
+
+	return parseQRCodeBitstream(data, version)
+}
+
+// readQRCodeFormat picks the format information closest to either copy in the
+// symbol, which tolerates up to three damaged bits.
+func readQRCodeFormat(modules [][]bool) (string, int, error) {
+	first, second := qrCodeFormatPositions(len(modules))
+	read := func(positions [15][2]int) int {
+		value := 0
+		for i, position := range positions {
+			if modules[position[1]][position[0]] {
+				value |= 1 << i
+			}
+		}
+		return value
+	}
+	firstBits, secondBits := read(first), read(second)
+
+	bestDistance := 16
+	bestLevel, bestMask := "", 0
+	for level := range qrCodeFormatLevelBits {
+		for mask := 0; mask < 8; mask++ {
+			formatBits := qrCodeFormatInformation(level, mask)
+			distance := min(qrCodeHammingDistance(formatBits, firstBits), qrCodeHammingDistance(formatBits, secondBits))
+			if distance < bestDistance {
+				bestDistance, bestLevel, bestMask = distance, level, mask
+			}
+		}
+	}
+	if bestDistance > 3 {
+		return "", 0, fmt.Errorf("can not read QR code format information")
+	}
+
+	return bestLevel, bestMask, nil
+}
+
+// correctQRCodeCodewords splits the interleaved codewords back into their
+// blocks, corrects each block and joins the data codewords.
+func correctQRCodeCodewords(codewords []byte, version int, errorCorrectionLevel string) ([]byte, error) {
+	blockCount := qrCodeErrorCorrectionBlocks[errorCorrectionLevel][version]
+	eccLength := qrCodeECCCodewordsPerBlock[errorCorrectionLevel][version]
+	shortBlocks := blockCount - len(codewords)%blockCount
+	shortLength := len(codewords) / blockCount
+
+	blocks := make([][]byte, blockCount)
+	index := 0
+	for i := 0; i <= shortLength-eccLength; i++ {
+		for j := range blocks {
+			if i == shortLength-eccLength && j < shortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], codewords[index])
+			index++
+		}
+	}
+	for i := 0; i < eccLength; i++ {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[index])
+			index++
+		}
+	}
+
+	var data []byte
+	for _, block := range blocks {
+		if err := correctReedSolomon(block, eccLength); err != nil {
+			return nil, err
+		}
+		data = append(data, block[:len(block)-eccLength]...)
+	}
+
+	return data, nil
+}
+
+// correctReedSolomon fixes a codeword in place using Berlekamp-Massey for the
+// error locator and Forney's formula for the error values.
+func correctReedSolomon(codeword []byte, eccLength int) error {
+	syndromes := make([]byte, eccLength)
+	clean := true
+	for i := range syndromes {
+		for _, value := range codeword {
+			syndromes[i] = qrCodeGaloisMultiply(syndromes[i], qrCodeGaloisExp[i]) ^ value
+		}
+		if syndromes[i] != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return nil
+	}
+
+	locator, previous := []byte{1}, []byte{1}
+	errors, shift, previousDiscrepancy := 0, 1, byte(1)
+	for n := 0; n < eccLength; n++ {
+		discrepancy := syndromes[n]
+		for i := 1; i <= errors && i < len(locator); i++ {
+			discrepancy ^= qrCodeGaloisMultiply(locator[i], syndromes[n-i])
+		}
+		if discrepancy == 0 {
+			shift++
+			continue
+		}
+
+		scale := qrCodeGaloisDivide(discrepancy, previousDiscrepancy)
+		next := make([]byte, max(len(locator), len(previous)+shift))
+		copy(next, locator)
+		for i, value := range previous {
+			next[i+shift] ^= qrCodeGaloisMultiply(scale, value)
+		}
+		if 2*errors <= n {
+			previous = locator
+			errors = n + 1 - errors
+			previousDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+	if 2*errors > eccLength {
+		return fmt.Errorf("too many errors in QR code")
+	}
+
+	var positions []int
+	for position := 0; position < len(codeword); position++ {
+		if qrCodeGaloisEvaluate(locator, qrCodeGaloisExp[255-position%255]) == 0 {
+			positions = append(positions, position)
+		}
+	}
+	if len(positions) != errors {
+		return fmt.Errorf("too many errors in QR code")
+	}
+
+	evaluator := make([]byte, eccLength)
+	for i := range evaluator {
+		for j := 0; j <= i && j < len(locator); j++ {
+			evaluator[i] ^= qrCodeGaloisMultiply(locator[j], syndromes[i-j])
+		}
+	}
+
+	for _, position := range positions {
+		inverse := qrCodeGaloisExp[255-position%255]
+		var derivative byte
+		for i := 1; i < len(locator); i += 2 {
+			derivative ^= qrCodeGaloisMultiply(locator[i], qrCodeGaloisExp[(qrCodeGaloisLog[inverse]*(i-1))%255])
+		}
+		if derivative == 0 {
+			return fmt.Errorf("too many errors in QR code")
+		}
+		magnitude := qrCodeGaloisMultiply(qrCodeGaloisExp[position%255], qrCodeGaloisDivide(qrCodeGaloisEvaluate(evaluator, inverse), derivative))
+		codeword[len(codeword)-1-position] ^= magnitude
+	}
+
+	return nil
+}
+
+func parseQRCodeBitstream(data []byte, version int) (string, error) {
+	reader := &qrCodeBitReader{data: data}
+	countGroup := 0
+	if version >= 27 {
+		countGroup = 2
+	} else if version >= 10 {
+		countGroup = 1
+	}
+
+	var result strings.Builder
+	for reader.available() >= 4 {
+		mode := reader.read(4)
+		switch mode {
+		case 0x0:
+			return result.String(), nil
+		case 0x1:
+			count := reader.read([]int{10, 12, 14}[countGroup])
+			if reader.available() < count/3*10+[]int{0, 4, 7}[count%3] {
+				return "", fmt.Errorf("truncated QR code numeric segment")
+			}
+			for ; count > 0; count -= 3 {
+				digits, length := 3, 10
+				if count < 3 {
+					digits, length = count, []int{0, 4, 7}[count]
+				}
+				value := reader.read(length)
+				text := fmt.Sprintf("%0*d", digits, value)
+				if len(text) != digits {
+					return "", fmt.Errorf("invalid QR code numeric segment")
+				}
+				result.WriteString(text)
+			}
+		case 0x2:
+			count := reader.read([]int{9, 11, 13}[countGroup])
+			if reader.available() < count/2*11+count%2*6 {
+				return "", fmt.Errorf("truncated QR code alphanumeric segment")
+			}
+			for ; count > 0; count -= 2 {
+				if count == 1 {
+					value := reader.read(6)
+					if value >= len(qrCodeAlphanumericCharset) {
+						return "", fmt.Errorf("invalid QR code alphanumeric segment")
+					}
+					result.WriteByte(qrCodeAlphanumericCharset[value])
+					break
+				}
+				value := reader.read(11)
+				if value >= len(qrCodeAlphanumericCharset)*len(qrCodeAlphanumericCharset) {
+					return "", fmt.Errorf("invalid QR code alphanumeric segment")
+				}
+				result.WriteByte(qrCodeAlphanumericCharset[value/45])
+				result.WriteByte(qrCodeAlphanumericCharset[value%45])
+			}
+		case 0x4:
+			count := reader.read([]int{8, 16, 16}[countGroup])
+			if reader.available() < count*8 {
+				return "", fmt.Errorf("truncated QR code byte segment")
+			}
+			for i := 0; i < count; i++ {
+				result.WriteByte(byte(reader.read(8)))
+			}
+		case 0x7:
+			designator := reader.read(8)
+			if designator&0xc0 == 0x80 {
+				reader.read(8)
+			} else if designator&0xe0 == 0xc0 {
+				reader.read(16)
+			}
+		case 0x3:
+			reader.read(16)
+		case 0x5:
+		case 0x9:
+			reader.read(8)
+		default:
+			return "", fmt.Errorf("unsupported QR code mode %d", mode)
+		}
+	}
+
+	return result.String(), nil
+}
+
+func (r *qrCodeBitReader) available() int {
+	return len(r.data)*8 - r.position
+}
+
+// read returns the next length bits, padding with zeros past the end.
+func (r *qrCodeBitReader) read(length int) int {
+	value := 0
+	for i := 0; i < length; i++ {
+		value <<= 1
+		if r.position < len(r.data)*8 && r.data[r.position/8]&(1<<(7-r.position%8)) != 0 {
+			value |= 1
+		}
+		r.position++
+	}
+
+	return value
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeQRCodeModules(t *testing.T) {
+	type args struct {
+		qrString             string
+		errorCorrectionLevel string
+		damagedCodewords     int
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantError error
+	}{
+		{
+			name: "Success: Numeric",
+			args: args{
+				qrString:             "0123456789012345",
+				errorCorrectionLevel: "L",
+			},
+			want:      "0123456789012345",
+			wantError: nil,
+		},
+		{
+			name: "Success: Alphanumeric",
+			args: args{
+				qrString:             "HTTPS://QRIS.ID/$ 45%",
+				errorCorrectionLevel: "M",
+			},
+			want:      "HTTPS://QRIS.ID/$ 45%",
+			wantError: nil,
+		},
+		{
+			name: "Success: QRIS",
+			args: args{
+				qrString:             testStickerContent.QRString,
+				errorCorrectionLevel: "Q",
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Success: Version With Version Information",
+			args: args{
+				qrString:             strings.Repeat(testStickerContent.QRString, 4),
+				errorCorrectionLevel: "L",
+			},
+			want:      strings.Repeat(testStickerContent.QRString, 4),
+			wantError: nil,
+		},
+		{
+			name: "Success: Corrected Errors",
+			args: args{
+				qrString:             testStickerContent.QRString,
+				errorCorrectionLevel: "H",
+				damagedCodewords:     40,
+			},
+			want:      testStickerContent.QRString,
+			wantError: nil,
+		},
+		{
+			name: "Error: Too Many Errors",
+			args: args{
+				qrString:             testStickerContent.QRString,
+				errorCorrectionLevel: "L",
+				damagedCodewords:     30,
+			},
+			want:      "",
+			wantError: fmt.Errorf("too many errors in QR code"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules, err := encodeModules(test.args.qrString, test.args.errorCorrectionLevel, 0)
+			if err != nil {
+				t.Fatalf(expectedErrorButGotMessage, "encodeModules()", nil, err)
+			}
+
+			// Damage whole codewords spread over every block, every 6th one
+			// in placement order, by inverting their eight modules.
+			positions := qrCodeDataPositions((len(modules) - 17) / 4)
+			for i := 0; i < test.args.damagedCodewords; i++ {
+				for _, position := range positions[i*6*8 : i*6*8+8] {
+					modules[position[1]][position[0]] = !modules[position[1]][position[0]]
+				}
+			}
+
+			got, err := decodeQRCodeModules(modules)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Errorf(expectedErrorButGotMessage, "decodeQRCodeModules()", test.wantError, err)
+			}
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "decodeQRCodeModules()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeQRCodeModulesInvalidSymbol(t *testing.T) {
+	modules := make([][]bool, 22)
+	for y := range modules {
+		modules[y] = make([]bool, 22)
+	}
+	if _, err := decodeQRCodeModules(modules); err == nil || err.Error() != "invalid QR code dimension 22" {
+		t.Errorf(expectedErrorButGotMessage, "decodeQRCodeModules()", "invalid QR code dimension 22", err)
+	}
+
+	modules, _ = encodeModules(testQRString, "L", 0)
+	first, second := qrCodeFormatPositions(len(modules))
+	for i := 0; i < 8; i++ {
+		modules[first[i][1]][first[i][0]] = !modules[first[i][1]][first[i][0]]
+		modules[second[i][1]][second[i][0]] = !modules[second[i][1]][second[i][0]]
+	}
+	if _, err := decodeQRCodeModules(modules); err == nil || err.Error() != "can not read QR code format information" {
+		t.Errorf(expectedErrorButGotMessage, "decodeQRCodeModules()", "can not read QR code format information", err)
+	}
+}
+
+func TestQRCodeAlignmentPositions(t *testing.T) {
+	tests := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+
+	for version, want := range tests {
+		got := qrCodeAlignmentPositions(version)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf(expectedButGotMessage, fmt.Sprintf("qrCodeAlignmentPositions(%d)", version), want, got)
+		}
+	}
+}
@@ -0,0 +1,562 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+const (
+	qrCodeBinarizerBlockSize       = 8
+	qrCodeBinarizerMinimumContrast = 24
+	qrCodeMaximumFinderCandidates  = 8
+	qrCodeMaximumFinderTriples     = 4
+)
+
+// qrCodeBitmap holds a binarised image, true is a dark pixel.
+type qrCodeBitmap struct {
+	width  int
+	height int
+	pixels []bool
+}
+
+type qrCodeFinderPattern struct {
+	x          float64
+	y          float64
+	moduleSize float64
+	count      int
+}
+
+// qrCodeHomography maps symbol coordinates in modules to image coordinates.
+type qrCodeHomography [8]float64
+
+// detectQRCodeModules locates a symbol in an image and samples its module
+// matrix. Every plausible reading is returned, best guess first, since the
+// decoder is the only reliable judge of which one is right. It stops with
+// ctx.Err() once ctx is done.
+func detectQRCodeModules(ctx context.Context, img image.Image) ([][][]bool, error) {
+	bitmap := binarizeQRCodeImage(img)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	finderPatterns := bitmap.findFinderPatterns()
+	if len(finderPatterns) < 3 {
+		return nil, fmt.Errorf("can not find QR code in image")
+	}
+
+	var candidates [][][]bool
+	for _, triple := range selectQRCodeFinderTriples(finderPatterns) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		topLeft, topRight, bottomLeft := orderQRCodeFinderPatterns(triple)
+		horizontalModuleSize := (bitmap.finderModuleSize(topLeft, topRight) + bitmap.finderModuleSize(topRight, topLeft)) / 2
+		verticalModuleSize := (bitmap.finderModuleSize(topLeft, bottomLeft) + bitmap.finderModuleSize(bottomLeft, topLeft)) / 2
+		moduleSize := (horizontalModuleSize + verticalModuleSize) / 2
+		estimate := (qrCodeDistance(topLeft, topRight)/horizontalModuleSize+qrCodeDistance(topLeft, bottomLeft)/verticalModuleSize)/2 + 7
+		for _, dimension := range qrCodeDimensionCandidates(estimate) {
+			for _, homography := range bitmap.qrCodeHomographies(topLeft, topRight, bottomLeft, moduleSize, dimension) {
+				if modules := bitmap.sample(homography, dimension); modules != nil {
+					candidates = append(candidates, modules)
+				}
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("can not find QR code in image")
+	}
+
+	return candidates, nil
+}
+
+// binarizeQRCodeImage thresholds every 8x8 block against the average of its
+// 5x5 neighbourhood of blocks, so uneven lighting in photos and screenshots
+// does not swallow parts of the symbol.
+func binarizeQRCodeImage(img image.Image) *qrCodeBitmap {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	luminance := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gray := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			luminance[y*width+x] = int(gray.Y)
+		}
+	}
+
+	blocksX := (width + qrCodeBinarizerBlockSize - 1) / qrCodeBinarizerBlockSize
+	blocksY := (height + qrCodeBinarizerBlockSize - 1) / qrCodeBinarizerBlockSize
+	blackPoints := make([][]int, blocksY)
+	for by := range blackPoints {
+		blackPoints[by] = make([]int, blocksX)
+		for bx := range blackPoints[by] {
+			sum, count, minimum, maximum := 0, 0, 255, 0
+			for y := by * qrCodeBinarizerBlockSize; y < min((by+1)*qrCodeBinarizerBlockSize, height); y++ {
+				for x := bx * qrCodeBinarizerBlockSize; x < min((bx+1)*qrCodeBinarizerBlockSize, width); x++ {
+					value := luminance[y*width+x]
+					sum += value
+					count++
+					minimum = min(minimum, value)
+					maximum = max(maximum, value)
+				}
+			}
+
+			average := sum / count
+			if maximum-minimum <= qrCodeBinarizerMinimumContrast {
+				// A flat block is assumed to be background, unless its
+				// neighbours show it sits inside a dark area.
+				average = minimum / 2
+				if by > 0 && bx > 0 {
+					neighbours := (blackPoints[by-1][bx] + 2*blackPoints[by][bx-1] + blackPoints[by-1][bx-1]) / 4
+					if minimum < neighbours {
+						average = neighbours
+					}
+				}
+			}
+			blackPoints[by][bx] = average
+		}
+	}
+
+	bitmap := &qrCodeBitmap{width: width, height: height, pixels: make([]bool, width*height)}
+	for by := 0; by < blocksY; by++ {
+		for bx := 0; bx < blocksX; bx++ {
+			sum := 0
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					sum += blackPoints[min(max(by+dy, 0), blocksY-1)][min(max(bx+dx, 0), blocksX-1)]
+				}
+			}
+			threshold := sum / 25
+			for y := by * qrCodeBinarizerBlockSize; y < min((by+1)*qrCodeBinarizerBlockSize, height); y++ {
+				for x := bx * qrCodeBinarizerBlockSize; x < min((bx+1)*qrCodeBinarizerBlockSize, width); x++ {
+					bitmap.pixels[y*width+x] = luminance[y*width+x] <= threshold
+				}
+			}
+		}
+	}
+
+	return bitmap
+}
+
+func (b *qrCodeBitmap) dark(x int, y int) bool {
+	return b.pixels[y*b.width+x]
+}
+
+// findFinderPatterns scans every row for the 1:1:3:1:1 run ratio of a finder
+// pattern and confirms each hit vertically and horizontally through its centre.
+func (b *qrCodeBitmap) findFinderPatterns() []*qrCodeFinderPattern {
+	var patterns []*qrCodeFinderPattern
+	for y := 0; y < b.height; y++ {
+		var counts [5]int
+		state := 0
+		for x := 0; x <= b.width; x++ {
+			if x < b.width && b.dark(x, y) {
+				if state%2 == 1 {
+					state++
+				}
+				counts[state]++
+				continue
+			}
+
+			if state%2 == 1 {
+				counts[state]++
+				continue
+			}
+			if state < 4 {
+				state++
+				counts[state]++
+				continue
+			}
+
+			if isQRCodeFinderRatio(counts) {
+				b.addFinderPattern(&patterns, counts, x, y)
+			}
+			counts = [5]int{counts[2], counts[3], counts[4], 1, 0}
+			state = 3
+		}
+	}
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patterns[i].count > patterns[j].count
+	})
+	var confirmed []*qrCodeFinderPattern
+	for _, pattern := range patterns {
+		if pattern.count >= 2 && len(confirmed) < qrCodeMaximumFinderCandidates {
+			confirmed = append(confirmed, pattern)
+		}
+	}
+
+	return confirmed
+}
+
+func isQRCodeFinderRatio(counts [5]int) bool {
+	total := 0
+	for _, count := range counts {
+		if count == 0 {
+			return false
+		}
+		total += count
+	}
+	if total < 7 {
+		return false
+	}
+
+	moduleSize := float64(total) / 7
+	variance := moduleSize / 2
+	for i, count := range counts {
+		expected := moduleSize
+		if i == 2 {
+			expected *= 3
+		}
+		tolerance := variance
+		if i == 2 {
+			tolerance *= 3
+		}
+		if math.Abs(expected-float64(count)) >= tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *qrCodeBitmap) addFinderPattern(patterns *[]*qrCodeFinderPattern, counts [5]int, endX int, y int) {
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	centerX := float64(endX-counts[4]-counts[3]) - float64(counts[2])/2
+	centerY, verticalTotal, ok := b.crossCheck(int(centerX), y, 0, 1, counts[2], total)
+	if !ok {
+		return
+	}
+	centerX, horizontalTotal, ok := b.crossCheck(int(centerX), int(centerY), 1, 0, counts[2], total)
+	if !ok {
+		return
+	}
+
+	moduleSize := float64(verticalTotal+horizontalTotal) / 14
+	for _, pattern := range *patterns {
+		if math.Abs(centerX-pattern.x) <= moduleSize && math.Abs(centerY-pattern.y) <= moduleSize && math.Abs(moduleSize-pattern.moduleSize) <= math.Max(1, pattern.moduleSize) {
+			weight := float64(pattern.count)
+			pattern.x = (pattern.x*weight + centerX) / (weight + 1)
+			pattern.y = (pattern.y*weight + centerY) / (weight + 1)
+			pattern.moduleSize = (pattern.moduleSize*weight + moduleSize) / (weight + 1)
+			pattern.count++
+			return
+		}
+	}
+	*patterns = append(*patterns, &qrCodeFinderPattern{x: centerX, y: centerY, moduleSize: moduleSize, count: 1})
+}
+
+// crossCheck measures the finder pattern runs through x, y along the given
+// direction and returns the centre coordinate along that direction.
+func (b *qrCodeBitmap) crossCheck(x int, y int, dx int, dy int, maximumCount int, originalTotal int) (float64, int, bool) {
+	inside := func(i int) bool {
+		px, py := x+dx*i, y+dy*i
+		return px >= 0 && py >= 0 && px < b.width && py < b.height
+	}
+	darkAt := func(i int) bool {
+		return b.dark(x+dx*i, y+dy*i)
+	}
+	if !inside(0) || !darkAt(0) {
+		return 0, 0, false
+	}
+
+	var counts [5]int
+	i := 0
+	for ; inside(i) && darkAt(i); i-- {
+		counts[2]++
+	}
+	for ; inside(i) && !darkAt(i) && counts[1] <= maximumCount; i-- {
+		counts[1]++
+	}
+	for ; inside(i) && darkAt(i) && counts[0] <= maximumCount; i-- {
+		counts[0]++
+	}
+	if counts[1] > maximumCount || counts[0] > maximumCount {
+		return 0, 0, false
+	}
+
+	i = 1
+	for ; inside(i) && darkAt(i); i++ {
+		counts[2]++
+	}
+	for ; inside(i) && !darkAt(i) && counts[3] <= maximumCount; i++ {
+		counts[3]++
+	}
+	for ; inside(i) && darkAt(i) && counts[4] <= maximumCount; i++ {
+		counts[4]++
+	}
+	if counts[3] > maximumCount || counts[4] > maximumCount {
+		return 0, 0, false
+	}
+
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	if 5*abs(total-originalTotal) >= 2*originalTotal || !isQRCodeFinderRatio(counts) {
+		return 0, 0, false
+	}
+
+	end := float64(i - counts[4] - counts[3])
+	center := end - float64(counts[2])/2
+	if dx == 1 {
+		return float64(x) + center, total, true
+	}
+
+	return float64(y) + center, total, true
+}
+
+// selectQRCodeFinderTriples ranks every combination of three candidates by
+// how close they come to the corners of a square with similar module sizes.
+func selectQRCodeFinderTriples(patterns []*qrCodeFinderPattern) [][3]*qrCodeFinderPattern {
+	type scoredTriple struct {
+		triple [3]*qrCodeFinderPattern
+		score  float64
+	}
+
+	var triples []scoredTriple
+	for i := 0; i < len(patterns); i++ {
+		for j := i + 1; j < len(patterns); j++ {
+			for k := j + 1; k < len(patterns); k++ {
+				triple := [3]*qrCodeFinderPattern{patterns[i], patterns[j], patterns[k]}
+				sizes := []float64{triple[0].moduleSize, triple[1].moduleSize, triple[2].moduleSize}
+				sort.Float64s(sizes)
+				if sizes[2] > sizes[0]*1.5 {
+					continue
+				}
+
+				sides := []float64{
+					qrCodeDistance(triple[0], triple[1]),
+					qrCodeDistance(triple[1], triple[2]),
+					qrCodeDistance(triple[0], triple[2]),
+				}
+				sort.Float64s(sides)
+				if sides[0] < 7*sizes[1] {
+					continue
+				}
+				score := math.Abs(sides[2]*sides[2]-sides[0]*sides[0]-sides[1]*sides[1])/(sides[2]*sides[2]) + math.Abs(sides[1]-sides[0])/sides[1] + (sizes[2]-sizes[0])/sizes[2]
+				triples = append(triples, scoredTriple{triple: triple, score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(triples, func(i, j int) bool {
+		return triples[i].score < triples[j].score
+	})
+	selected := make([][3]*qrCodeFinderPattern, 0, qrCodeMaximumFinderTriples)
+	for i := 0; i < len(triples) && i < qrCodeMaximumFinderTriples; i++ {
+		selected = append(selected, triples[i].triple)
+	}
+
+	return selected
+}
+
+// orderQRCodeFinderPatterns puts the pattern opposite the longest side in the
+// top-left corner and orients the other two clockwise from it.
+func orderQRCodeFinderPatterns(triple [3]*qrCodeFinderPattern) (*qrCodeFinderPattern, *qrCodeFinderPattern, *qrCodeFinderPattern) {
+	a, b, c := triple[0], triple[1], triple[2]
+	ab, bc, ac := qrCodeDistance(a, b), qrCodeDistance(b, c), qrCodeDistance(a, c)
+
+	topLeft, topRight, bottomLeft := c, a, b
+	if bc >= ab && bc >= ac {
+		topLeft, topRight, bottomLeft = a, b, c
+	} else if ac >= ab && ac >= bc {
+		topLeft, topRight, bottomLeft = b, a, c
+	}
+
+	if (topRight.x-topLeft.x)*(bottomLeft.y-topLeft.y)-(topRight.y-topLeft.y)*(bottomLeft.x-topLeft.x) < 0 {
+		topRight, bottomLeft = bottomLeft, topRight
+	}
+
+	return topLeft, topRight, bottomLeft
+}
+
+// finderModuleSize measures the finder pattern of from along the line towards
+// to, where its seven modules keep their true width whatever the rotation.
+func (b *qrCodeBitmap) finderModuleSize(from *qrCodeFinderPattern, to *qrCodeFinderPattern) float64 {
+	distance := qrCodeDistance(from, to)
+	stepX, stepY := (to.x-from.x)/distance, (to.y-from.y)/distance
+
+	length := 0.0
+	for _, direction := range []float64{1, -1} {
+		// Runs from the centre outwards: dark half of the centre, light ring,
+		// dark ring, ending at the first light pixel past it.
+		state, steps := 0, 0
+		for state < 3 {
+			x := int(math.Floor(from.x + direction*stepX*float64(steps)))
+			y := int(math.Floor(from.y + direction*stepY*float64(steps)))
+			if x < 0 || y < 0 || x >= b.width || y >= b.height || float64(steps) > 8*from.moduleSize {
+				return from.moduleSize
+			}
+			if b.dark(x, y) == (state == 1) {
+				state++
+			}
+			steps++
+		}
+		length += float64(steps - 1)
+	}
+
+	return length / 7
+}
+
+// qrCodeDimensionCandidates rounds an estimated symbol size to the nearest
+// valid size, followed by its neighbouring valid sizes.
+func qrCodeDimensionCandidates(estimate float64) []int {
+	nearest := int(math.Round((estimate-17)/4))*4 + 17
+
+	var dimensions []int
+	for _, dimension := range []int{nearest, nearest + 4, nearest - 4} {
+		if dimension >= qrCodeDimension(qrCodeMinimumVersion) && dimension <= qrCodeDimension(qrCodeMaximumVersion) {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+
+	return dimensions
+}
+
+// qrCodeHomographies maps the three finder centres and a fourth point to the
+// symbol. The fourth point is the bottom-right alignment pattern when one can
+// be found, with the parallelogram corner as a fallback.
+func (b *qrCodeBitmap) qrCodeHomographies(topLeft *qrCodeFinderPattern, topRight *qrCodeFinderPattern, bottomLeft *qrCodeFinderPattern, moduleSize float64, dimension int) []qrCodeHomography {
+	far := float64(dimension) - 3.5
+	source := [4][2]float64{{3.5, 3.5}, {far, 3.5}, {3.5, far}, {far, far}}
+	destination := [4][2]float64{
+		{topLeft.x, topLeft.y},
+		{topRight.x, topRight.y},
+		{bottomLeft.x, bottomLeft.y},
+		{topRight.x - topLeft.x + bottomLeft.x, topRight.y - topLeft.y + bottomLeft.y},
+	}
+
+	var homographies []qrCodeHomography
+	version := (dimension - 17) / 4
+	if version >= 2 {
+		if x, y, ok := b.findAlignmentPattern(source, destination, moduleSize, dimension); ok {
+			alignmentSource := source
+			alignmentSource[3] = [2]float64{far - 3, far - 3}
+			alignmentDestination := destination
+			alignmentDestination[3] = [2]float64{x, y}
+			if homography, ok := newQRCodeHomography(alignmentSource, alignmentDestination); ok {
+				homographies = append(homographies, homography)
+			}
+		}
+	}
+	if homography, ok := newQRCodeHomography(source, destination); ok {
+		homographies = append(homographies, homography)
+	}
+
+	return homographies
+}
+
+// findAlignmentPattern searches around the position predicted by the finder
+// patterns for the offset where the 5x5 alignment pattern matches best.
+func (b *qrCodeBitmap) findAlignmentPattern(source [4][2]float64, destination [4][2]float64, moduleSize float64, dimension int) (float64, float64, bool) {
+	affine, ok := newQRCodeHomography(source, destination)
+	if !ok {
+		return 0, 0, false
+	}
+
+	center := float64(dimension) - 6.5
+	estimateX, estimateY := affine.transform(center, center)
+	radius := int(math.Ceil(moduleSize * 4))
+	step := max(1, int(moduleSize/3))
+	bestScore, bestX, bestY := 0, 0.0, 0.0
+	for dy := -radius; dy <= radius; dy += step {
+		for dx := -radius; dx <= radius; dx += step {
+			score := 0
+			for my := -2; my <= 2; my++ {
+				for mx := -2; mx <= 2; mx++ {
+					px, py := affine.transform(center+float64(mx), center+float64(my))
+					x, y := int(px)+dx, int(py)+dy
+					if x < 0 || y < 0 || x >= b.width || y >= b.height {
+						continue
+					}
+					ring := max(abs(mx), abs(my))
+					if b.dark(x, y) == (ring != 1) {
+						score++
+					}
+				}
+			}
+			if score > bestScore {
+				bestScore, bestX, bestY = score, estimateX+float64(dx), estimateY+float64(dy)
+			}
+		}
+	}
+
+	return bestX, bestY, bestScore >= 23
+}
+
+func (b *qrCodeBitmap) sample(homography qrCodeHomography, dimension int) [][]bool {
+	modules := make([][]bool, dimension)
+	for y := range modules {
+		modules[y] = make([]bool, dimension)
+		for x := range modules[y] {
+			px, py := homography.transform(float64(x)+0.5, float64(y)+0.5)
+			ix, iy := int(math.Floor(px)), int(math.Floor(py))
+			if ix < -1 || iy < -1 || ix > b.width || iy > b.height {
+				return nil
+			}
+			modules[y][x] = b.dark(min(max(ix, 0), b.width-1), min(max(iy, 0), b.height-1))
+		}
+	}
+
+	return modules
+}
+
+// newQRCodeHomography solves the eight unknowns of the projective transform
+// taking four source points onto four destination points.
+func newQRCodeHomography(source [4][2]float64, destination [4][2]float64) (qrCodeHomography, bool) {
+	var matrix [8][9]float64
+	for i := 0; i < 4; i++ {
+		u, v := source[i][0], source[i][1]
+		x, y := destination[i][0], destination[i][1]
+		matrix[2*i] = [9]float64{u, v, 1, 0, 0, 0, -u * x, -v * x, x}
+		matrix[2*i+1] = [9]float64{0, 0, 0, u, v, 1, -u * y, -v * y, y}
+	}
+
+	for column := 0; column < 8; column++ {
+		pivot := column
+		for row := column + 1; row < 8; row++ {
+			if math.Abs(matrix[row][column]) > math.Abs(matrix[pivot][column]) {
+				pivot = row
+			}
+		}
+		if math.Abs(matrix[pivot][column]) < 1e-9 {
+			return qrCodeHomography{}, false
+		}
+		matrix[column], matrix[pivot] = matrix[pivot], matrix[column]
+
+		for row := 0; row < 8; row++ {
+			if row == column {
+				continue
+			}
+			factor := matrix[row][column] / matrix[column][column]
+			for k := column; k < 9; k++ {
+				matrix[row][k] -= factor * matrix[column][k]
+			}
+		}
+	}
+
+	var homography qrCodeHomography
+	for i := range homography {
+		homography[i] = matrix[i][8] / matrix[i][i]
+	}
+
+	return homography, true
+}
+
+func (h qrCodeHomography) transform(u float64, v float64) (float64, float64) {
+	denominator := h[6]*u + h[7]*v + 1
+
+	return (h[0]*u + h[1]*v + h[2]) / denominator, (h[3]*u + h[4]*v + h[5]) / denominator
+}
+
+func qrCodeDistance(a *qrCodeFinderPattern, b *qrCodeFinderPattern) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestNewQRCodeHomography(t *testing.T) {
+	source := [4][2]float64{{3.5, 3.5}, {21.5, 3.5}, {3.5, 21.5}, {18.5, 18.5}}
+	destination := [4][2]float64{{100, 120}, {310, 90}, {80, 330}, {260, 270}}
+
+	homography, ok := newQRCodeHomography(source, destination)
+	if !ok {
+		t.Fatalf(expectedButGotMessage, "newQRCodeHomography()", true, ok)
+	}
+	for i := range source {
+		x, y := homography.transform(source[i][0], source[i][1])
+		if math.Abs(x-destination[i][0]) > 1e-6 || math.Abs(y-destination[i][1]) > 1e-6 {
+			t.Errorf(expectedButGotMessage, "transform()", destination[i], [2]float64{x, y})
+		}
+	}
+
+	_, ok = newQRCodeHomography(source, [4][2]float64{})
+	if ok {
+		t.Errorf(expectedButGotMessage, "newQRCodeHomography() with collapsed destination", false, ok)
+	}
+}
+
+func TestBinarizeQRCodeImage(t *testing.T) {
+	// Dark and light stripes under lighting that fades from bright to dim,
+	// where the dim light stripes are darker than the bright dark ones.
+	img := image.NewGray(image.Rect(0, 0, 160, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 160; x++ {
+			light := 255 - x
+			if (x/4)%2 == 0 {
+				light = light / 3
+			}
+			img.SetGray(x, y, color.Gray{Y: uint8(light)})
+		}
+	}
+
+	bitmap := binarizeQRCodeImage(img)
+	for x := 0; x < 160; x++ {
+		if got, want := bitmap.dark(x, 32), (x/4)%2 == 0; got != want {
+			t.Errorf(expectedButGotMessage, "dark()", want, got)
+		}
+	}
+}
+
+func TestOrderQRCodeFinderPatterns(t *testing.T) {
+	topLeft := &qrCodeFinderPattern{x: 200, y: 100}
+	topRight := &qrCodeFinderPattern{x: 300, y: 200}
+	bottomLeft := &qrCodeFinderPattern{x: 100, y: 200}
+
+	for _, triple := range [][3]*qrCodeFinderPattern{
+		{topLeft, topRight, bottomLeft},
+		{bottomLeft, topLeft, topRight},
+		{topRight, bottomLeft, topLeft},
+	} {
+		gotTopLeft, gotTopRight, gotBottomLeft := orderQRCodeFinderPatterns(triple)
+		if gotTopLeft != topLeft || gotTopRight != topRight || gotBottomLeft != bottomLeft {
+			t.Errorf(expectedButGotMessage, "orderQRCodeFinderPatterns()", [3]*qrCodeFinderPattern{topLeft, topRight, bottomLeft}, [3]*qrCodeFinderPattern{gotTopLeft, gotTopRight, gotBottomLeft})
+		}
+	}
+}
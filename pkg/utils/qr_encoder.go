@@ -0,0 +1,450 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	qrCodeModeNumeric = iota
+	qrCodeModeAlphanumeric
+	qrCodeModeByte
+)
+
+// Mode indicators and character count lengths for versions 1-9, 10-26 and
+// 27-40, indexed by mode.
+var (
+	qrCodeModeIndicators = [3]int{0x1, 0x2, 0x4}
+	qrCodeCountBits      = [3][3]int{
+		{10, 12, 14},
+		{9, 11, 13},
+		{8, 16, 16},
+	}
+)
+
+type qrCodeSegment struct {
+	mode int
+	data string
+}
+
+type qrCodeBitWriter struct {
+	data   []byte
+	length int
+}
+
+// encodeQRCodeSymbol encodes text into a symbol without quiet zone, where true
+// is a dark module. The text is split into the cheapest mix of numeric,
+// alphanumeric and byte segments, the smallest version that fits the error
+// correction level is used and the mask with the lowest penalty is applied.
+func encodeQRCodeSymbol(text string, errorCorrectionLevel string) ([][]bool, error) {
+	if _, exists := qrCodeFormatLevelBits[errorCorrectionLevel]; !exists {
+		return nil, fmt.Errorf("unsupported QR code error correction level %s", errorCorrectionLevel)
+	}
+
+	version, segments, err := selectQRCodeVersion(text, errorCorrectionLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	data := encodeQRCodeSegments(segments, version, errorCorrectionLevel)
+	codewords := addQRCodeErrorCorrection(data, version, errorCorrectionLevel)
+
+	return drawQRCodeSymbol(codewords, version, errorCorrectionLevel), nil
+}
+
+// selectQRCodeVersion returns the smallest version able to hold the text,
+// together with the segments that are optimal for its character count group.
+func selectQRCodeVersion(text string, errorCorrectionLevel string) (int, []qrCodeSegment, error) {
+	var segments []qrCodeSegment
+	for version := qrCodeMinimumVersion; version <= qrCodeMaximumVersion; version++ {
+		if version == qrCodeMinimumVersion || version == 10 || version == 27 {
+			segments = splitQRCodeSegments(text, version)
+		}
+		if qrCodeSegmentsLength(segments, version) <= qrCodeDataCodewords(version, errorCorrectionLevel)*8 {
+			return version, segments, nil
+		}
+	}
+
+	return 0, nil, fmt.Errorf("QR code content is too long for error correction level %s", errorCorrectionLevel)
+}
+
+// splitQRCodeSegments finds the cheapest mode for every byte with dynamic
+// programming over the bit cost, counted in sixths of a bit so that
+// alphanumeric pairs and numeric triples stay integral.
+func splitQRCodeSegments(text string, version int) []qrCodeSegment {
+	if text == "" {
+		return nil
+	}
+
+	group := qrCodeCountGroup(version)
+	var headerCosts [3]int
+	for mode := range headerCosts {
+		headerCosts[mode] = (4 + qrCodeCountBits[mode][group]) * 6
+	}
+
+	choices := make([][3]int, len(text))
+	costs := headerCosts
+	for i := 0; i < len(text); i++ {
+		var current [3]int
+		var encodable [3]bool
+		for mode := range current {
+			current[mode] = math.MaxInt
+		}
+
+		current[qrCodeModeByte] = costs[qrCodeModeByte] + 48
+		choices[i][qrCodeModeByte], encodable[qrCodeModeByte] = qrCodeModeByte, true
+		if strings.IndexByte(qrCodeAlphanumericCharset, text[i]) >= 0 {
+			current[qrCodeModeAlphanumeric] = costs[qrCodeModeAlphanumeric] + 33
+			choices[i][qrCodeModeAlphanumeric], encodable[qrCodeModeAlphanumeric] = qrCodeModeAlphanumeric, true
+		}
+		if text[i] >= '0' && text[i] <= '9' {
+			current[qrCodeModeNumeric] = costs[qrCodeModeNumeric] + 20
+			choices[i][qrCodeModeNumeric], encodable[qrCodeModeNumeric] = qrCodeModeNumeric, true
+		}
+
+		// Switching after this byte pays the header of the next segment up
+		// front, on top of the previous segment rounded up to whole bits.
+		next := current
+		for to := range next {
+			for from := range current {
+				if !encodable[from] {
+					continue
+				}
+				cost := (current[from]+5)/6*6 + headerCosts[to]
+				if cost < next[to] {
+					next[to] = cost
+					choices[i][to] = from
+				}
+			}
+		}
+		costs = next
+	}
+
+	mode := qrCodeModeByte
+	for candidate := range costs {
+		if costs[candidate] < costs[mode] {
+			mode = candidate
+		}
+	}
+
+	modes := make([]int, len(text))
+	for i := len(text) - 1; i >= 0; i-- {
+		mode = choices[i][mode]
+		modes[i] = mode
+	}
+
+	var segments []qrCodeSegment
+	start := 0
+	for i := 1; i <= len(text); i++ {
+		if i == len(text) || modes[i] != modes[start] {
+			segments = append(segments, qrCodeSegment{mode: modes[start], data: text[start:i]})
+			start = i
+		}
+	}
+
+	return segments
+}
+
+func qrCodeSegmentsLength(segments []qrCodeSegment, version int) int {
+	group := qrCodeCountGroup(version)
+	length := 0
+	for _, segment := range segments {
+		count := len(segment.data)
+		if count >= 1<<qrCodeCountBits[segment.mode][group] {
+			return math.MaxInt
+		}
+		length += 4 + qrCodeCountBits[segment.mode][group]
+		switch segment.mode {
+		case qrCodeModeNumeric:
+			length += count/3*10 + []int{0, 4, 7}[count%3]
+		case qrCodeModeAlphanumeric:
+			length += count/2*11 + count%2*6
+		default:
+			length += count * 8
+		}
+	}
+
+	return length
+}
+
+// encodeQRCodeSegments writes the segments followed by the terminator and the
+// alternating pad codewords up to the data capacity of the version.
+func encodeQRCodeSegments(segments []qrCodeSegment, version int, errorCorrectionLevel string) []byte {
+	group := qrCodeCountGroup(version)
+	writer := &qrCodeBitWriter{}
+	for _, segment := range segments {
+		writer.write(qrCodeModeIndicators[segment.mode], 4)
+		writer.write(len(segment.data), qrCodeCountBits[segment.mode][group])
+		switch segment.mode {
+		case qrCodeModeNumeric:
+			for i := 0; i < len(segment.data); i += 3 {
+				digits := segment.data[i:min(i+3, len(segment.data))]
+				value := 0
+				for _, digit := range digits {
+					value = value*10 + int(digit-'0')
+				}
+				writer.write(value, []int{0, 4, 7, 10}[len(digits)])
+			}
+		case qrCodeModeAlphanumeric:
+			for i := 0; i < len(segment.data); i += 2 {
+				value := strings.IndexByte(qrCodeAlphanumericCharset, segment.data[i])
+				if i+1 == len(segment.data) {
+					writer.write(value, 6)
+					break
+				}
+				writer.write(value*45+strings.IndexByte(qrCodeAlphanumericCharset, segment.data[i+1]), 11)
+			}
+		default:
+			for i := 0; i < len(segment.data); i++ {
+				writer.write(int(segment.data[i]), 8)
+			}
+		}
+	}
+
+	capacity := qrCodeDataCodewords(version, errorCorrectionLevel) * 8
+	writer.write(0, min(4, capacity-writer.length))
+	writer.write(0, (8-writer.length%8)%8)
+	for pad := 0xec; writer.length < capacity; pad ^= 0xec ^ 0x11 {
+		writer.write(pad, 8)
+	}
+
+	return writer.data
+}
+
+// addQRCodeErrorCorrection splits the data into blocks, appends the Reed-Solomon
+// codewords of each block and interleaves them, the inverse of
+// correctQRCodeCodewords.
+func addQRCodeErrorCorrection(data []byte, version int, errorCorrectionLevel string) []byte {
+	blockCount := qrCodeErrorCorrectionBlocks[errorCorrectionLevel][version]
+	eccLength := qrCodeECCCodewordsPerBlock[errorCorrectionLevel][version]
+	rawCodewords := qrCodeRawDataModules(version) / 8
+	shortBlocks := blockCount - rawCodewords%blockCount
+	shortLength := rawCodewords / blockCount
+	generator := qrCodeReedSolomonGenerator(eccLength)
+
+	blocks := make([][]byte, blockCount)
+	eccBlocks := make([][]byte, blockCount)
+	for i, offset := 0, 0; i < blockCount; i++ {
+		length := shortLength - eccLength
+		if i >= shortBlocks {
+			length++
+		}
+		blocks[i] = data[offset : offset+length]
+		eccBlocks[i] = qrCodeReedSolomonRemainder(blocks[i], generator)
+		offset += length
+	}
+
+	codewords := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortLength-eccLength; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				codewords = append(codewords, block[i])
+			}
+		}
+	}
+	for i := 0; i < eccLength; i++ {
+		for _, block := range eccBlocks {
+			codewords = append(codewords, block[i])
+		}
+	}
+
+	return codewords
+}
+
+// qrCodeReedSolomonGenerator returns the generator polynomial with roots
+// α^0 to α^(degree-1), highest degree first.
+func qrCodeReedSolomonGenerator(degree int) []byte {
+	generator := []byte{1}
+	for i := 0; i < degree; i++ {
+		next := make([]byte, len(generator)+1)
+		for j, coefficient := range generator {
+			next[j] ^= coefficient
+			next[j+1] ^= qrCodeGaloisMultiply(coefficient, qrCodeGaloisExp[i])
+		}
+		generator = next
+	}
+
+	return generator
+}
+
+func qrCodeReedSolomonRemainder(data []byte, generator []byte) []byte {
+	remainder := make([]byte, len(generator)-1)
+	for _, value := range data {
+		factor := value ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[len(remainder)-1] = 0
+		for i := range remainder {
+			remainder[i] ^= qrCodeGaloisMultiply(generator[i+1], factor)
+		}
+	}
+
+	return remainder
+}
+
+// drawQRCodeSymbol places the function patterns and the codewords, then keeps
+// whichever of the eight masks scores the lowest penalty.
+func drawQRCodeSymbol(codewords []byte, version int, errorCorrectionLevel string) [][]bool {
+	dimension := qrCodeDimension(version)
+	base := make([][]bool, dimension)
+	for y := range base {
+		base[y] = make([]bool, dimension)
+	}
+
+	for i := 0; i < dimension; i++ {
+		base[6][i] = i%2 == 0
+		base[i][6] = i%2 == 0
+	}
+	for _, corner := range [][2]int{{3, 3}, {dimension - 4, 3}, {3, dimension - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || y < 0 || x >= dimension || y >= dimension {
+					continue
+				}
+				distance := max(abs(dx), abs(dy))
+				base[y][x] = distance != 2 && distance != 4
+			}
+		}
+	}
+
+	positions := qrCodeAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					base[y+dy][x+dx] = max(abs(dx), abs(dy)) != 1
+				}
+			}
+		}
+	}
+
+	if version >= 7 {
+		remainder := version
+		for i := 0; i < 12; i++ {
+			remainder = remainder<<1 ^ (remainder>>11)*0x1f25
+		}
+		versionBits := version<<12 | remainder
+		for i := 0; i < 18; i++ {
+			dark := versionBits>>i&1 == 1
+			a, b := dimension-11+i%3, i/3
+			base[b][a] = dark
+			base[a][b] = dark
+		}
+	}
+	base[dimension-8][8] = true
+
+	var best [][]bool
+	bestPenalty := math.MaxInt
+	for mask := 0; mask < 8; mask++ {
+		modules := make([][]bool, dimension)
+		for y := range modules {
+			modules[y] = append([]bool(nil), base[y]...)
+		}
+
+		for i, position := range qrCodeDataPositions(version) {
+			x, y := position[0], position[1]
+			bit := i < len(codewords)*8 && codewords[i/8]&(1<<(7-i%8)) != 0
+			modules[y][x] = bit != qrCodeMask(mask, x, y)
+		}
+
+		formatBits := qrCodeFormatInformation(errorCorrectionLevel, mask)
+		first, second := qrCodeFormatPositions(dimension)
+		for i := 0; i < 15; i++ {
+			dark := formatBits>>i&1 == 1
+			modules[first[i][1]][first[i][0]] = dark
+			modules[second[i][1]][second[i][0]] = dark
+		}
+
+		if penalty := qrCodePenalty(modules); penalty < bestPenalty {
+			best, bestPenalty = modules, penalty
+		}
+	}
+
+	return best
+}
+
+// qrCodePenalty scores a masked symbol with the four rules of the
+// specification: runs of five or more, 2x2 blocks, finder-like patterns and
+// the deviation of the dark ratio from one half.
+func qrCodePenalty(modules [][]bool) int {
+	dimension := len(modules)
+	get := func(x int, y int, transposed bool) bool {
+		if transposed {
+			return modules[x][y]
+		}
+		return modules[y][x]
+	}
+
+	penalty := 0
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < dimension; y++ {
+			run := 0
+			for x := 0; x < dimension; x++ {
+				if x > 0 && get(x, y, transposed) == get(x-1, y, transposed) {
+					run++
+				} else {
+					run = 1
+				}
+				if run == 5 {
+					penalty += 3
+				} else if run > 5 {
+					penalty++
+				}
+			}
+
+			for x := 0; x+11 <= dimension; x++ {
+				var pattern [11]bool
+				for i := range pattern {
+					pattern[i] = get(x+i, y, transposed)
+				}
+				if pattern == [11]bool{true, false, true, true, true, false, true, false, false, false, false} ||
+					pattern == [11]bool{false, false, false, false, true, false, true, true, true, false, true} {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < dimension; y++ {
+		for x := 0; x < dimension; x++ {
+			if modules[y][x] {
+				dark++
+			}
+			if x+1 < dimension && y+1 < dimension && modules[y][x] == modules[y][x+1] && modules[y][x] == modules[y+1][x] && modules[y][x] == modules[y+1][x+1] {
+				penalty += 3
+			}
+		}
+	}
+	total := dimension * dimension
+	penalty += abs(dark*20-total*10) / total * 10
+
+	return penalty
+}
+
+func qrCodeCountGroup(version int) int {
+	if version >= 27 {
+		return 2
+	} else if version >= 10 {
+		return 1
+	}
+
+	return 0
+}
+
+func (w *qrCodeBitWriter) write(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		if w.length%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if value>>i&1 == 1 {
+			w.data[len(w.data)-1] |= 1 << (7 - w.length%8)
+		}
+		w.length++
+	}
+}
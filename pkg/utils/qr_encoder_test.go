@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boombuler/barcode/qr"
+)
+
+func TestEncodeQRCodeSymbol(t *testing.T) {
+	boombulerLevels := map[string]qr.ErrorCorrectionLevel{
+		"L": qr.L,
+		"M": qr.M,
+		"Q": qr.Q,
+		"H": qr.H,
+	}
+
+	type args struct {
+		text                 string
+		errorCorrectionLevel string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantDimension int
+		wantIdentical bool
+		wantError     error
+	}{
+		{
+			name: "Success: Numeric Same As Boombuler",
+			args: args{
+				text:                 "0123456789",
+				errorCorrectionLevel: "M",
+			},
+			wantDimension: 21,
+			wantIdentical: true,
+			wantError:     nil,
+		},
+		{
+			name: "Success: Alphanumeric Same As Boombuler",
+			args: args{
+				text:                 "HELLO WORLD",
+				errorCorrectionLevel: "H",
+			},
+			wantDimension: 25,
+			wantIdentical: true,
+			wantError:     nil,
+		},
+		{
+			name: "Success: Empty",
+			args: args{
+				text:                 "",
+				errorCorrectionLevel: "L",
+			},
+			wantDimension: 21,
+			wantError:     nil,
+		},
+		{
+			name: "Success: QRIS Level L",
+			args: args{
+				text:                 testStickerContent.QRString,
+				errorCorrectionLevel: "L",
+			},
+			wantDimension: 45,
+			wantError:     nil,
+		},
+		{
+			name: "Success: QRIS Level H",
+			args: args{
+				text:                 testStickerContent.QRString,
+				errorCorrectionLevel: "H",
+			},
+			wantDimension: 65,
+			wantError:     nil,
+		},
+		{
+			name: "Success: Mixed Modes",
+			args: args{
+				text:                 "ABCDEFGH0123456789012345abc",
+				errorCorrectionLevel: "Q",
+			},
+			wantDimension: 25,
+			wantError:     nil,
+		},
+		{
+			name: "Success: Version With Version Information",
+			args: args{
+				text:                 strings.Repeat(testStickerContent.QRString, 4),
+				errorCorrectionLevel: "M",
+			},
+			wantDimension: 0,
+			wantError:     nil,
+		},
+		{
+			name: "Error: Unsupported Error Correction Level",
+			args: args{
+				text:                 testStickerContent.QRString,
+				errorCorrectionLevel: "X",
+			},
+			wantError: fmt.Errorf("unsupported QR code error correction level X"),
+		},
+		{
+			name: "Error: Content Too Long",
+			args: args{
+				text:                 strings.Repeat("a", 2954),
+				errorCorrectionLevel: "L",
+			},
+			wantError: fmt.Errorf("QR code content is too long for error correction level L"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := encodeQRCodeSymbol(test.args.text, test.args.errorCorrectionLevel)
+			if (err != nil || test.wantError != nil) && (err == nil || test.wantError == nil || err.Error() != test.wantError.Error()) {
+				t.Fatalf(expectedErrorButGotMessage, "encodeQRCodeSymbol()", test.wantError, err)
+			}
+			if test.wantError != nil {
+				return
+			}
+			if test.wantDimension != 0 && len(got) != test.wantDimension {
+				t.Errorf(expectedButGotMessage, "encodeQRCodeSymbol() dimension", test.wantDimension, len(got))
+			}
+
+			decoded, err := decodeQRCodeModules(got)
+			if err != nil {
+				t.Fatalf(expectedErrorButGotMessage, "decodeQRCodeModules()", nil, err)
+			}
+			if decoded != test.args.text {
+				t.Errorf(expectedButGotMessage, "decodeQRCodeModules()", test.args.text, decoded)
+			}
+
+			boombuler, err := qr.Encode(test.args.text, boombulerLevels[test.args.errorCorrectionLevel], qr.Auto)
+			if err != nil {
+				t.Fatalf(expectedErrorButGotMessage, "qr.Encode()", nil, err)
+			}
+			want := make([][]bool, boombuler.Bounds().Dy())
+			for y := range want {
+				want[y] = make([]bool, boombuler.Bounds().Dx())
+				for x := range want[y] {
+					r, _, _, _ := boombuler.At(x, y).RGBA()
+					want[y][x] = r == 0
+				}
+			}
+			if len(got) > len(want) {
+				t.Errorf(expectedButGotMessage, "encodeQRCodeSymbol() dimension at most boombuler", len(want), len(got))
+			}
+			if test.wantIdentical && !reflect.DeepEqual(got, want) {
+				t.Errorf(expectedButGotMessage, "encodeQRCodeSymbol() same as boombuler", true, false)
+			}
+		})
+	}
+}
+
+func TestSplitQRCodeSegments(t *testing.T) {
+	type args struct {
+		text    string
+		version int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []qrCodeSegment
+	}{
+		{
+			name: "Success: Empty",
+			args: args{
+				text:    "",
+				version: 1,
+			},
+			want: nil,
+		},
+		{
+			name: "Success: Short Digit Run Stays Alphanumeric",
+			args: args{
+				text:    "A12B",
+				version: 1,
+			},
+			want: []qrCodeSegment{
+				{mode: qrCodeModeAlphanumeric, data: "A12B"},
+			},
+		},
+		{
+			name: "Success: Long Digit Run Gets Numeric",
+			args: args{
+				text:    "ABCDEFGH0123456789012345abc",
+				version: 1,
+			},
+			want: []qrCodeSegment{
+				{mode: qrCodeModeAlphanumeric, data: "ABCDEFGH"},
+				{mode: qrCodeModeNumeric, data: "0123456789012345"},
+				{mode: qrCodeModeByte, data: "abc"},
+			},
+		},
+		{
+			name: "Success: Short Alphanumeric Run Stays Byte",
+			args: args{
+				text:    "https://example.com",
+				version: 1,
+			},
+			want: []qrCodeSegment{
+				{mode: qrCodeModeByte, data: "https://example.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := splitQRCodeSegments(test.args.text, test.args.version)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "splitQRCodeSegments()", test.want, got)
+			}
+			if length := qrCodeSegmentsLength(got, test.args.version); length > 4+8+len(test.args.text)*8 {
+				t.Errorf(expectedButGotMessage, "qrCodeSegmentsLength() at most a single byte segment", 4+8+len(test.args.text)*8, length)
+			}
+		})
+	}
+}
+
+func TestQRCodeReedSolomonRemainder(t *testing.T) {
+	// Version 1-M data codewords of "01234567" from ISO/IEC 18004 Annex I.
+	data := []byte{0x10, 0x20, 0x0c, 0x56, 0x61, 0x80, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11}
+	want := []byte{0xa5, 0x24, 0xd4, 0xc1, 0xed, 0x36, 0xc7, 0x87, 0x2c, 0x55}
+
+	got := qrCodeReedSolomonRemainder(data, qrCodeReedSolomonGenerator(len(want)))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(expectedButGotMessage, "qrCodeReedSolomonRemainder()", want, got)
+	}
+
+	codeword := append(append([]byte(nil), data...), got...)
+	if err := correctReedSolomon(codeword, len(want)); err != nil {
+		t.Errorf(expectedErrorButGotMessage, "correctReedSolomon()", nil, err)
+	}
+}
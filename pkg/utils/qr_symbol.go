@@ -0,0 +1,248 @@
+package utils
+
+import "math/bits"
+
+// Symbol structure of ISO/IEC 18004 QR codes, shared by the decoder. The
+// block tables are indexed by version, index 0 is unused.
+
+const (
+	qrCodeMinimumVersion = 1
+	qrCodeMaximumVersion = 40
+)
+
+var qrCodeFormatLevelBits = map[string]int{
+	"L": 1,
+	"M": 0,
+	"Q": 3,
+	"H": 2,
+}
+
+var qrCodeECCCodewordsPerBlock = map[string][41]int{
+	"L": {0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	"M": {0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	"Q": {0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	"H": {0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var qrCodeErrorCorrectionBlocks = map[string][41]int{
+	"L": {0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	"M": {0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	"Q": {0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	"H": {0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+var (
+	qrCodeGaloisExp [512]byte
+	qrCodeGaloisLog [256]int
+)
+
+func init() {
+	value := 1
+	for i := 0; i < 255; i++ {
+		qrCodeGaloisExp[i] = byte(value)
+		qrCodeGaloisLog[value] = i
+		value <<= 1
+		if value&0x100 != 0 {
+			value ^= 0x11d
+		}
+	}
+	for i := 255; i < len(qrCodeGaloisExp); i++ {
+		qrCodeGaloisExp[i] = qrCodeGaloisExp[i-255]
+	}
+}
+
+func qrCodeDimension(version int) int {
+	return version*4 + 17
+}
+
+// qrCodeRawDataModules counts the modules left for codewords and remainder
+// bits once every function pattern has been placed.
+func qrCodeRawDataModules(version int) int {
+	modules := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		modules -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			modules -= 36
+		}
+	}
+
+	return modules
+}
+
+func qrCodeDataCodewords(version int, errorCorrectionLevel string) int {
+	return qrCodeRawDataModules(version)/8 - qrCodeECCCodewordsPerBlock[errorCorrectionLevel][version]*qrCodeErrorCorrectionBlocks[errorCorrectionLevel][version]
+}
+
+func qrCodeAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, qrCodeDimension(version)-7; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+
+	return positions
+}
+
+// qrCodeFunctionModules marks finder, timing and alignment patterns together
+// with the format and version areas, i.e. every module that holds no data.
+func qrCodeFunctionModules(version int) [][]bool {
+	dimension := qrCodeDimension(version)
+	modules := make([][]bool, dimension)
+	for y := range modules {
+		modules[y] = make([]bool, dimension)
+	}
+	mark := func(x int, y int, width int, height int) {
+		for dy := 0; dy < height; dy++ {
+			for dx := 0; dx < width; dx++ {
+				modules[y+dy][x+dx] = true
+			}
+		}
+	}
+
+	mark(0, 0, 9, 9)
+	mark(dimension-8, 0, 8, 9)
+	mark(0, dimension-8, 9, 8)
+	mark(6, 0, 1, dimension)
+	mark(0, 6, dimension, 1)
+
+	positions := qrCodeAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			mark(x-2, y-2, 5, 5)
+		}
+	}
+
+	if version >= 7 {
+		mark(dimension-11, 0, 3, 6)
+		mark(0, dimension-11, 6, 3)
+	}
+
+	return modules
+}
+
+// qrCodeFormatInformation returns the 15 BCH protected format bits, already
+// XORed with the fixed pattern from the specification.
+func qrCodeFormatInformation(errorCorrectionLevel string, mask int) int {
+	data := qrCodeFormatLevelBits[errorCorrectionLevel]<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// qrCodeFormatPositions lists both copies of the format information as x, y
+// pairs, least significant bit first.
+func qrCodeFormatPositions(dimension int) ([15][2]int, [15][2]int) {
+	var first, second [15][2]int
+	for i := 0; i < 15; i++ {
+		switch {
+		case i < 6:
+			first[i] = [2]int{8, i}
+		case i < 8:
+			first[i] = [2]int{8, i + 1}
+		case i == 8:
+			first[i] = [2]int{7, 8}
+		default:
+			first[i] = [2]int{14 - i, 8}
+		}
+
+		if i < 8 {
+			second[i] = [2]int{dimension - 1 - i, 8}
+		} else {
+			second[i] = [2]int{8, dimension - 15 + i}
+		}
+	}
+
+	return first, second
+}
+
+func qrCodeMask(mask int, x int, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// qrCodeDataPositions walks the data area in the two-column zigzag order used
+// to place codewords, starting at the bottom-right corner.
+func qrCodeDataPositions(version int) [][2]int {
+	dimension := qrCodeDimension(version)
+	function := qrCodeFunctionModules(version)
+	positions := make([][2]int, 0, qrCodeRawDataModules(version))
+	for right := dimension - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < dimension; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = dimension - 1 - vertical
+				}
+				if !function[y][x] {
+					positions = append(positions, [2]int{x, y})
+				}
+			}
+		}
+	}
+
+	return positions
+}
+
+func qrCodeGaloisMultiply(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return qrCodeGaloisExp[qrCodeGaloisLog[a]+qrCodeGaloisLog[b]]
+}
+
+func qrCodeGaloisDivide(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return qrCodeGaloisExp[qrCodeGaloisLog[a]+255-qrCodeGaloisLog[b]]
+}
+
+// qrCodeGaloisEvaluate evaluates a polynomial stored lowest degree first.
+func qrCodeGaloisEvaluate(polynomial []byte, x byte) byte {
+	var result byte
+	for i := len(polynomial) - 1; i >= 0; i-- {
+		result = qrCodeGaloisMultiply(result, x) ^ polynomial[i]
+	}
+
+	return result
+}
+
+func qrCodeHammingDistance(a int, b int) int {
+	return bits.OnesCount(uint(a ^ b))
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiterSweepInterval is how often buckets that refilled completely are
+// dropped, so one-off clients do not pile up.
+const rateLimiterSweepInterval = time.Minute
+
+type RateLimiter struct {
+	rate      float64
+	burst     float64
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type RateLimiterInterface interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+// NewRateLimiter keeps a token bucket per key that refills rate tokens a
+// second up to burst.
+func NewRateLimiter(rate float64, burst int) RateLimiterInterface {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   math.Max(float64(burst), 1),
+		buckets: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket, or reports how long until one is
+// available.
+func (u *RateLimiter) Allow(key string) (bool, time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.now()
+	u.sweep(now)
+
+	bucket, exists := u.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: u.burst, updated: now}
+		u.buckets[key] = bucket
+	}
+	u.refill(bucket, now)
+
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / u.rate * float64(time.Second))
+	}
+	bucket.tokens--
+
+	return true, 0
+}
+
+func (u *RateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	bucket.tokens = math.Min(u.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*u.rate)
+	bucket.updated = now
+}
+
+func (u *RateLimiter) sweep(now time.Time) {
+	if now.Sub(u.lastSweep) < rateLimiterSweepInterval {
+		return
+	}
+	u.lastSweep = now
+
+	for key, bucket := range u.buckets {
+		if u.refill(bucket, now); bucket.tokens >= u.burst {
+			delete(u.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, 3).(*RateLimiter)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		advance   time.Duration
+		want      bool
+		wantRetry time.Duration
+	}{
+		{name: "Success: Burst 1", key: "kiosk", want: true},
+		{name: "Success: Burst 2", key: "kiosk", want: true},
+		{name: "Success: Burst 3", key: "kiosk", want: true},
+		{name: "Error: Bucket Empty", key: "kiosk", want: false, wantRetry: 500 * time.Millisecond},
+		{name: "Success: Other Key", key: "cashier", want: true},
+		{name: "Error: Partially Refilled", key: "kiosk", advance: 250 * time.Millisecond, want: false, wantRetry: 250 * time.Millisecond},
+		{name: "Success: Refilled", key: "kiosk", advance: 250 * time.Millisecond, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now = now.Add(test.advance)
+
+			got, gotRetry := limiter.Allow(test.key)
+			if got != test.want {
+				t.Errorf(expectedButGotMessage, "Allow()", test.want, got)
+			}
+			if gotRetry != test.wantRetry {
+				t.Errorf(expectedButGotMessage, "Allow() retry", test.wantRetry, gotRetry)
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(1, 1).(*RateLimiter)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	limiter.Allow("kiosk")
+	now = now.Add(rateLimiterSweepInterval)
+	limiter.Allow("cashier")
+
+	if _, exists := limiter.buckets["kiosk"]; exists || len(limiter.buckets) != 1 {
+		t.Errorf(expectedButGotMessage, "buckets", "cashier only", limiter.buckets)
+	}
+}
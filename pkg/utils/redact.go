@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const RedactedValue = "[REDACTED]"
+
+// redactedKeys are attributes that are never logged, whatever their value.
+var redactedKeys = map[string]bool{
+	"qr_string":     true,
+	"qris_string":   true,
+	"mpan":          true,
+	"nmid":          true,
+	"mobile_number": true,
+}
+
+var (
+	redactQRStringPattern = regexp.MustCompile(`000201\d{2}.*?(6304[0-9A-Fa-f]{4}|$)`)
+	redactNMIDPattern     = regexp.MustCompile(`\bID\d{10,15}\b`)
+	redactMPANPattern     = regexp.MustCompile(`\b\d{15,19}\b`)
+	redactMobilePattern   = regexp.MustCompile(`(\+62|\b62|\b0)8\d{7,11}\b`)
+)
+
+// Redact masks QR strings, NMIDs, MPANs and Indonesian mobile numbers found
+// anywhere in value. Identifiers keep their last four characters so support
+// can still tell them apart.
+func Redact(value string) string {
+	value = redactQRStringPattern.ReplaceAllString(value, "[REDACTED QR STRING]")
+	for _, pattern := range []*regexp.Regexp{redactNMIDPattern, redactMPANPattern, redactMobilePattern} {
+		value = pattern.ReplaceAllStringFunc(value, func(match string) string {
+			return strings.Repeat("*", len(match)-4) + match[len(match)-4:]
+		})
+	}
+
+	return value
+}
+
+// RedactAttr is a slog.HandlerOptions.ReplaceAttr that redacts sensitive keys
+// and every string value, the log message included.
+func RedactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if redactedKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, Redact(attr.Value.String()))
+	case slog.KindAny:
+		switch value := attr.Value.Any().(type) {
+		case error:
+			return slog.String(attr.Key, Redact(value.Error()))
+		case []string:
+			redacted := make([]string, len(value))
+			for i, item := range value {
+				redacted[i] = Redact(item)
+			}
+			return slog.Any(attr.Key, redacted)
+		}
+	}
+
+	return attr
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "Success: QR String",
+			value: "parsing " + testStickerContent.QRString + " failed",
+			want:  "parsing [REDACTED QR STRING] failed",
+		},
+		{
+			name:  "Success: QR String Without CRC",
+			value: "parsing 00020101021126630016COM.MEMBASUH.WWW",
+			want:  "parsing [REDACTED QR STRING]",
+		},
+		{
+			name:  "Success: NMID",
+			value: "NMID ID2020034073193",
+			want:  "NMID ***********3193",
+		},
+		{
+			name:  "Success: MPAN",
+			value: "invalid parse acquirer for content 9360000911000045150",
+			want:  "invalid parse acquirer for content ***************5150",
+		},
+		{
+			name:  "Success: Mobile Numbers",
+			value: "call 081234567890 or +6281234567890",
+			want:  "call ********7890 or **********7890",
+		},
+		{
+			name:  "Success: Nothing To Redact",
+			value: "merchant city exceeds 15 characters",
+			want:  "merchant city exceeds 15 characters",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Redact(test.value); got != test.want {
+				t.Errorf(expectedButGotMessage, "Redact()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRedactAttr(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&b, &slog.HandlerOptions{ReplaceAttr: RedactAttr}))
+
+	logger.Info("parsed "+testStickerContent.QRString,
+		"qr_string", "anything",
+		slog.Group("detail", "nmid", "ID2020034073193"),
+		"error", fmt.Errorf("invalid content 9360000911000045150"),
+		"issues", []string{"call 081234567890"},
+		"amount", 1337,
+	)
+
+	got := b.String()
+	for _, want := range []string{
+		`"msg":"parsed [REDACTED QR STRING]"`,
+		`"qr_string":"[REDACTED]"`,
+		`"detail":{"nmid":"[REDACTED]"}`,
+		`"error":"invalid content ***************5150"`,
+		`"issues":["call ********7890"]`,
+		`"amount":1337`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf(expectedButGotMessage, "RedactAttr() output to contain", want, got)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// A6 portrait in points, the size of the standard QRIS table sticker.
+	stickerWidth  = 297.64
+	stickerHeight = 419.53
+	stickerMargin = 20.0
+
+	stickerMinimumDPI = 72
+	stickerMaximumDPI = 1200
+)
+
+var (
+	stickerColorBlack = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+	stickerColorWhite = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	stickerColorGray  = color.RGBA{R: 0x4d, G: 0x4d, B: 0x4d, A: 0xff}
+	stickerColorRed   = color.RGBA{R: 0xe3, G: 0x1e, B: 0x25, A: 0xff}
+
+	stickerFontRegular = mustParseStickerFont("GoRegular", goregular.TTF)
+	stickerFontBold    = mustParseStickerFont("GoBold", gobold.TTF)
+)
+
+type Sticker struct {
+}
+
+type StickerContent struct {
+	QRString     string
+	MerchantName string
+	NMID         string
+	TerminalID   string
+}
+
+type StickerOptions struct {
+	Format               string `json:"format"`
+	DPI                  int    `json:"dpi"`
+	ErrorCorrectionLevel string `json:"error_correction_level"`
+}
+
+type StickerInterface interface {
+	Render(stickerContent *StickerContent, stickerOptions *StickerOptions) ([]byte, error)
+	RenderContext(ctx context.Context, stickerContent *StickerContent, stickerOptions *StickerOptions) ([]byte, error)
+	RenderBase64(stickerContent *StickerContent, stickerOptions *StickerOptions) (string, error)
+	RenderBase64Context(ctx context.Context, stickerContent *StickerContent, stickerOptions *StickerOptions) (string, error)
+}
+
+type stickerElement struct {
+	x        float64
+	y        float64
+	width    float64
+	height   float64
+	text     string
+	font     *stickerFont
+	fontSize float64
+	align    int
+	color    color.RGBA
+}
+
+type stickerFont struct {
+	name   string
+	data   []byte
+	font   *sfnt.Font
+	widths [95]int
+}
+
+const (
+	stickerAlignLeft = iota
+	stickerAlignCenter
+)
+
+func NewSticker() StickerInterface {
+	return &Sticker{}
+}
+
+func DefaultStickerOptions() *StickerOptions {
+	return &StickerOptions{
+		Format:               QRCodeFormatPDF,
+		DPI:                  300,
+		ErrorCorrectionLevel: "M",
+	}
+}
+
+// MergeStickerOptions returns stickerOptions with every set field of
+// overrides applied, nil or zero fields keeping their value.
+func MergeStickerOptions(stickerOptions *StickerOptions, overrides *StickerOptions) *StickerOptions {
+	merged := StickerOptions{}
+	if stickerOptions != nil {
+		merged = *stickerOptions
+	}
+	if overrides == nil {
+		return &merged
+	}
+
+	if overrides.Format != "" {
+		merged.Format = overrides.Format
+	}
+	if overrides.DPI > 0 {
+		merged.DPI = overrides.DPI
+	}
+	if overrides.ErrorCorrectionLevel != "" {
+		merged.ErrorCorrectionLevel = overrides.ErrorCorrectionLevel
+	}
+
+	return &merged
+}
+
+func (u *Sticker) Render(stickerContent *StickerContent, stickerOptions *StickerOptions) ([]byte, error) {
+	return u.RenderContext(context.Background(), stickerContent, stickerOptions)
+}
+
+// RenderContext is Render returning ctx.Err() once ctx is done, checked
+// between the rendering steps. Nil or zero options fall back to
+// DefaultStickerOptions.
+func (u *Sticker) RenderContext(ctx context.Context, stickerContent *StickerContent, stickerOptions *StickerOptions) ([]byte, error) {
+	stickerOptions = MergeStickerOptions(DefaultStickerOptions(), stickerOptions)
+	if stickerOptions.DPI < stickerMinimumDPI || stickerOptions.DPI > stickerMaximumDPI {
+		return nil, fmt.Errorf("sticker DPI must be between %d and %d", stickerMinimumDPI, stickerMaximumDPI)
+	}
+
+	elements, err := u.layout(stickerContent, stickerOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch stickerOptions.Format {
+	case QRCodeFormatPNG:
+		return u.renderPNG(ctx, elements, stickerOptions.DPI)
+	case QRCodeFormatPDF:
+		return u.renderPDF(ctx, elements)
+	default:
+		return nil, fmt.Errorf("unsupported sticker format %s", stickerOptions.Format)
+	}
+}
+
+func (u *Sticker) RenderBase64(stickerContent *StickerContent, stickerOptions *StickerOptions) (string, error) {
+	return u.RenderBase64Context(context.Background(), stickerContent, stickerOptions)
+}
+
+func (u *Sticker) RenderBase64Context(ctx context.Context, stickerContent *StickerContent, stickerOptions *StickerOptions) (string, error) {
+	stickerOptions = MergeStickerOptions(DefaultStickerOptions(), stickerOptions)
+	sticker, err := u.RenderContext(ctx, stickerContent, stickerOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:" + QRCodeFormatMediaTypes[stickerOptions.Format] + ";base64," + base64.StdEncoding.EncodeToString(sticker), nil
+}
+
+// layout places every element of the sticker in points, with the origin at
+// the top-left corner and text positioned by its baseline.
+func (u *Sticker) layout(stickerContent *StickerContent, stickerOptions *StickerOptions) ([]stickerElement, error) {
+	modules, err := encodeModules(stickerContent.QRString, stickerOptions.ErrorCorrectionLevel, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	contentWidth := stickerWidth - 2*stickerMargin
+	elements := []stickerElement{
+		{x: 0, y: 0, width: stickerWidth, height: stickerHeight, color: stickerColorWhite},
+		{x: stickerMargin, y: 52, text: "QRIS", font: stickerFontBold, fontSize: 34, align: stickerAlignLeft, color: stickerColorBlack},
+		{x: stickerMargin, y: 64, text: "QR Code Standar Pembayaran Nasional", font: stickerFontRegular, fontSize: 7, align: stickerAlignLeft, color: stickerColorGray},
+		{x: stickerMargin, y: 72, width: contentWidth, height: 2, color: stickerColorRed},
+	}
+
+	merchantName := stickerText(stickerContent.MerchantName)
+	merchantNameSize := 16.0
+	for merchantNameSize > 8 && stickerFontBold.textWidth(merchantName, merchantNameSize) > contentWidth {
+		merchantNameSize -= 0.5
+	}
+	elements = append(elements,
+		stickerElement{x: stickerWidth / 2, y: 100, text: merchantName, font: stickerFontBold, fontSize: merchantNameSize, align: stickerAlignCenter, color: stickerColorBlack},
+		stickerElement{x: stickerWidth / 2, y: 116, text: "NMID : " + stickerText(stickerContent.NMID), font: stickerFontRegular, fontSize: 10, align: stickerAlignCenter, color: stickerColorBlack},
+	)
+
+	qrCodeSize := stickerWidth - 88
+	qrCodeLeft := (stickerWidth - qrCodeSize) / 2
+	qrCodeTop := 124.0
+	moduleSize := qrCodeSize / float64(len(modules))
+	for _, rect := range mergeModules(modules) {
+		elements = append(elements, stickerElement{
+			x:      qrCodeLeft + float64(rect.x)*moduleSize,
+			y:      qrCodeTop + float64(rect.y)*moduleSize,
+			width:  float64(rect.width) * moduleSize,
+			height: float64(rect.height) * moduleSize,
+			color:  stickerColorBlack,
+		})
+	}
+
+	if stickerContent.TerminalID != "" {
+		elements = append(elements, stickerElement{x: stickerWidth / 2, y: qrCodeTop + qrCodeSize + 14, text: "TID : " + stickerText(stickerContent.TerminalID), font: stickerFontRegular, fontSize: 9, align: stickerAlignCenter, color: stickerColorBlack})
+	}
+
+	elements = append(elements,
+		stickerElement{x: stickerWidth / 2, y: stickerHeight - 48, text: "Cek aplikasi penyelenggara di: www.aspi-qris.id", font: stickerFontRegular, fontSize: 7, align: stickerAlignCenter, color: stickerColorGray},
+		stickerElement{x: 0, y: stickerHeight - 38, width: stickerWidth, height: 38, color: stickerColorRed},
+		stickerElement{x: stickerWidth / 2, y: stickerHeight - 14, text: "Satu QRIS untuk Semua", font: stickerFontBold, fontSize: 14, align: stickerAlignCenter, color: stickerColorWhite},
+	)
+
+	return elements, nil
+}
+
+func (u *Sticker) renderPNG(ctx context.Context, elements []stickerElement, dpi int) ([]byte, error) {
+	scale := float64(dpi) / 72
+	pixel := func(value float64) int {
+		return int(math.Round(value * scale))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, pixel(stickerWidth), pixel(stickerHeight)))
+	faces := map[string]font.Face{}
+	for _, element := range elements {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if element.text == "" {
+			rect := image.Rect(pixel(element.x), pixel(element.y), pixel(element.x+element.width), pixel(element.y+element.height))
+			draw.Draw(img, rect, image.NewUniform(element.color), image.Point{}, draw.Src)
+			continue
+		}
+
+		key := fmt.Sprintf("%s-%g", element.font.name, element.fontSize)
+		face, exists := faces[key]
+		if !exists {
+			var err error
+			face, err = opentype.NewFace(element.font.font, &opentype.FaceOptions{
+				Size:    element.fontSize,
+				DPI:     float64(dpi),
+				Hinting: font.HintingNone,
+			})
+			if err != nil {
+				return nil, err
+			}
+			faces[key] = face
+		}
+
+		x := element.x
+		if element.align == stickerAlignCenter {
+			x -= element.font.textWidth(element.text, element.fontSize) / 2
+		}
+		drawer := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(element.color),
+			Face: face,
+			Dot:  fixed.Point26_6{X: fixed.Int26_6(x * scale * 64), Y: fixed.Int26_6(element.y * scale * 64)},
+		}
+		drawer.DrawString(element.text)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *Sticker) renderPDF(ctx context.Context, elements []stickerElement) ([]byte, error) {
+	document := newPDFDocument()
+	fonts := map[*stickerFont]string{}
+	var resources strings.Builder
+	resources.WriteString("/Font << ")
+	for i, stickerFont := range []*stickerFont{stickerFontRegular, stickerFontBold} {
+		fontID, err := document.addTrueTypeFont(stickerFont.name, stickerFont.data, stickerFont.font, stickerFont.widths[:])
+		if err != nil {
+			return nil, err
+		}
+		fonts[stickerFont] = fmt.Sprintf("F%d", i+1)
+		fmt.Fprintf(&resources, "/F%d %d 0 R ", i+1, fontID)
+	}
+	resources.WriteString(">> ")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	for _, element := range elements {
+		if element.text == "" {
+			fmt.Fprintf(&content, "%s rg\n%s %s %s %s re f\n", pdfColor(element.color), pdfNumber(element.x), pdfNumber(stickerHeight-element.y-element.height), pdfNumber(element.width), pdfNumber(element.height))
+			continue
+		}
+
+		x := element.x
+		if element.align == stickerAlignCenter {
+			x -= element.font.textWidth(element.text, element.fontSize) / 2
+		}
+		fmt.Fprintf(&content, "%s rg\nBT /%s %s Tf %s %s Td (%s) Tj ET\n", pdfColor(element.color), fonts[element.font], pdfNumber(element.fontSize), pdfNumber(x), pdfNumber(stickerHeight-element.y), pdfString(element.text))
+	}
+
+	document.addPage(stickerWidth, stickerHeight, content.Bytes(), resources.String())
+
+	return document.bytes(), nil
+}
+
+func mustParseStickerFont(name string, data []byte) *stickerFont {
+	parsed, err := opentype.Parse(data)
+	if err != nil {
+		panic(err)
+	}
+
+	stickerFont := &stickerFont{
+		name: name,
+		data: data,
+		font: parsed,
+	}
+
+	var buf sfnt.Buffer
+	for r := rune(32); r <= 126; r++ {
+		index, err := parsed.GlyphIndex(&buf, r)
+		if err != nil {
+			panic(err)
+		}
+		advance, err := parsed.GlyphAdvance(&buf, index, fixed.I(1000), font.HintingNone)
+		if err != nil {
+			panic(err)
+		}
+		stickerFont.widths[r-32] = advance.Round()
+	}
+
+	return stickerFont
+}
+
+func (f *stickerFont) textWidth(text string, fontSize float64) float64 {
+	width := 0
+	for _, r := range text {
+		width += f.widths[r-32]
+	}
+
+	return float64(width) * fontSize / 1000
+}
+
+// stickerText keeps printable ASCII only, since both the PDF encoding and the
+// glyph width table cover that range.
+func stickerText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 32 || r > 126 {
+			return '?'
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSticker(t *testing.T) {
+	tests := []struct {
+		name string
+		want StickerInterface
+	}{
+		{
+			name: "Success",
+			want: &Sticker{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewSticker()
+
+			if u == nil {
+				t.Errorf(expectedReturnNonNil, "NewSticker", "StickerInterface")
+			}
+
+			got, ok := u.(*Sticker)
+			if !ok {
+				t.Errorf(expectedTypeAssertionErrorMessage, "*Sticker")
+			}
+
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf(expectedButGotMessage, "*Sticker", test.want, got)
+			}
+		})
+	}
+}
+
+func TestStickerRender(t *testing.T) {
+	tests := []struct {
+		name           string
+		stickerOptions *StickerOptions
+		wantError      error
+	}{
+		{
+			name:           "Error: DPI",
+			stickerOptions: &StickerOptions{Format: QRCodeFormatPNG, DPI: 10, ErrorCorrectionLevel: "M"},
+			wantError:      fmt.Errorf("sticker DPI must be between 72 and 1200"),
+		},
+		{
+			name:           "Error: Error Correction Level",
+			stickerOptions: &StickerOptions{Format: QRCodeFormatPNG, DPI: 72, ErrorCorrectionLevel: "X"},
+			wantError:      fmt.Errorf("unsupported QR code error correction level X"),
+		},
+		{
+			name:           "Error: Format",
+			stickerOptions: &StickerOptions{Format: QRCodeFormatSVG, DPI: 72, ErrorCorrectionLevel: "M"},
+			wantError:      fmt.Errorf("unsupported sticker format svg"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := &Sticker{}
+
+			_, err := u.Render(testStickerContent, test.stickerOptions)
+			if err == nil || err.Error() != test.wantError.Error() {
+				t.Errorf(expectedErrorButGotMessage, "Render()", test.wantError, err)
+			}
+		})
+	}
+
+	t.Run("Success: PNG", func(t *testing.T) {
+		u := &Sticker{}
+
+		got, err := u.Render(testStickerContent, &StickerOptions{Format: QRCodeFormatPNG, DPI: 144, ErrorCorrectionLevel: "M"})
+		if err != nil {
+			t.Fatalf(expectedErrorButGotMessage, "Render()", nil, err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(got))
+		if err != nil {
+			t.Fatalf(expectedErrorButGotMessage, "png.Decode()", nil, err)
+		}
+		if bounds := img.Bounds(); bounds.Dx() != 595 || bounds.Dy() != 839 {
+			t.Errorf(expectedButGotMessage, "Render() size", "595x839", bounds.Size())
+		}
+	})
+
+	t.Run("Success: PDF", func(t *testing.T) {
+		u := &Sticker{}
+
+		got, err := u.RenderBase64(testStickerContent, DefaultStickerOptions())
+		if err != nil {
+			t.Fatalf(expectedErrorButGotMessage, "RenderBase64()", nil, err)
+		}
+		if !strings.HasPrefix(got, "data:application/pdf;base64,") {
+			t.Errorf(expectedButGotMessage, "RenderBase64()", "data:application/pdf;base64,", got[:40])
+		}
+
+		sticker, _ := u.Render(testStickerContent, DefaultStickerOptions())
+		for _, want := range []string{
+			"/MediaBox [0 0 297.64 419.53]",
+			"/BaseFont /GoBold",
+			"(Sintas Store) Tj",
+			"(NMID : ID2020034073193) Tj",
+			"(TID : 0489371081) Tj",
+			"(Satu QRIS untuk Semua) Tj",
+		} {
+			if !bytes.Contains(sticker, []byte(want)) {
+				t.Errorf(expectedResponseToContain, want, "sticker PDF")
+			}
+		}
+	})
+}
+
+func TestMergeStickerOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides *StickerOptions
+		want      *StickerOptions
+	}{
+		{
+			name:      "Success: Nil",
+			overrides: nil,
+			want:      DefaultStickerOptions(),
+		},
+		{
+			name:      "Success: Zero",
+			overrides: &StickerOptions{},
+			want:      DefaultStickerOptions(),
+		},
+		{
+			name:      "Success: Overrides",
+			overrides: &StickerOptions{Format: QRCodeFormatPNG, DPI: 72, ErrorCorrectionLevel: "H"},
+			want:      &StickerOptions{Format: QRCodeFormatPNG, DPI: 72, ErrorCorrectionLevel: "H"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MergeStickerOptions(DefaultStickerOptions(), test.overrides); !reflect.DeepEqual(got, test.want) {
+				t.Errorf(expectedButGotMessage, "MergeStickerOptions()", test.want, got)
+			}
+		})
+	}
+}
+
+func TestStickerText(t *testing.T) {
+	got := stickerText("Warung Bu Sri (Cabang 2)\n€")
+	want := "Warung Bu Sri (Cabang 2)??"
+	if got != want {
+		t.Errorf(expectedButGotMessage, "stickerText()", want, got)
+	}
+}
+
+func TestStickerRenderContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	u := &Sticker{}
+	for _, format := range []string{QRCodeFormatPNG, QRCodeFormatPDF} {
+		stickerOptions := &StickerOptions{Format: format, DPI: 72, ErrorCorrectionLevel: "M"}
+		if _, err := u.RenderBase64Context(ctx, testStickerContent, stickerOptions); !errors.Is(err, context.Canceled) {
+			t.Errorf(expectedErrorButGotMessage, "RenderBase64Context() "+format, context.Canceled, err)
+		}
+	}
+}
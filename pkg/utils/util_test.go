@@ -1,11 +1,104 @@
 package utils
 
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+)
+
 var (
 	expectedButGotMessage             = "Expected %v = %v, but got = %v"
 	expectedErrorButGotMessage        = "Expected %v error = %v, but got = %v"
 	expectedTypeAssertionErrorMessage = "Expected type assertion error, but got = %v"
 	expectedReturnNonNil              = "Expected %v to return a non-nil %v"
+	expectedResponseToContain         = "Expected response to contain %s, but got %s"
 
-	testQRString = "QR String"
-	testQRCode   = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAH0AAAB9EAAAAAD6e++6AAAB1UlEQVR4nOyc7WrEMAwEm3Lv/8rpT1NQlRWylcLs/DxyjocF4a/4c99fUL7f7sB7WJ2I1YlYnYjViXyiH69rR9NrnLjai35T/7unLwtw6lYnAlYPy9yiPqWNypLaSv7cnr4swKlbnQhY/aHMLdTRl/rfznit05cFOHWrEwGry2XuHPnk9hzg1K1OBKw+XOai8jVb3Bbg1K1OBKwul7lOCVInqGrB21MOwalbnQhY/aHM7dnnzHda60VwD+DUrU4ErH69fzy4szfbAZy61YmA1Vvn5tSVts5eav6OvFd5K+DUrU4ErC6P5tTyNXsAWO1LBDh1qxMBqz+M5upjqXPHgzureR7N/cLqRMDq8hZEfZQWtZI/p7LnCAo4dasTAatv+tgrXw+rT3N3f9MaAU7d6kTA6iM7reokuLPqV1+lA6dudSJg9bDMnbsVRH1HZ4qsAk7d6kTA6iM3lHTekRe8ThEEp251ImD1kRtK6u+Y+JwfnLrViYDVRz7dr6/N1VupPwdO3epEwOrDN5TUd1qj56L2on3dHHDqVicCVh+5oSRqpVPw1F75ePAfWJ0IWH3khpKIc5sb6rgOnLrViYDV/8ENJW8BTt3qRKxOxOpErE7kJwAA//+8Eaz8VoRFQgAAAABJRU5ErkJggg=="
+	testQRString       = "QR String"
+	testStickerContent = &StickerContent{
+		QRString:     "00020101021126630016COM.MEMBASUH.WWW0118936000091100004515021004893710810303UMI51440014ID.CO.QRIS.WWW0215ID20200340731930303UKE5204482953033605802ID5912Sintas Store6015Kota Yogyakarta61055500062070703A0163049FB7",
+		MerchantName: "Sintas Store",
+		NMID:         "ID2020034073193",
+		TerminalID:   "0489371081",
+	}
+	testQRCodeSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="125" height="125" viewBox="0 0 21 21" shape-rendering="crispEdges"><rect width="21" height="21" fill="#ffffff"/><path fill="#000000" d="M0 0h7v1h-7zM8 0h1v1h-1zM10 0h3v1h-3zM14 0h7v1h-7zM0 1h1v5h-1zM6 1h1v5h-1zM10 1h2v1h-2zM14 1h1v5h-1zM20 1h1v5h-1zM2 2h3v3h-3zM8 2h2v2h-2zM11 2h1v1h-1zM16 2h3v3h-3zM12 3h1v1h-1zM8 4h1v1h-1zM11 4h1v1h-1zM9 5h4v1h-4zM0 6h7v1h-7zM8 6h1v1h-1zM10 6h1v1h-1zM12 6h1v1h-1zM14 6h7v1h-7zM11 7h2v1h-2zM0 8h4v1h-4zM6 8h1v1h-1zM8 8h6v1h-6zM16 8h3v1h-3zM20 8h1v1h-1zM2 9h1v1h-1zM4 9h2v1h-2zM7 9h3v1h-3zM11 9h6v1h-6zM18 9h2v1h-2zM0 10h1v1h-1zM2 10h6v1h-6zM11 10h1v2h-1zM15 10h1v1h-1zM17 10h4v1h-4zM0 11h2v1h-2zM3 11h3v1h-3zM8 11h2v1h-2zM15 11h2v1h-2zM0 12h1v1h-1zM3 12h2v1h-2zM6 12h1v1h-1zM9 12h1v1h-1zM12 12h1v1h-1zM14 12h3v1h-3zM8 13h1v1h-1zM11 13h1v1h-1zM13 13h2v1h-2zM16 13h2v1h-2zM19 13h2v1h-2zM0 14h7v1h-7zM10 14h3v1h-3zM14 14h3v1h-3zM0 15h1v5h-1zM6 15h1v5h-1zM10 15h1v2h-1zM15 15h5v1h-5zM2 16h3v3h-3zM12 16h1v1h-1zM14 16h1v1h-1zM8 17h1v1h-1zM10 17h2v1h-2zM14 17h2v1h-2zM19 17h1v1h-1zM8 18h3v1h-3zM12 18h1v1h-1zM14 18h1v1h-1zM17 18h1v1h-1zM8 19h1v2h-1zM10 19h1v1h-1zM13 19h1v2h-1zM20 19h1v1h-1zM0 20h7v1h-7zM15 20h4v1h-4z"/></svg>`
+	testQRCode = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAH0AAAB9EAAAAAD6e++6AAAB1UlEQVR4nOyc7WrEMAwEm3Lv/8rpT1NQlRWylcLs/DxyjocF4a/4c99fUL7f7sB7WJ2I1YlYnYjViXyiH69rR9NrnLjai35T/7unLwtw6lYnAlYPy9yiPqWNypLaSv7cnr4swKlbnQhY/aHMLdTRl/rfznit05cFOHWrEwGry2XuHPnk9hzg1K1OBKw+XOai8jVb3Bbg1K1OBKwul7lOCVInqGrB21MOwalbnQhY/aHM7dnnzHda60VwD+DUrU4ErH69fzy4szfbAZy61YmA1Vvn5tSVts5eav6OvFd5K+DUrU4ErC6P5tTyNXsAWO1LBDh1qxMBqz+M5upjqXPHgzureR7N/cLqRMDq8hZEfZQWtZI/p7LnCAo4dasTAatv+tgrXw+rT3N3f9MaAU7d6kTA6iM7reokuLPqV1+lA6dudSJg9bDMnbsVRH1HZ4qsAk7d6kTA6iM3lHTekRe8ThEEp251ImD1kRtK6u+Y+JwfnLrViYDVRz7dr6/N1VupPwdO3epEwOrDN5TUd1qj56L2on3dHHDqVicCVh+5oSRqpVPw1F75ePAfWJ0IWH3khpKIc5sb6rgOnLrViYDV/8ENJW8BTt3qRKxOxOpErE7kJwAA//+8Eaz8VoRFQgAAAABJRU5ErkJggg=="
 )
+
+func testQRCodeOptions(format string, size int) *QRCodeOptions {
+	return &QRCodeOptions{
+		Format:               format,
+		Size:                 size,
+		ErrorCorrectionLevel: "L",
+		ForegroundColor:      "#000000",
+		BackgroundColor:      "#FFFFFF",
+	}
+}
+
+// testLogo draws a dark checkerboard, the worst case for a logo since every
+// module it covers reads as a coin toss.
+func testLogo(format string, width int, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (x/8+y/8)%2 == 0 {
+				img.Set(x, y, color.NRGBA{R: 0xe3, G: 0x1e, B: 0x25, A: 0xff})
+			} else {
+				img.Set(x, y, color.NRGBA{A: 0xff})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		jpeg.Encode(&buf, img, nil)
+	} else {
+		png.Encode(&buf, img)
+	}
+
+	return buf.Bytes()
+}
+
+// testPhoto places an image rotated, scaled and optionally mirrored on a
+// larger grey canvas with a lighting gradient, roughly what a phone camera
+// would capture, and encodes it as JPEG.
+func testPhoto(imageData []byte, angle float64, scale float64, mirror bool) []byte {
+	src, _, _ := image.Decode(bytes.NewReader(imageData))
+	bounds := src.Bounds()
+	size := int(float64(bounds.Dx())*scale*1.5) + 40
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.RGBA{R: 0xb4, G: 0xb9, B: 0xaa, A: 0xff}), image.Point{}, draw.Src)
+
+	cos, sin := math.Cos(angle)*scale, math.Sin(angle)*scale
+	flip := 1.0
+	if mirror {
+		flip = -1
+	}
+	centerX, centerY := float64(bounds.Dx())/2, float64(bounds.Dy())/2
+	transform := f64.Aff3{
+		flip * cos, -sin, float64(size)/2 - (flip*cos*centerX - sin*centerY),
+		flip * sin, cos, float64(size)/2 - (flip*sin*centerX + cos*centerY),
+	}
+	draw.BiLinear.Transform(dst, transform, src, bounds, draw.Over, nil)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			offset := dst.PixOffset(x, y)
+			for i := 0; i < 3; i++ {
+				dst.Pix[offset+i] = uint8(max(int(dst.Pix[offset+i])-x*60/size, 0))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 75})
+
+	return buf.Bytes()
+}